@@ -0,0 +1,185 @@
+package libaudit
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// archNames maps the AUDIT_ARCH_* values to their machine names
+var archNames = map[uint32]string{
+	AUDIT_ARCH_X86_64:  "x86_64",
+	AUDIT_ARCH_I386:    "i386",
+	AUDIT_ARCH_AARCH64: "aarch64",
+	AUDIT_ARCH_ARM:     "arm",
+	AUDIT_ARCH_PPC64:   "ppc64",
+	AUDIT_ARCH_PPC64LE: "ppc64le",
+	AUDIT_ARCH_PPC:     "ppc",
+	AUDIT_ARCH_S390X:   "s390x",
+	AUDIT_ARCH_S390:    "s390",
+	AUDIT_ARCH_RISCV64: "riscv64",
+}
+
+// compatArch maps 64 bit archs to the arch of their 32 bit compat syscall ABI (b32 in rules)
+var compatArch = map[uint32]uint32{
+	AUDIT_ARCH_X86_64:  AUDIT_ARCH_I386,
+	AUDIT_ARCH_AARCH64: AUDIT_ARCH_ARM,
+	AUDIT_ARCH_PPC64:   AUDIT_ARCH_PPC,
+	AUDIT_ARCH_S390X:   AUDIT_ARCH_S390,
+}
+
+// machineToArch converts uname machine names and GOARCH values to the AUDIT_ARCH_* value
+func machineToArch(machine string) (uint32, bool) {
+	switch machine {
+	case "x86_64", "amd64":
+		return AUDIT_ARCH_X86_64, true
+	case "i386", "i486", "i586", "i686", "386":
+		return AUDIT_ARCH_I386, true
+	case "aarch64", "arm64":
+		return AUDIT_ARCH_AARCH64, true
+	case "ppc64le":
+		return AUDIT_ARCH_PPC64LE, true
+	case "ppc64":
+		return AUDIT_ARCH_PPC64, true
+	case "ppc":
+		return AUDIT_ARCH_PPC, true
+	case "s390x":
+		return AUDIT_ARCH_S390X, true
+	case "s390":
+		return AUDIT_ARCH_S390, true
+	case "riscv64":
+		return AUDIT_ARCH_RISCV64, true
+	}
+	// arm, armv6l, armv7l ...
+	if strings.HasPrefix(machine, "arm") {
+		return AUDIT_ARCH_ARM, true
+	}
+	return 0, false
+}
+
+var (
+	nativeArchOnce  sync.Once
+	nativeArchName  string
+	nativeArchAudit uint32
+)
+
+// NativeArch returns the machine name and the AUDIT_ARCH_* value of the running kernel.
+// The kernel is asked via uname, GOARCH is used if the machine is not known.
+// Rules without an arch field resolve syscalls with the table of this arch.
+func NativeArch() (string, uint32) {
+	nativeArchOnce.Do(func() {
+		var uts syscall.Utsname
+		if err := syscall.Uname(&uts); err == nil {
+			var machine []byte
+			for _, c := range uts.Machine {
+				if c == 0 {
+					break
+				}
+				machine = append(machine, byte(c))
+			}
+			if arch, ok := machineToArch(string(machine)); ok {
+				nativeArchName, nativeArchAudit = archNames[arch], arch
+				return
+			}
+		}
+		if arch, ok := machineToArch(runtime.GOARCH); ok {
+			nativeArchName, nativeArchAudit = archNames[arch], arch
+			return
+		}
+		nativeArchName = runtime.GOARCH
+	})
+	return nativeArchName, nativeArchAudit
+}
+
+// auditNameToArch converts the arch field values used in rules to the AUDIT_ARCH_* value.
+// b64 (64) and b32 (32) are relative to the native arch, machine names (x86_64, aarch64)
+// and AUDIT_ARCH_* values are accepted as well.
+func auditNameToArch(fieldval interface{}) (uint32, error) {
+	var name string
+	switch val := fieldval.(type) {
+	case float64:
+		name = strconv.FormatUint(uint64(val), 10)
+	case string:
+		name = val
+	default:
+		return 0, errors.Wrap(errUnset, fmt.Sprintf("auditNameToArch failed to set: %v", fieldval))
+	}
+	_, native := NativeArch()
+	switch name {
+	case "b64", "64":
+		if native&__AUDIT_ARCH_64BIT == 0 {
+			return 0, fmt.Errorf("auditNameToArch failed: b64 is not supported on %v", archNames[native])
+		}
+		return native, nil
+	case "b32", "32":
+		if native&__AUDIT_ARCH_64BIT == 0 {
+			return native, nil
+		}
+		if arch, ok := compatArch[native]; ok {
+			return arch, nil
+		}
+		return 0, fmt.Errorf("auditNameToArch failed: b32 is not supported on %v", archNames[native])
+	}
+	if arch, ok := machineToArch(name); ok {
+		return arch, nil
+	}
+	for arch, n := range archNames {
+		if n == name {
+			return arch, nil
+		}
+	}
+	if v, err := strconv.ParseUint(name, 0, 32); err == nil {
+		if _, ok := archNames[uint32(v)]; ok {
+			return uint32(v), nil
+		}
+	}
+	return 0, errors.Wrap(errNoStr, fmt.Sprintf("auditNameToArch failed: unsupported arch %v", name))
+}
+
+// archToName converts the arch field value to the auditctl notation,
+// b64/b32 for the native arch and its compat arch, the machine name otherwise
+func archToName(arch uint32) string {
+	_, native := NativeArch()
+	if arch == native {
+		if native&__AUDIT_ARCH_64BIT == 0 {
+			return "b32"
+		}
+		return "b64"
+	}
+	if compat, ok := compatArch[native]; ok && compat == arch {
+		return "b32"
+	}
+	if name, ok := archNames[arch]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%x", arch)
+}
+
+// parseArch converts the arch field of audit events (e.g. c000003e) to the AUDIT_ARCH_* value.
+// The field is the hex formatted value, not a memory dump, so it doesn't depend on the host byte order.
+func parseArch(fieldValue string) (uint32, error) {
+	arch, err := strconv.ParseUint(fieldValue, 16, 32)
+	if err != nil {
+		return 0, errors.Wrap(err, "arch parsing failed")
+	}
+	return uint32(arch), nil
+}
+
+// InterpretArch converts the hex formatted arch field of audit events (e.g. c000003e) to the machine
+// name of the arch (x86_64) as ausearch -i prints it.
+func InterpretArch(hex string) (string, error) {
+	arch, err := parseArch(hex)
+	if err != nil {
+		return "", errors.Wrap(err, "InterpretArch failed")
+	}
+	name, ok := archNames[arch]
+	if !ok {
+		return "", fmt.Errorf("InterpretArch failed: unknown arch 0x%x", arch)
+	}
+	return name, nil
+}
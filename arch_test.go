@@ -0,0 +1,98 @@
+package libaudit
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestMachineToArch(t *testing.T) {
+	for _, tt := range []struct {
+		machine  string
+		expected uint32
+	}{
+		{"amd64", AUDIT_ARCH_X86_64},
+		{"x86_64", AUDIT_ARCH_X86_64},
+		{"386", AUDIT_ARCH_I386},
+		{"i686", AUDIT_ARCH_I386},
+		{"arm", AUDIT_ARCH_ARM},
+		{"armv7l", AUDIT_ARCH_ARM},
+		{"arm64", AUDIT_ARCH_AARCH64},
+		{"aarch64", AUDIT_ARCH_AARCH64},
+		{"riscv64", AUDIT_ARCH_RISCV64},
+	} {
+		arch, ok := machineToArch(tt.machine)
+		if !ok || arch != tt.expected {
+			t.Errorf("machineToArch(%v): expected 0x%x, found 0x%x", tt.machine, tt.expected, arch)
+		}
+	}
+	if _, ok := machineToArch("vax"); ok {
+		t.Errorf("machineToArch: expected vax to be unknown")
+	}
+}
+
+func TestNativeArch(t *testing.T) {
+	name, arch := NativeArch()
+	expected, ok := machineToArch(runtime.GOARCH)
+	if !ok {
+		t.Skipf("skipping native arch test: unknown GOARCH %v", runtime.GOARCH)
+	}
+	// a 32 bit binary may run on a 64 bit kernel
+	if arch != expected && compatArch[arch] != expected {
+		t.Errorf("NativeArch: expected 0x%x for %v, found %v 0x%x", expected, runtime.GOARCH, name, arch)
+	}
+	if b64, err := auditNameToArch("b64"); arch&__AUDIT_ARCH_64BIT != 0 && (err != nil || b64 != arch) {
+		t.Errorf("auditNameToArch: expected b64 to be the native arch 0x%x, found 0x%x (%v)", arch, b64, err)
+	}
+}
+
+func TestParseArch(t *testing.T) {
+	for _, tt := range []struct {
+		value    string
+		expected uint32
+	}{
+		{"c000003e", AUDIT_ARCH_X86_64},
+		{"C000003E", AUDIT_ARCH_X86_64},
+		{"40000003", AUDIT_ARCH_I386},
+		{"c00000b7", AUDIT_ARCH_AARCH64},
+		{"80000016", AUDIT_ARCH_S390X},
+	} {
+		arch, err := parseArch(tt.value)
+		if err != nil || arch != tt.expected {
+			t.Errorf("parseArch(%v): expected 0x%x, found 0x%x (%v)", tt.value, tt.expected, arch, err)
+		}
+	}
+	if _, err := parseArch("x86_64"); err == nil {
+		t.Errorf("parseArch: expected error for x86_64")
+	}
+}
+
+func TestInterpretArch(t *testing.T) {
+	for value, expected := range map[string]string{
+		"c000003e": "x86_64",
+		"40000003": "i386",
+		"c00000b7": "aarch64",
+		"80000016": "s390x",
+		"c00000f3": "riscv64",
+	} {
+		if name, err := InterpretArch(value); err != nil || name != expected {
+			t.Errorf("InterpretArch(%v): expected %v, found %v (%v)", value, expected, name, err)
+		}
+	}
+	for _, value := range []string{"x86_64", "12345678", ""} {
+		if _, err := InterpretArch(value); err == nil {
+			t.Errorf("InterpretArch(%v): expected error", value)
+		}
+	}
+
+	// the parser interprets unknown archs without failing the event
+	for msg, expected := range map[string]string{
+		`audit(1464163771.720:020): arch=c000003e syscall=2 success=yes exit=3`: "x86_64",
+		`audit(1464163771.720:021): arch=40000003 syscall=5 success=yes exit=3`: "i386",
+		`audit(1464163771.720:022): arch=12345678 success=yes exit=3`:           "unknown-elf-type(12345678)",
+	} {
+		x, err := ParseAuditEvent(msg, AUDIT_SYSCALL, true)
+		if err != nil || x.Data["arch"] != expected {
+			t.Errorf("ParseAuditEvent(%v): expected arch %v, found %v (%v)", msg, expected, x, err)
+		}
+	}
+}
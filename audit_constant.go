@@ -4,6 +4,14 @@
 
 package libaudit
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
 const (
 	MAX_AUDIT_MESSAGE_LENGTH = 8970
 	AUDIT_MAX_FIELDS         = 64
@@ -45,9 +53,9 @@ const (
 	AUDIT_PERS                  = 10
 	AUDIT_FILTER_EXCLUDE        = 0x05
 	AUDIT_ARCH                  = 11
-	PATH_MAX                    = 4096
+	PATH_MAX                    = 4096 /* limit of watched paths, including the terminating null */
 	AUDIT_MSGTYPE               = 12
-	AUDIT_MAX_KEY_LEN           = 256
+	AUDIT_MAX_KEY_LEN           = 256 /* limit of rule keys */
 	AUDIT_PERM                  = 106
 	AUDIT_FILTERKEY             = 210
 	AUDIT_SUBJ_USER             = 13 /* security label user */
@@ -64,6 +72,7 @@ const (
 	AUDIT_OBJ_LEV_LOW           = 22
 	AUDIT_OBJ_LEV_HIGH          = 23
 	AUDIT_LOGINUID_SET          = 24
+	AUDIT_SESSIONID             = 25 /* audit session id, since kernel 4.10 */
 	AUDIT_DEVMAJOR              = 100
 	AUDIT_DEVMINOR              = 101
 	AUDIT_INODE                 = 102
@@ -90,15 +99,38 @@ const (
 	AUDIT_OPERATORS             = (AUDIT_EQUAL | AUDIT_NOT_EQUAL | AUDIT_BIT_MASK)
 	/* Status symbols */
 	/* Mask values */
-	AUDIT_STATUS_ENABLED       = 0x0001
-	AUDIT_STATUS_FAILURE       = 0x0002
-	AUDIT_STATUS_PID           = 0x0004
-	AUDIT_STATUS_RATE_LIMIT    = 0x0008
-	AUDIT_STATUS_BACKLOG_LIMIT = 0x0010
+	AUDIT_STATUS_ENABLED           = 0x0001
+	AUDIT_STATUS_FAILURE           = 0x0002
+	AUDIT_STATUS_PID               = 0x0004
+	AUDIT_STATUS_RATE_LIMIT        = 0x0008
+	AUDIT_STATUS_BACKLOG_LIMIT     = 0x0010
+	AUDIT_STATUS_BACKLOG_WAIT_TIME = 0x0020
+	/* Enabled states */
+	AUDIT_DISABLED = 0
+	AUDIT_ENABLED  = 1
+	AUDIT_LOCKED   = 2 /* configuration is immutable until reboot */
 	/* Failure-to-log actions */
 	AUDIT_FAIL_SILENT = 0
 	AUDIT_FAIL_PRINTK = 1
 	AUDIT_FAIL_PANIC  = 2
+	/* Multicast Netlink socket groups (default up to 32) */
+	AUDIT_NLGRP_NONE    = 0 /* Group 0 not used */
+	AUDIT_NLGRP_READLOG = 1 /* "best effort" read only socket */
+
+	/* audit_features (since kernel 3.13) */
+	AUDIT_FEATURE_VERSION             = 1
+	AUDIT_FEATURE_ONLY_UNSET_LOGINUID = 0
+	AUDIT_FEATURE_LOGINUID_IMMUTABLE  = 1
+	AUDIT_LAST_FEATURE                = AUDIT_FEATURE_LOGINUID_IMMUTABLE
+
+	/* feature bitmap of the audit status, the capabilities of the kernel */
+	AUDIT_FEATURE_BITMAP_BACKLOG_LIMIT     = 0x00000001
+	AUDIT_FEATURE_BITMAP_BACKLOG_WAIT_TIME = 0x00000002
+	AUDIT_FEATURE_BITMAP_EXECUTABLE_PATH   = 0x00000004 /* exe field, since kernel 4.3 */
+	AUDIT_FEATURE_BITMAP_EXCLUDE_EXTEND    = 0x00000008 /* fields other than msgtype on the exclude list */
+	AUDIT_FEATURE_BITMAP_SESSIONID_FILTER  = 0x00000010
+	AUDIT_FEATURE_BITMAP_LOST_RESET        = 0x00000020
+	AUDIT_FEATURE_BITMAP_FILTER_FS         = 0x00000040
 
 	/* distinguish syscall tables */
 	__AUDIT_ARCH_64BIT  = 0x80000000
@@ -130,6 +162,9 @@ const (
 	AUDIT_ARCH_SPARC   = (EM_SPARC)
 	AUDIT_ARCH_SPARC64 = (EM_SPARCV9 | __AUDIT_ARCH_64BIT)
 	AUDIT_ARCH_X86_64  = (EM_X86_64 | __AUDIT_ARCH_64BIT | __AUDIT_ARCH_LE)
+	AUDIT_ARCH_AARCH64 = (EM_AARCH64 | __AUDIT_ARCH_64BIT | __AUDIT_ARCH_LE)
+	AUDIT_ARCH_PPC64LE = (EM_PPC64 | __AUDIT_ARCH_64BIT | __AUDIT_ARCH_LE)
+	AUDIT_ARCH_RISCV64 = (EM_RISCV | __AUDIT_ARCH_64BIT | __AUDIT_ARCH_LE)
 	///Temporary Solution need to add linux/elf-em.h
 	EM_NONE  = 0
 	EM_M32   = 1
@@ -164,6 +199,7 @@ const (
 	EM_BLACKFIN    = 106    /* ADI Blackfin Processor */
 	EM_TI_C6000    = 140    /* TI C6X DSPs */
 	EM_AARCH64     = 183    /* ARM 64 bit */
+	EM_RISCV       = 243    /* RISC-V */
 	EM_FRV         = 0x5441 /* Fujitsu FR-V */
 	EM_AVR32       = 0x18ad /* Atmel AVR32 */
 
@@ -207,7 +243,11 @@ const (
  * 2600 - 2999 future user space (maybe integrity labels and related events)
  */
 
+// the generated String is renamed to builtinString, String consults the message types
+// registered with RegisterMessageType first
+
 //go:generate stringer -type=auditConstant
+//go:generate sed -i "s/^func (i auditConstant) String() string/func (i auditConstant) builtinString() string/" auditconstant_string.go
 type auditConstant uint16
 
 const (
@@ -347,25 +387,29 @@ const (
 	AUDIT_FIRST_KERN_CRYPTO_MSG auditConstant = 1600
 	AUDIT_LAST_KERN_CRYPTO_MSG  auditConstant = 1699
 
-	// AUDIT_FIRST_KERN_ANOM_MSG auditConstant = 1700
-	AUDIT_LAST_KERN_ANOM_MSG auditConstant = 1799
-	AUDIT_ANOM_PROMISCUOUS   auditConstant = 1700 /* Device changed promiscuous mode */
-	AUDIT_ANOM_ABEND         auditConstant = 1701 /* Process ended abnormally */
-	AUDIT_ANOM_LINK          auditConstant = 1702 /* Suspicious use of file links */
+	// the message types come before the range markers sharing their values
+	// so that stringer names the types after them
+	AUDIT_ANOM_PROMISCUOUS    auditConstant = 1700 /* Device changed promiscuous mode */
+	AUDIT_ANOM_ABEND          auditConstant = 1701 /* Process ended abnormally */
+	AUDIT_ANOM_LINK           auditConstant = 1702 /* Suspicious use of file links */
+	AUDIT_ANOM_CREAT          auditConstant = 1703 /* Suspicious file creation */
+	AUDIT_FIRST_KERN_ANOM_MSG auditConstant = 1700
+	AUDIT_LAST_KERN_ANOM_MSG  auditConstant = 1799
 
-	AUDIT_INTEGRITY_FIRST_MSG auditConstant = 1800
-	AUDIT_TINTEGRITY_LAST_MSG auditConstant = 1899
+	AUDIT_INTEGRITY_DATA        auditConstant = 1800 /* Data integrity verification */
+	AUDIT_INTEGRITY_METADATA    auditConstant = 1801 // Metadata integrity verification
+	AUDIT_INTEGRITY_STATUS      auditConstant = 1802 /* integrity enable status */
+	AUDIT_INTEGRITY_HASH        auditConstant = 1803 /* integrity HASH type */
+	AUDIT_INTEGRITY_PCR         auditConstant = 1804 /* PCR invalidation msgs */
+	AUDIT_INTEGRITY_RULE        auditConstant = 1805 /* Policy rule */
+	AUDIT_INTEGRITY_EVM_XATTR   auditConstant = 1806 /* New EVM-covered xattr */
+	AUDIT_INTEGRITY_POLICY_RULE auditConstant = 1807 /* IMA policy rules */
+	AUDIT_INTEGRITY_FIRST_MSG   auditConstant = 1800
+	AUDIT_TINTEGRITY_LAST_MSG   auditConstant = 1899
+	AUDIT_INTEGRITY_LAST_MSG    auditConstant = 1899
 
-	AUDIT_INTEGRITY_DATA     auditConstant = 1800 /* Data integrity verification */
-	AUDIT_INTEGRITY_METADATA auditConstant = 1801 // Metadata integrity verification
-	AUDIT_INTEGRITY_STATUS   auditConstant = 1802 /* integrity enable status */
-	AUDIT_INTEGRITY_HASH     auditConstant = 1803 /* integrity HASH type */
-	AUDIT_INTEGRITY_PCR      auditConstant = 1804 /* PCR invalidation msgs */
-	AUDIT_INTEGRITY_RULE     auditConstant = 1805 /* Policy rule */
-	AUDIT_KERNEL             auditConstant = 2000 /* Asynchronous audit record. NOT A REQUEST. */
+	AUDIT_KERNEL auditConstant = 2000 /* Asynchronous audit record. NOT A REQUEST. */
 
-	AUDIT_FIRST_ANOM_MSG           auditConstant = 2100
-	AUDIT_LAST_ANOM_MSG            auditConstant = 2199
 	AUDIT_ANOM_LOGIN_FAILURES      auditConstant = 2100 // Failed login limit reached
 	AUDIT_ANOM_LOGIN_TIME          auditConstant = 2101 // Login attempted at bad time
 	AUDIT_ANOM_LOGIN_SESSIONS      auditConstant = 2102 // Max concurrent sessions reached
@@ -384,9 +428,13 @@ const (
 	AUDIT_ANOM_DEL_ACCT            auditConstant = 2115 // Deleting an acct
 	AUDIT_ANOM_MOD_ACCT            auditConstant = 2116 // Changing an acct
 	AUDIT_ANOM_ROOT_TRANS          auditConstant = 2117 // User became root
+	AUDIT_ANOM_LOGIN_SERVICE       auditConstant = 2118 // Service acct attempted login
+	AUDIT_ANOM_LOGIN_ROOT          auditConstant = 2119 // Root login attempted
+	AUDIT_ANOM_ORIGIN_FAILURES     auditConstant = 2120 // Origin has too many failed login
+	AUDIT_ANOM_SESSION             auditConstant = 2121 // The user session is bad
+	AUDIT_FIRST_ANOM_MSG           auditConstant = 2100
+	AUDIT_LAST_ANOM_MSG            auditConstant = 2199
 
-	AUDIT_FIRST_ANOM_RESP        auditConstant = 2200
-	AUDIT_LAST_ANOM_RESP         auditConstant = 2299
 	AUDIT_RESP_ANOMALY           auditConstant = 2200 /* Anomaly not reacted to */
 	AUDIT_RESP_ALERT             auditConstant = 2201 /* Alert email was sent */
 	AUDIT_RESP_KILL_PROC         auditConstant = 2202 /* Kill program */
@@ -400,6 +448,8 @@ const (
 	AUDIT_RESP_EXEC              auditConstant = 2210 /* Execute a script */
 	AUDIT_RESP_SINGLE            auditConstant = 2211 /* Go to single user mode */
 	AUDIT_RESP_HALT              auditConstant = 2212 /* take the system down */
+	AUDIT_FIRST_ANOM_RESP        auditConstant = 2200
+	AUDIT_LAST_ANOM_RESP         auditConstant = 2299
 
 	AUDIT_FIRST_USER_LSPP_MSG    auditConstant = 2300
 	AUDIT_LAST_USER_LSPP_MSG     auditConstant = 2399
@@ -464,3 +514,73 @@ const (
 	AUDIT_COMPARE_EGID_TO_SGID     auditConstant = 24
 	AUDIT_COMPARE_SGID_TO_FSGID    auditConstant = 25
 )
+
+// registeredTypes holds the map[auditConstant]string of the names registered with RegisterMessageType,
+// it is replaced as a whole on registration so the lookups need no lock
+var (
+	registeredTypes   atomic.Value
+	registeredTypesMu sync.Mutex
+)
+
+// RegisterMessageType names the message type num, i.e. a record type of a newer kernel, so that the
+// receive loops accept it and name the events after it. name is the record type as in audit logs
+// (FANOTIFY, the AUDIT_ prefix is optional), an empty name removes the registration.
+// Registered names take precedence over the built-in ones, which they can override.
+// It is safe for concurrent use, the events parsed concurrently may carry either name though.
+func RegisterMessageType(num uint16, name string) error {
+	name = strings.TrimPrefix(name, "AUDIT_")
+	for _, c := range name {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return fmt.Errorf("RegisterMessageType failed: invalid name %q", name)
+		}
+	}
+	registeredTypesMu.Lock()
+	defer registeredTypesMu.Unlock()
+	old, _ := registeredTypes.Load().(map[auditConstant]string)
+	types := make(map[auditConstant]string, len(old)+1)
+	for t, n := range old {
+		types[t] = n
+	}
+	if len(name) == 0 {
+		delete(types, auditConstant(num))
+	} else {
+		types[auditConstant(num)] = "AUDIT_" + name
+	}
+	registeredTypes.Store(types)
+	return nil
+}
+
+// lookup returns the name of the constant, registered or built-in
+func (i auditConstant) lookup() (string, bool) {
+	if types, _ := registeredTypes.Load().(map[auditConstant]string); types != nil {
+		if str, ok := types[i]; ok {
+			return str, true
+		}
+	}
+	str, ok := _auditConstant_map[i]
+	return str, ok
+}
+
+// String returns the name of the constant (AUDIT_SYSCALL, ...), see RegisterMessageType.
+func (i auditConstant) String() string {
+	if str, ok := i.lookup(); ok {
+		return str
+	}
+	return i.builtinString()
+}
+
+// IsKnown reports whether the constant is known to the library, i.e. a message type with a name,
+// built-in or registered with RegisterMessageType.
+func (i auditConstant) IsKnown() bool {
+	_, ok := i.lookup()
+	return ok
+}
+
+// typeName returns the name of the message type without the AUDIT_ prefix (SYSCALL, PATH ...),
+// unknown message types are named UNKNOWN[<type>] like auditd does.
+func (i auditConstant) typeName() string {
+	if str, ok := i.lookup(); ok {
+		return str[6:]
+	}
+	return "UNKNOWN[" + strconv.Itoa(int(i)) + "]"
+}
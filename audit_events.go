@@ -1,9 +1,15 @@
 package libaudit
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"github.com/pkg/errors"
@@ -19,82 +25,604 @@ type EventCallback func(*AuditEvent, error, ...interface{})
 type RawEventCallback func(string, error, ...interface{})
 type RawEventTypeCallback func(uint16, string, error, ...interface{})
 
+// BatchEventCallback is similar to EventCallback but receives all the AuditEvent structs parsed from
+// the messages of a single receive call, in the order of the messages.
+type BatchEventCallback func([]*AuditEvent, error, ...interface{})
+
+// StoppableEventCallback is similar to EventCallback but the function can stop the receive loop
+// by returning an error, which is then returned by the loop.
+type StoppableEventCallback func(*AuditEvent, error, ...interface{}) error
+
 // AuditEvent holds a parsed audit message.
 // Serial holds the serial number for the message.
 // Timestamp holds the unix timestamp of the message.
 // Type indicates the type of the audit message.
 // Data holds a map of field values of audit messages where keys => field names and values => field values.
+// Records with a syscall field also get a syscall_name field, the name of the syscall in the table of the arch field.
 // Raw string holds the original audit message received from kernel.
+// Typed returns the event with Serial and Timestamp parsed, the Get methods of Data parse numeric fields.
 type AuditEvent struct {
 	Serial    string
 	Timestamp string
 	Type      string
-	Data      map[string]string
+	Data      EventData
 	Raw       string
 }
 
 //NewAuditEvent takes a NetlinkMessage passed from the netlink connection
 //and parses the data from the message header to return an AuditEvent struct.
 func NewAuditEvent(msg NetlinkMessage) (*AuditEvent, error) {
-	x, err := ParseAuditEvent(string(msg.Data[:]), auditConstant(msg.Header.Type), true)
-	if err != nil {
+	return newAuditEvent(msg, true)
+}
+
+// NewAuditEventNoRaw is similar to NewAuditEvent but leaves the Raw field of the AuditEvent empty.
+func NewAuditEventNoRaw(msg NetlinkMessage) (*AuditEvent, error) {
+	return newAuditEvent(msg, false)
+}
+
+// atomicBool is a setting read by the receive loops, it is safe for concurrent use
+type atomicBool struct {
+	v int32
+}
+
+func newAtomicBool(v bool) *atomicBool {
+	b := &atomicBool{}
+	b.set(v)
+	return b
+}
+
+func (b *atomicBool) set(v bool) {
+	var i int32
+	if v {
+		i = 1
+	}
+	atomic.StoreInt32(&b.v, i)
+}
+
+func (b *atomicBool) get() bool {
+	return atomic.LoadInt32(&b.v) != 0
+}
+
+// atomicDuration is a setting read by the receive loops, it is safe for concurrent use
+type atomicDuration struct {
+	v int64
+}
+
+func (d *atomicDuration) set(v time.Duration) {
+	atomic.StoreInt64(&d.v, int64(v))
+}
+
+func (d *atomicDuration) get() time.Duration {
+	return time.Duration(atomic.LoadInt64(&d.v))
+}
+
+// keepRaw controls whether the receivers set the Raw field of the events, see SetKeepRaw
+var keepRaw = newAtomicBool(true)
+
+// SetKeepRaw controls whether the receivers (including ProcessOnce and Decoder) set the Raw field of
+// the AuditEvent structs they deliver, it is set by default.
+func SetKeepRaw(keep bool) {
+	keepRaw.set(keep)
+}
+
+// acceptUnknownTypes controls whether messages of unknown types are parsed, see SetAcceptUnknownTypes
+var acceptUnknownTypes = newAtomicBool(false)
+
+// SetAcceptUnknownTypes controls whether NewAuditEvent and the receivers parse messages of types unknown to the
+// library (i.e. added by newer kernels) instead of returning an error for them. The Type of these events is the
+// numeric form UNKNOWN[<type>], GetRawAuditEvents forwards them as "type=<type> msg=...". By default they
+// are rejected, GetRawAuditEvents passes an "Unknown Type: <type>" error instead of the message.
+func SetAcceptUnknownTypes(accept bool) {
+	acceptUnknownTypes.set(accept)
+}
+
+// normalizeSentinels controls whether the receivers normalize the events, see SetNormalizeSentinels
+var normalizeSentinels = newAtomicBool(false)
+
+// SetNormalizeSentinels controls whether NewAuditEvent and the receivers (including ProcessOnce and Decoder)
+// remove the fields of the events meaning no value (unset ids, ?, (null) ...), see NormalizeSentinels.
+// By default the fields are delivered as reported.
+func SetNormalizeSentinels(normalize bool) {
+	normalizeSentinels.set(normalize)
+}
+
+// keepRawProctitle controls whether the proctitle fields are interpreted, see SetKeepRawProctitle
+var keepRawProctitle = newAtomicBool(false)
+
+// SetKeepRawProctitle controls whether interpreting events (ParseAuditEvent, NewAuditEvent and the receivers)
+// keeps the proctitle field of PROCTITLE records hex encoded as sent by the kernel. By default it is decoded
+// to the command line with the arguments separated by spaces, keeping it lets (*AuditEvent).Proctitle tell
+// the arguments apart exactly.
+func SetKeepRawProctitle(keep bool) {
+	keepRawProctitle.set(keep)
+}
+
+// lenientParsing controls whether events failing to be interpreted are delivered, see SetLenientParsing
+var lenientParsing = newAtomicBool(false)
+
+// SetLenientParsing controls whether NewAuditEvent and the receivers (including ProcessOnce and Decoder) deliver
+// events with fields that failed to be interpreted, see ParseAuditEventLenient. The callbacks then receive the
+// event along with ParseWarnings as error. By default the events are discarded and only the error is passed.
+func SetLenientParsing(lenient bool) {
+	lenientParsing.set(lenient)
+}
+
+// newAuditEvent is NewAuditEvent which sets Raw only if keepRaw is true
+func newAuditEvent(msg NetlinkMessage, keepRaw bool) (*AuditEvent, error) {
+	x, err := parseAuditEvent(string(msg.Data[:]), auditConstant(msg.Header.Type), true, keepRaw, lenientParsing.get())
+	if x == nil {
 		return nil, err
 	}
-	if (*x).Type == "auditConstant("+strconv.Itoa(int(msg.Header.Type))+")" {
+	if !acceptUnknownTypes.get() && !auditConstant(msg.Header.Type).IsKnown() {
 		return nil, fmt.Errorf("NewAuditEvent failed: unknown message type %d", msg.Header.Type)
 	}
+	if normalizeSentinels.get() {
+		x.NormalizeSentinels()
+	}
+
+	return x, err
+}
+
+// InterpretCapabilities replaces the hex formatted capability masks (cap_fp, cap_pe, ...) of an event
+// that was parsed without interpretation by the comma separated capability names (CAP_*), empty masks
+// by none like the interpretation prints them.
+func (e *AuditEvent) InterpretCapabilities() error {
+	for field, value := range e.Data {
+		if ftype, ok := fieldLookupMap[field]; !ok || ftype != typeCapBitmap {
+			continue
+		}
+		names, err := DecodeCapabilities(value)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("InterpretCapabilities failed for %v", field))
+		}
+		e.Data[field] = joinCapabilities(names)
+	}
+	return nil
+}
+
+// Receiver is the handle of a receive loop running inside a go-routine.
+// Stop asks the loop to exit, as the loop only checks for it between two receive calls
+// a receive timeout (SetsockRecvTO) bounds the time it takes to stop.
+// Stopped is closed once the loop has returned, i.e. when the netlink connection can be closed safely.
+type Receiver struct {
+	done    chan struct{}
+	stopped chan struct{}
+	stop    sync.Once
+}
+
+func newReceiver() *Receiver {
+	return &Receiver{
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Stop signals the receive loop to exit, it doesn't wait for it, see Wait.
+func (r *Receiver) Stop() {
+	r.stop.Do(func() { close(r.done) })
+}
+
+// Stopped returns a channel that is closed when the receive loop has returned.
+func (r *Receiver) Stopped() <-chan struct{} {
+	return r.stopped
+}
+
+// Wait blocks until the receive loop has returned.
+func (r *Receiver) Wait() {
+	<-r.stopped
+}
 
-	return x, nil
+// ErrIdle is passed to the callbacks of the receive loops (without event) when no message was received
+// within the idle timeout, see SetIdleTimeout.
+var ErrIdle = errors.New("no audit message received within the idle timeout")
+
+// idleTimeout is the interval of ErrIdle heartbeats, see SetIdleTimeout
+var idleTimeout atomicDuration
+
+// SetIdleTimeout makes the receive loops pass ErrIdle to their callback whenever no message was received
+// for d, so that supervisors can tell a quiet audit stream from a stalled loop. The loops then set the receive
+// timeout of the connection (SetsockRecvTO) to d when they start. 0 (the default) disables the heartbeat.
+// Loops already running keep the timeout they started with.
+func SetIdleTimeout(d time.Duration) {
+	idleTimeout.set(d)
+}
+
+// idleTimer holds the pacing state of a receive loop, the time since it received a message for the
+// ErrIdle heartbeats and its backoff after failed receives. A nil idleTimer never fires nor backs off.
+type idleTimer struct {
+	timeout time.Duration
+	last    time.Time
+	delay   time.Duration
+}
+
+// newIdleTimer returns the idleTimer of a receive loop on s, it fires if SetIdleTimeout was called
+func newIdleTimer(s Netlink) *idleTimer {
+	t := &idleTimer{last: time.Now()}
+	if d := idleTimeout.get(); d > 0 {
+		s.SetsockRecvTO(int64(d / time.Millisecond))
+		t.timeout = d
+	}
+	return t
+}
+
+// idle records the outcome of a receive call and reports whether the loop has been idle for the timeout,
+// then the next heartbeat is due after another timeout
+func (t *idleTimer) idle(received bool) bool {
+	if t == nil || t.timeout <= 0 {
+		return false
+	}
+	now := time.Now()
+	if received || now.Sub(t.last) < t.timeout {
+		if received {
+			t.last = now
+		}
+		return false
+	}
+	t.last = now
+	return true
+}
+
+// receivePollInterval bounds the time a receive loop waits for a connection in non-blocking mode to become
+// readable and backs off after failed receives, i.e. the time it takes to notice a stop signal
+const receivePollInterval = 100 * time.Millisecond
+
+// readableWaiter is implemented by connections which can wait for a message without receiving it
+type readableWaiter interface {
+	waitReadable(timeout time.Duration) error
+}
+
+// backoff is called by the receive loop on s with the error of every receive so that the loop doesn't spin
+// on a connection in non-blocking mode or on a persistent error. It waits for the connection to become
+// readable after ErrWouldBlock and sleeps after other errors, for up to receivePollInterval. Receive
+// timeouts (EAGAIN) already waited in the receive call.
+func (t *idleTimer) backoff(s Netlink, err error) {
+	cause := errors.Cause(err)
+	if t == nil || err == nil || cause == syscall.EAGAIN || cause == ErrClosed || cause == ErrEventsLost {
+		if t != nil {
+			t.delay = 0
+		}
+		return
+	}
+	if cause == ErrWouldBlock {
+		if w, ok := s.(readableWaiter); ok && w.waitReadable(receivePollInterval) == nil {
+			t.delay = 0
+			return
+		}
+	}
+	if t.delay == 0 {
+		t.delay = time.Millisecond
+	} else if t.delay *= 2; t.delay > receivePollInterval {
+		t.delay = receivePollInterval
+	}
+	time.Sleep(t.delay)
+}
+
+// suppressedTypes holds the map[uint16]bool of the message types dropped by the receivers, see
+// SuppressEventTypes, it is replaced as a whole so the lookups need no lock
+var suppressedTypes atomic.Value
+
+// SuppressEventTypes makes the receivers (including ProcessOnce and Decoder) drop messages of the given
+// types before parsing them, i.e. AUDIT_EOE markers which carry no fields. Calling it without types
+// restores the default of delivering every message.
+func SuppressEventTypes(types ...auditConstant) {
+	if len(types) == 0 {
+		suppressedTypes.Store(map[uint16]bool(nil))
+		return
+	}
+	suppressed := make(map[uint16]bool, len(types))
+	for _, t := range types {
+		suppressed[uint16(t)] = true
+	}
+	suppressedTypes.Store(suppressed)
+}
+
+// isSuppressed reports whether messages of msgType are dropped by the receivers
+func isSuppressed(msgType uint16) bool {
+	if suppressed, _ := suppressedTypes.Load().(map[uint16]bool); suppressed[msgType] {
+		debugf("skipping suppressed message of type %v", auditConstant(msgType))
+		return true
+	}
+	return false
+}
+
+// errorCoalescing is the window of coalescing repeated errors, see SetErrorCoalescing
+var errorCoalescing atomicDuration
+
+// SetErrorCoalescing makes the receive loops coalesce repeated identical errors passed to their callback without
+// event (i.e. a persistent error reported by the kernel), so a persisting condition doesn't flood the logs of the
+// callback. The first occurrence is passed at once, the following ones within window are counted and passed as
+// a single RepeatedError with the next occurrence after window, or before a different error. The errors passed
+// along with events (parse warnings) and ErrIdle are never coalesced. 0 (the default) disables the coalescing.
+// Loops already running keep the window they started with.
+func SetErrorCoalescing(window time.Duration) {
+	errorCoalescing.set(window)
+}
+
+// RepeatedError is passed to the callbacks of the receive loops in place of the occurrences of an error coalesced
+// since it was last passed, see SetErrorCoalescing.
+type RepeatedError struct {
+	Err   error // the last occurrence
+	Count int   // the number of occurrences coalesced
+}
+
+func (e *RepeatedError) Error() string {
+	return fmt.Sprintf("still failing (%d occurrences): %v", e.Count, e.Err)
+}
+
+// Cause returns the repeated error so that errors.Cause unwraps it
+func (e *RepeatedError) Cause() error {
+	return e.Err
+}
+
+// errorCoalescer coalesces the errors of one receive loop, a nil errorCoalescer passes every error
+type errorCoalescer struct {
+	window time.Duration
+	last   error
+	passed time.Time // when last was passed to the callback
+	count  int       // occurrences of last since
+}
+
+// newErrorCoalescer returns the errorCoalescer of a receive loop, nil if SetErrorCoalescing wasn't called
+func newErrorCoalescer() *errorCoalescer {
+	window := errorCoalescing.get()
+	if window <= 0 {
+		return nil
+	}
+	return &errorCoalescer{window: window}
+}
+
+// coalesce returns the errors to pass to the callback in place of err, none if it is coalesced
+func (c *errorCoalescer) coalesce(err error) []error {
+	if c == nil || err == ErrIdle {
+		return []error{err}
+	}
+	now := time.Now()
+	if c.last != nil && err.Error() == c.last.Error() {
+		c.last = err
+		c.count++
+		if now.Sub(c.passed) < c.window {
+			return nil
+		}
+		repeated := &RepeatedError{Err: err, Count: c.count}
+		c.passed, c.count = now, 0
+		return []error{repeated}
+	}
+	var errs []error
+	if c.count > 0 {
+		errs = append(errs, &RepeatedError{Err: c.last, Count: c.count})
+	}
+	c.last, c.passed, c.count = err, now, 0
+	return append(errs, err)
+}
+
+// coalesceEventErrors returns cb coalescing the errors passed without event
+func coalesceEventErrors(cb EventCallback) EventCallback {
+	c := newErrorCoalescer()
+	if c == nil {
+		return cb
+	}
+	return func(e *AuditEvent, err error, args ...interface{}) {
+		if e != nil || err == nil {
+			cb(e, err, args...)
+			return
+		}
+		for _, err := range c.coalesce(err) {
+			cb(nil, err, args...)
+		}
+	}
+}
+
+// coalesceStoppableErrors is coalesceEventErrors for StoppableEventCallback
+func coalesceStoppableErrors(cb StoppableEventCallback) StoppableEventCallback {
+	c := newErrorCoalescer()
+	if c == nil {
+		return cb
+	}
+	return func(e *AuditEvent, err error, args ...interface{}) error {
+		if e != nil || err == nil {
+			return cb(e, err, args...)
+		}
+		for _, err := range c.coalesce(err) {
+			if stop := cb(nil, err, args...); stop != nil {
+				return stop
+			}
+		}
+		return nil
+	}
+}
+
+// coalesceBatchErrors is coalesceEventErrors for BatchEventCallback, errors along with events are passed as they are
+func coalesceBatchErrors(cb BatchEventCallback) BatchEventCallback {
+	c := newErrorCoalescer()
+	if c == nil {
+		return cb
+	}
+	return func(batch []*AuditEvent, err error, args ...interface{}) {
+		if len(batch) > 0 || err == nil {
+			cb(batch, err, args...)
+			return
+		}
+		for _, err := range c.coalesce(err) {
+			cb(nil, err, args...)
+		}
+	}
+}
+
+// coalesceStatusErrors is coalesceEventErrors for StatusEventCallback
+func coalesceStatusErrors(cb StatusEventCallback) StatusEventCallback {
+	c := newErrorCoalescer()
+	if c == nil {
+		return cb
+	}
+	return func(e *AuditEvent, counters StatusCounters, err error, args ...interface{}) {
+		if e != nil || err == nil {
+			cb(e, counters, err, args...)
+			return
+		}
+		for _, err := range c.coalesce(err) {
+			cb(nil, counters, err, args...)
+		}
+	}
+}
+
+// coalesceRawErrors is coalesceEventErrors for RawEventCallback
+func coalesceRawErrors(cb RawEventCallback) RawEventCallback {
+	c := newErrorCoalescer()
+	if c == nil {
+		return cb
+	}
+	return func(m string, err error, args ...interface{}) {
+		if len(m) > 0 || err == nil {
+			cb(m, err, args...)
+			return
+		}
+		for _, err := range c.coalesce(err) {
+			cb("", err, args...)
+		}
+	}
+}
+
+// coalesceRawTypeErrors is coalesceEventErrors for RawEventTypeCallback, the messages reporting
+// errors are passed with the occurrence passed
+func coalesceRawTypeErrors(cb RawEventTypeCallback) RawEventTypeCallback {
+	c := newErrorCoalescer()
+	if c == nil {
+		return cb
+	}
+	return func(msgType uint16, m string, err error, args ...interface{}) {
+		if err == nil {
+			cb(msgType, m, err, args...)
+			return
+		}
+		for _, err := range c.coalesce(err) {
+			cb(msgType, m, err, args...)
+		}
+	}
 }
 
 // GetAuditEvents receives audit messages from the kernel and parses them to AuditEvent struct.
 // It passes them along the callback function and if any error occurs while receiving the message,
 // the same will be passed in the callback as well.
-// Code that receives the message runs inside a go-routine.
-func GetAuditEvents(s Netlink, cb EventCallback, args ...interface{}) {
+// Code that receives the message runs inside a go-routine which is controlled by the returned Receiver.
+func GetAuditEvents(s Netlink, cb EventCallback, args ...interface{}) *Receiver {
+	r := newReceiver()
 	go func() {
+		defer close(r.stopped)
 		rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+		idle := newIdleTimer(s)
+		cb := coalesceEventErrors(cb)
 
 		for {
 			select {
+			case <-r.done:
+				return
 			default:
-				msgs, err := s.Receive(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, rb)
-				if err == nil {
-					for _, msg := range msgs {
-						if msg.Header.Type == syscall.NLMSG_ERROR {
-							err := int32(nativeEndian().Uint32(msg.Data[0:4]))
-							if err != 0 {
-								cb(nil, fmt.Errorf("error receiving events %d", err), args...)
-							}
-						} else {
-							nae, err := NewAuditEvent(msg)
-							cb(nae, err, args...)
-						}
-					}
+				if err := processOnce(s, rb, idle, cb, args...); errors.Cause(err) == ErrClosed {
+					return
 				}
 			}
 		}
 	}()
+	return r
+}
+
+// ProcessOnce does a single receive on the netlink connection, parses the received messages to AuditEvent
+// structs and passes them along the callback function before returning. Errors reported by the kernel and
+// parsing errors are passed in the callback, an error receiving from the connection is returned. Lost events
+// (ErrEventsLost) are passed in the callback as well, the receive loops continue after them.
+// It doesn't spawn any go-routine and leaves scheduling (loops, pools) to the caller.
+func ProcessOnce(s Netlink, cb EventCallback, args ...interface{}) error {
+	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	return processOnce(s, rb, nil, cb, args...)
+}
+
+// processOnce is ProcessOnce receiving into rb, ErrIdle is passed to cb when idle fires
+func processOnce(s Netlink, rb []byte, idle *idleTimer, cb EventCallback, args ...interface{}) error {
+	_, err := processOnceStoppable(s, rb, idle, func(e *AuditEvent, err error, args ...interface{}) error {
+		cb(e, err, args...)
+		return nil
+	}, args...)
+	return err
+}
+
+// processOnceStoppable is processOnce with a StoppableEventCallback, the error of the callback
+// stops processing the received messages and is returned as stop
+func processOnceStoppable(s Netlink, rb []byte, idle *idleTimer, cb StoppableEventCallback, args ...interface{}) (stop error, err error) {
+	msgs, err := s.Receive(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, rb)
+	if err != nil {
+		if errors.Cause(err) != syscall.EAGAIN {
+			debugf("ProcessOnce: receive failed: %v", err)
+		}
+		if errors.Cause(err) == ErrEventsLost {
+			stop = cb(nil, err, args...)
+		} else if errors.Cause(err) != ErrClosed && idle.idle(false) {
+			stop = cb(nil, ErrIdle, args...)
+		}
+		idle.backoff(s, err)
+		return stop, errors.Wrap(err, "ProcessOnce failed")
+	}
+	idle.idle(true)
+	idle.backoff(s, nil)
+	observeMessages(msgs)
+	for _, msg := range msgs {
+		if isSuppressed(msg.Header.Type) {
+			continue
+		}
+		if msg.Header.Type == syscall.NLMSG_ERROR {
+			v := int32(nativeEndian().Uint32(msg.Data[0:4]))
+			if v != 0 {
+				stop = cb(nil, fmt.Errorf("error receiving events %d", v), args...)
+			}
+		} else {
+			nae, err := newAuditEvent(msg, keepRaw.get())
+			observeEvent(nae, err)
+			stop = cb(nae, err, args...)
+		}
+		if stop != nil {
+			return stop, nil
+		}
+	}
+	return nil, nil
 }
 
 // GetRawAuditEvents receives raw audit messages from kernel parses them to AuditEvent struct.
 // It passes them along the callback function and if any error occurs while receiving the message,
 // the same will be passed in the callback as well.
-// Code that receives the message runs inside a go-routine.
-func GetRawAuditEvents(s Netlink, cb RawEventCallback, args ...interface{}) {
+// Code that receives the message runs inside a go-routine which is controlled by the returned Receiver.
+func GetRawAuditEvents(s Netlink, cb RawEventCallback, args ...interface{}) *Receiver {
+	r := newReceiver()
 	go func() {
+		defer close(r.stopped)
 		rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+		idle := newIdleTimer(s)
+		cb := coalesceRawErrors(cb)
 
 		for {
 			select {
+			case <-r.done:
+				return
 			default:
 				msgs, err := s.Receive(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, rb)
+				if err == ErrClosed {
+					return
+				}
+				if errors.Cause(err) == ErrEventsLost {
+					cb("", err, args...)
+				}
+				if idle.idle(err == nil) {
+					cb("", ErrIdle, args...)
+				}
+				idle.backoff(s, err)
 				if err == nil {
+					observeMessages(msgs)
 					for _, msg := range msgs {
 						var (
 							m   string
 							err error
 						)
+						if isSuppressed(msg.Header.Type) {
+							continue
+						}
 						if msg.Header.Type == syscall.NLMSG_ERROR {
 							v := int32(nativeEndian().Uint32(msg.Data[0:4]))
 							if v != 0 {
@@ -102,10 +630,17 @@ func GetRawAuditEvents(s Netlink, cb RawEventCallback, args ...interface{}) {
 							}
 						} else {
 							Type := auditConstant(msg.Header.Type)
-							if Type.String() == "auditConstant("+strconv.Itoa(int(msg.Header.Type))+")" {
-								err = errors.New("Unknown Type: " + string(msg.Header.Type))
-							} else {
-								m = "type=" + Type.String()[6:] + " msg=" + string(msg.Data[:]) + "\n"
+							switch {
+							case Type.IsKnown():
+								m = "type=" + Type.typeName() + " msg=" + string(msg.Data[:]) + "\n"
+								currentMetrics().IncEvent(Type.typeName())
+							case acceptUnknownTypes.get():
+								// forwarded with the numeric type so no record is lost
+								m = "type=" + strconv.Itoa(int(msg.Header.Type)) + " msg=" + string(msg.Data[:]) + "\n"
+								currentMetrics().IncEvent(Type.typeName())
+							default:
+								err = errors.New("Unknown Type: " + strconv.Itoa(int(msg.Header.Type)))
+								currentMetrics().IncParseError()
 							}
 						}
 						cb(m, err, args...)
@@ -114,6 +649,7 @@ func GetRawAuditEvents(s Netlink, cb RawEventCallback, args ...interface{}) {
 			}
 		}
 	}()
+	return r
 }
 
 // GetRawAuditEvents receives raw audit messages from kernel parses them to AuditEvent struct.
@@ -122,79 +658,218 @@ func GetRawAuditEvents(s Netlink, cb RawEventCallback, args ...interface{}) {
 // Code that receives the message runs inside a go-routine.
 func GetRawAuditMessages(s Netlink, cb RawEventTypeCallback, done *chan bool, args ...interface{}) {
 	//rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	idle := newIdleTimer(s)
+	cb = coalesceRawTypeErrors(cb)
 
 	for {
 		select {
 		case <-*done:
-			//fmt.Printf("Loop Done %v\n", info)
+			debugf("GetRawAuditMessages: done")
 			return
 		default:
-			//fmt.Printf("Loop Receive\n")
-			b, err := s.ReceiveNoParse(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
-			if err == nil {
-				for len(b) >= syscall.NLMSG_HDRLEN {
-					h := (*syscall.NlMsghdr)(unsafe.Pointer(&b[0]))
-					if int(h.Len) < syscall.NLMSG_HDRLEN || int(h.Len) > len(b) {
-						break
-					}
-					b = b[syscall.NLMSG_HDRLEN:]
-					dlen := nlmAlignOf(int(h.Len)) - syscall.NLMSG_HDRLEN
+			if err := processRawOnce(s, idle, cb, args...); err == ErrClosed {
+				return
+			}
+		}
+	}
+}
 
-					if err != nil {
-						break
-					}
-					if len(b) == int(h.Len) || dlen == int(h.Len) {
-						// this should never be possible in correct scenarios
-						// but sometimes kernel reponse have length of header == length of data appended
-						// which would lead to trimming of data if we subtract NLMSG_HDRLEN
-						// therefore following workaround
-						//m = NetlinkMessage{Header: *h, Data: dbuf[:int(h.Len)]}
-						if h.Type == syscall.NLMSG_ERROR {
-							v := int32(nativeEndian().Uint32(b[0:4]))
-							if v != 0 {
-								cb(h.Type, string(b[:h.Len]), fmt.Errorf("error receiving events %d", v), args...)
-							}
-						} else {
-							cb(h.Type, string(b[:int(h.Len)]), nil, args...)
-						}
-					} else {
-						//m = NetlinkMessage{Header: *h, Data: dbuf[:int(h.Len)-syscall.NLMSG_HDRLEN]}
-						if h.Type == syscall.NLMSG_ERROR {
-							v := int32(nativeEndian().Uint32(b[0:4]))
-							if v != 0 {
-								cb(h.Type, string(b[:int(h.Len)-syscall.NLMSG_HDRLEN]), fmt.Errorf("error receiving events %d", v), args...)
-							}
-						} else {
-							cb(h.Type, string(b[:int(h.Len)-syscall.NLMSG_HDRLEN]), nil, args...)
-						}
+// processRawOnce is the body of the receive loop of GetRawAuditMessages, it returns the receive error
+func processRawOnce(s Netlink, idle *idleTimer, cb RawEventTypeCallback, args ...interface{}) error {
+	b, err := s.ReceiveNoParse(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+	if err == ErrClosed {
+		return err
+	}
+	if errors.Cause(err) == ErrEventsLost {
+		cb(0, "", err, args...)
+	}
+	if idle.idle(err == nil) {
+		cb(0, "", ErrIdle, args...)
+	}
+	idle.backoff(s, err)
+	if err == nil {
+		currentMetrics().AddBytesRead(len(b))
+		for len(b) >= syscall.NLMSG_HDRLEN {
+			h := (*syscall.NlMsghdr)(unsafe.Pointer(&b[0]))
+			if int(h.Len) < syscall.NLMSG_HDRLEN || int(h.Len) > len(b) {
+				break
+			}
+			b = b[syscall.NLMSG_HDRLEN:]
+			dlen := nlmAlignOf(int(h.Len)) - syscall.NLMSG_HDRLEN
+
+			if err != nil {
+				break
+			}
+			if isSuppressed(h.Type) {
+				b = b[dlen:]
+				continue
+			}
+			if len(b) == int(h.Len) || dlen == int(h.Len) {
+				// this should never be possible in correct scenarios
+				// but sometimes kernel reponse have length of header == length of data appended
+				// which would lead to trimming of data if we subtract NLMSG_HDRLEN
+				// therefore following workaround
+				//m = NetlinkMessage{Header: *h, Data: dbuf[:int(h.Len)]}
+				if h.Type == syscall.NLMSG_ERROR {
+					v := int32(nativeEndian().Uint32(b[0:4]))
+					if v != 0 {
+						cb(h.Type, string(b[:h.Len]), fmt.Errorf("error receiving events %d", v), args...)
 					}
-					b = b[dlen:]
+				} else {
+					cb(h.Type, string(b[:int(h.Len)]), nil, args...)
 				}
-				/**
-				for _, msg := range msgs {
-					if msg.Header.Type == syscall.NLMSG_ERROR {
-						v := int32(nativeEndian().Uint32(msg.Data[0:4]))
-						if v != 0 {
-							cb(msg.Header.Type, string(msg.Data[:]), fmt.Errorf("error receiving events %d", v), args...)
-						}
-					} else {
-						cb(msg.Header.Type, string(msg.Data[:]), nil, args...)
+			} else {
+				//m = NetlinkMessage{Header: *h, Data: dbuf[:int(h.Len)-syscall.NLMSG_HDRLEN]}
+				if h.Type == syscall.NLMSG_ERROR {
+					v := int32(nativeEndian().Uint32(b[0:4]))
+					if v != 0 {
+						cb(h.Type, string(b[:int(h.Len)-syscall.NLMSG_HDRLEN]), fmt.Errorf("error receiving events %d", v), args...)
 					}
+				} else {
+					cb(h.Type, string(b[:int(h.Len)-syscall.NLMSG_HDRLEN]), nil, args...)
 				}
-				**/
 			}
-			//fmt.Printf("Loop Done Receive\n")
+			b = b[dlen:]
 		}
+		/**
+		for _, msg := range msgs {
+			if msg.Header.Type == syscall.NLMSG_ERROR {
+				v := int32(nativeEndian().Uint32(msg.Data[0:4]))
+				if v != 0 {
+					cb(msg.Header.Type, string(msg.Data[:]), fmt.Errorf("error receiving events %d", v), args...)
+				}
+			} else {
+				cb(msg.Header.Type, string(msg.Data[:]), nil, args...)
+			}
+		}
+		**/
+	} else if errors.Cause(err) != syscall.EAGAIN {
+		debugf("GetRawAuditMessages: receive failed: %v", err)
 	}
+	return err
 }
 
 // GetAuditMessages is a blocking function (runs in forever for loop) that
 // receives audit messages from kernel and parses them to AuditEvent.
 // It passes them along the callback function and if any error occurs while receiving the message,
 // the same will be passed in the callback as well.
-// It will return when a signal is received on the done channel.
+// It will return when a signal is received on the done channel or the connection is closed.
 func GetAuditMessages(s Netlink, cb EventCallback, done *chan bool, args ...interface{}) {
 	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	idle := newIdleTimer(s)
+	cb = coalesceEventErrors(cb)
+
+	for {
+		select {
+		case <-*done:
+			return
+		default:
+			if err := processOnce(s, rb, idle, cb, args...); errors.Cause(err) == ErrClosed {
+				return
+			}
+		}
+	}
+
+}
+
+// GetAuditMessagesStoppable is similar to GetAuditMessages but the callback can stop the loop as well.
+// It returns the error returned by the callback or nil when a signal is received on the done channel or the connection is closed
+// (done can be nil if the callback alone stops the loop).
+func GetAuditMessagesStoppable(s Netlink, cb StoppableEventCallback, done *chan bool, args ...interface{}) error {
+	var doneChan chan bool
+	if done != nil {
+		doneChan = *done
+	}
+	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	idle := newIdleTimer(s)
+	cb = coalesceStoppableErrors(cb)
+
+	for {
+		select {
+		case <-doneChan:
+			return nil
+		default:
+			stop, err := processOnceStoppable(s, rb, idle, cb, args...)
+			if stop != nil {
+				return stop
+			}
+			if errors.Cause(err) == ErrClosed {
+				return nil
+			}
+		}
+	}
+}
+
+// setupCtxLoop prepares s for a receive loop running until ctx is done and returns the idle timer of the loop
+func setupCtxLoop(ctx context.Context, s Netlink) (*idleTimer, error) {
+	if s == nil {
+		return nil, errors.New("no netlink connection")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	idle := newIdleTimer(s)
+	if idle.timeout <= 0 || idle.timeout > receivePollInterval {
+		if err := s.SetsockRecvTO(int64(receivePollInterval / time.Millisecond)); err != nil {
+			return nil, errors.Wrap(err, "SetsockRecvTO failed")
+		}
+	}
+	return idle, nil
+}
+
+// GetAuditMessagesCtx is similar to GetAuditMessages but runs until ctx is done instead of waiting for the
+// done channel. It sets the receive timeout of the connection (SetsockRecvTO) so that a blocked receive
+// notices the cancellation within 100ms, a shorter idle timeout (SetIdleTimeout) is kept.
+// It returns ctx.Err() once ctx is done, nil when the connection is closed and an error if the loop
+// can't be set up, e.g. because the receive timeout can't be set.
+func GetAuditMessagesCtx(ctx context.Context, s Netlink, cb EventCallback, args ...interface{}) error {
+	idle, err := setupCtxLoop(ctx, s)
+	if err != nil {
+		return errors.Wrap(err, "GetAuditMessagesCtx failed")
+	}
+	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	cb = coalesceEventErrors(cb)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := processOnce(s, rb, idle, cb, args...); errors.Cause(err) == ErrClosed {
+				return nil
+			}
+		}
+	}
+}
+
+// GetRawAuditMessagesCtx is similar to GetRawAuditMessages but runs until ctx is done, see GetAuditMessagesCtx.
+func GetRawAuditMessagesCtx(ctx context.Context, s Netlink, cb RawEventTypeCallback, args ...interface{}) error {
+	idle, err := setupCtxLoop(ctx, s)
+	if err != nil {
+		return errors.Wrap(err, "GetRawAuditMessagesCtx failed")
+	}
+	cb = coalesceRawTypeErrors(cb)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := processRawOnce(s, idle, cb, args...); err == ErrClosed {
+				return nil
+			}
+		}
+	}
+}
+
+// GetAuditMessageBatches is similar to GetAuditMessages but passes all events received with one receive call
+// in a single callback invocation. Messages which fail to parse and errors reported by the kernel are left out
+// of the batch, the first of these errors is passed along with the batch.
+// It will return when a signal is received on the done channel or the connection is closed.
+func GetAuditMessageBatches(s Netlink, cb BatchEventCallback, done *chan bool, args ...interface{}) {
+	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	idle := newIdleTimer(s)
+	cb = coalesceBatchErrors(cb)
 
 	for {
 		select {
@@ -202,20 +877,135 @@ func GetAuditMessages(s Netlink, cb EventCallback, done *chan bool, args ...inte
 			return
 		default:
 			msgs, err := s.Receive(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, rb)
-			if err == nil {
-				for _, msg := range msgs {
-					if msg.Header.Type == syscall.NLMSG_ERROR {
-						v := int32(nativeEndian().Uint32(msg.Data[0:4]))
-						if v != 0 {
-							cb(nil, fmt.Errorf("error receiving events %d", v), args...)
-						}
-					} else {
-						nae, err := NewAuditEvent(msg)
-						cb(nae, err, args...)
+			if err == ErrClosed {
+				return
+			}
+			if errors.Cause(err) == ErrEventsLost {
+				cb(nil, err, args...)
+			}
+			if idle.idle(err == nil) {
+				cb(nil, ErrIdle, args...)
+			}
+			idle.backoff(s, err)
+			if err != nil {
+				continue
+			}
+			observeMessages(msgs)
+			var (
+				batch    = make([]*AuditEvent, 0, len(msgs))
+				batchErr error
+			)
+			for _, msg := range msgs {
+				if isSuppressed(msg.Header.Type) {
+					continue
+				}
+				if msg.Header.Type == syscall.NLMSG_ERROR {
+					v := int32(nativeEndian().Uint32(msg.Data[0:4]))
+					if v != 0 && batchErr == nil {
+						batchErr = fmt.Errorf("error receiving events %d", v)
 					}
+					continue
+				}
+				nae, err := newAuditEvent(msg, keepRaw.get())
+				observeEvent(nae, err)
+				if err != nil && batchErr == nil {
+					batchErr = err
+				}
+				if nae != nil {
+					batch = append(batch, nae)
 				}
 			}
+			if len(batch) > 0 || batchErr != nil {
+				cb(batch, batchErr, args...)
+			}
 		}
 	}
+}
+
+// StatusCounters holds the kernel counters of the audit status as last observed by a receiver.
+type StatusCounters struct {
+	Lost    uint32    // events lost by the kernel since boot
+	Backlog uint32    // events waiting in the kernel queue
+	Updated time.Time // when the counters were received, zero if no status was received yet
+}
+
+// StatusEventCallback is similar to EventCallback but additionally receives the most recently
+// observed kernel counters, a jump of Lost between two events indicates events were lost in between.
+type StatusEventCallback func(*AuditEvent, StatusCounters, error, ...interface{})
 
+// GetAuditMessagesWithStatus is similar to GetAuditMessages but requests the audit status every interval and
+// passes the latest counters along with each event. The status request is sent on s and its reply is picked up
+// from the event stream, so no separate round trip is needed. As the status can only be requested between two
+// receive calls, the counters are refreshed at most once per receive.
+// It will return when a signal is received on the done channel or the connection is closed.
+// An interval which isn't positive is passed to the callback as error and the function returns at once.
+func GetAuditMessagesWithStatus(s Netlink, cb StatusEventCallback, interval time.Duration, done *chan bool, args ...interface{}) {
+	var (
+		counters    StatusCounters
+		lastRequest time.Time
+	)
+	if interval <= 0 {
+		cb(nil, counters, fmt.Errorf("GetAuditMessagesWithStatus: invalid status interval %v", interval), args...)
+		return
+	}
+	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	idle := newIdleTimer(s)
+	cb = coalesceStatusErrors(cb)
+
+	for {
+		select {
+		case <-*done:
+			return
+		default:
+			if time.Since(lastRequest) >= interval {
+				// no ack, it would be delivered to the callback as an empty error
+				wb := newNetlinkAuditRequest(uint16(AUDIT_GET), syscall.AF_NETLINK, 0)
+				wb.Header.Flags = syscall.NLM_F_REQUEST
+				if err := s.Send(wb); err != nil {
+					cb(nil, counters, errors.Wrap(err, "GetAuditMessagesWithStatus: status request failed"), args...)
+				}
+				lastRequest = time.Now()
+			}
+			msgs, err := s.Receive(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, rb)
+			if err == ErrClosed {
+				return
+			}
+			if errors.Cause(err) == ErrEventsLost {
+				cb(nil, counters, err, args...)
+			}
+			if idle.idle(err == nil) {
+				cb(nil, counters, ErrIdle, args...)
+			}
+			idle.backoff(s, err)
+			if err != nil {
+				continue
+			}
+			observeMessages(msgs)
+			for _, msg := range msgs {
+				if isSuppressed(msg.Header.Type) {
+					continue
+				}
+				switch msg.Header.Type {
+				case uint16(AUDIT_GET):
+					var status auditStatus
+					if err := binary.Read(bytes.NewReader(msg.Data), nativeEndian(), &status); err != nil {
+						cb(nil, counters, errors.Wrap(err, "GetAuditMessagesWithStatus: status parsing failed"), args...)
+						continue
+					}
+					currentMetrics().ObserveLost(int(status.Lost))
+					currentMetrics().ObserveBacklog(int(status.Backlog))
+					counters = StatusCounters{Lost: status.Lost, Backlog: status.Backlog, Updated: time.Now()}
+				case syscall.NLMSG_ERROR:
+					v := int32(nativeEndian().Uint32(msg.Data[0:4]))
+					if v != 0 {
+						cb(nil, counters, fmt.Errorf("error receiving events %d", v), args...)
+					}
+				default:
+					nae, err := newAuditEvent(msg, keepRaw.get())
+					observeEvent(nae, err)
+					cb(nae, counters, err, args...)
+				}
+			}
+		}
+	}
 }
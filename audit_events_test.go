@@ -0,0 +1,572 @@
+package libaudit
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// testEventMessage builds an audit event message as it is multicast by the kernel
+func testEventMessage(msgType auditConstant, msg string) NetlinkMessage {
+	return testReplyMessage(NetlinkMessage{}, uint16(msgType), []byte(msg))
+}
+
+func TestProcessOnce(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		pending: []NetlinkMessage{
+			testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`),
+		},
+	}
+	var (
+		events []*AuditEvent
+		errs   []error
+	)
+	cb := func(e *AuditEvent, err error, args ...interface{}) {
+		events = append(events, e)
+		errs = append(errs, err)
+	}
+	if err := ProcessOnce(s, cb); err != nil {
+		t.Fatalf("ProcessOnce failed %v", err)
+	}
+	if len(events) != 1 || errs[0] != nil {
+		t.Fatalf("ProcessOnce: expected one event, found %v %v", events, errs)
+	}
+	if events[0].Type != "CONFIG_CHANGE" || events[0].Serial != "20" || events[0].Data["op"] != "add_rule" {
+		t.Errorf("ProcessOnce: unexpected event %+v", events[0])
+	}
+
+	// kernel errors are passed to the callback
+	s.pending = append(s.pending, testAckMessage(NetlinkMessage{}, int32(syscall.EPERM)))
+	if err := ProcessOnce(s, cb); err != nil {
+		t.Fatalf("ProcessOnce failed %v", err)
+	}
+	if len(errs) != 2 || errs[1] == nil {
+		t.Errorf("ProcessOnce: expected kernel error in callback, found %v", errs)
+	}
+
+	// receive errors are returned without calling back
+	if err := ProcessOnce(s, cb); errors.Cause(err) != syscall.EAGAIN {
+		t.Errorf("ProcessOnce: expected EAGAIN, found %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("ProcessOnce: unexpected callback on receive error")
+	}
+}
+
+func TestReceiverStop(t *testing.T) {
+	s := &testReplyNetlinkConn{}
+	received := make(chan struct{}, 1)
+	r := GetAuditEvents(s, func(e *AuditEvent, err error, args ...interface{}) {
+		received <- struct{}{}
+	})
+	r.Stop()
+	// stopping twice is fine
+	r.Stop()
+	select {
+	case <-r.Stopped():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Receiver: loop didn't stop")
+	}
+	r.Wait()
+	if len(received) != 0 {
+		t.Errorf("Receiver: unexpected callback")
+	}
+}
+
+func TestGetAuditMessagesStoppable(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		pending: []NetlinkMessage{
+			testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`),
+			testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:21): auid=1000 ses=2 op=remove_rule key="passwd" list=4 res=1`),
+			testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:22): auid=1000 ses=2 op=add_rule key="shadow" list=4 res=1`),
+		},
+	}
+	errSentinel := errors.New("sentinel event")
+	var serials []string
+	err := GetAuditMessagesStoppable(s, func(e *AuditEvent, err error, args ...interface{}) error {
+		if err != nil {
+			return err
+		}
+		serials = append(serials, e.Serial)
+		if e.Data["op"] == "remove_rule" {
+			return errSentinel
+		}
+		return nil
+	}, nil)
+	if err != errSentinel {
+		t.Errorf("GetAuditMessagesStoppable: expected %v, found %v", errSentinel, err)
+	}
+	if len(serials) != 2 || len(s.pending) != 1 {
+		t.Errorf("GetAuditMessagesStoppable: expected to stop after the second event, found %v", serials)
+	}
+}
+
+// testBatchNetlinkConn returns all pending messages with a single receive call
+type testBatchNetlinkConn struct {
+	testReplyNetlinkConn
+}
+
+func (t *testBatchNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	if len(t.pending) == 0 {
+		return nil, syscall.EAGAIN
+	}
+	msgs := t.pending
+	t.pending = nil
+	return msgs, nil
+}
+
+func TestGetAuditMessageBatches(t *testing.T) {
+	s := &testBatchNetlinkConn{}
+	s.pending = []NetlinkMessage{
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`),
+		testAckMessage(NetlinkMessage{}, 0),
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:21): auid=1000 ses=2 op=remove_rule key="passwd" list=4 res=1`),
+		testEventMessage(AUDIT_CONFIG_CHANGE, `malformed`),
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:22): auid=1000 ses=2 op=add_rule key="shadow" list=4 res=1`),
+	}
+	var (
+		calls  int
+		serial []string
+		batchE error
+	)
+	done := make(chan bool)
+	GetAuditMessageBatches(s, func(events []*AuditEvent, err error, args ...interface{}) {
+		calls++
+		for _, e := range events {
+			serial = append(serial, e.Serial)
+		}
+		batchE = err
+		close(done)
+	}, &done)
+	if calls != 1 {
+		t.Fatalf("GetAuditMessageBatches: expected one callback, found %d", calls)
+	}
+	if !reflect.DeepEqual(serial, []string{"20", "21", "22"}) {
+		t.Errorf("GetAuditMessageBatches: expected events 20 21 22, found %v", serial)
+	}
+	if batchE == nil {
+		t.Errorf("GetAuditMessageBatches: expected the parsing error with the batch")
+	}
+}
+
+func TestSuppressEventTypes(t *testing.T) {
+	SuppressEventTypes(AUDIT_EOE)
+	defer SuppressEventTypes()
+
+	s := &testBatchNetlinkConn{}
+	s.pending = []NetlinkMessage{
+		testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:20): arch=c000003e syscall=59 success=yes exit=0`),
+		testEventMessage(AUDIT_EOE, `audit(1464163771.720:20): `),
+	}
+	var types []string
+	cb := func(e *AuditEvent, err error, args ...interface{}) {
+		types = append(types, e.Type)
+	}
+	if err := ProcessOnce(s, cb); err != nil {
+		t.Fatalf("ProcessOnce failed %v", err)
+	}
+	if !reflect.DeepEqual(types, []string{"SYSCALL"}) {
+		t.Errorf("SuppressEventTypes: expected EOE to be dropped, found %v", types)
+	}
+
+	SuppressEventTypes()
+	types = nil
+	s.pending = []NetlinkMessage{testEventMessage(AUDIT_EOE, `audit(1464163771.720:20): `)}
+	ProcessOnce(s, cb)
+	if !reflect.DeepEqual(types, []string{"EOE"}) {
+		t.Errorf("SuppressEventTypes: expected EOE by default, found %v", types)
+	}
+}
+
+func TestSettingsConcurrent(t *testing.T) {
+	defer func() {
+		SetKeepRaw(true)
+		SetAcceptUnknownTypes(false)
+		SetNormalizeSentinels(false)
+		SetKeepRawProctitle(false)
+		SetLenientParsing(false)
+		SetResolveIDs(true)
+		SetIdleTimeout(0)
+		SetErrorCoalescing(0)
+		SuppressEventTypes()
+		SetLogger(nil)
+		SetMetrics(nil)
+	}()
+
+	msg := testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:20): arch=c000003e syscall=59 success=yes exit=0 auid=4294967295 ses=4294967295`)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			on := i%2 == 0
+			SetKeepRaw(on)
+			SetAcceptUnknownTypes(on)
+			SetNormalizeSentinels(on)
+			SetKeepRawProctitle(on)
+			SetLenientParsing(on)
+			SetResolveIDs(on)
+			SetIdleTimeout(time.Duration(i))
+			SetErrorCoalescing(time.Duration(i))
+			if on {
+				SuppressEventTypes(AUDIT_EOE)
+				SetLogger(&testLogger{})
+				SetMetrics(noopMetrics{})
+			} else {
+				SuppressEventTypes()
+				SetLogger(nil)
+				SetMetrics(nil)
+			}
+		}
+	}()
+	s := &testBatchNetlinkConn{}
+	for i := 0; i < 100; i++ {
+		s.pending = []NetlinkMessage{msg}
+		if err := ProcessOnce(s, func(e *AuditEvent, err error, args ...interface{}) {}); err != nil {
+			t.Fatalf("ProcessOnce failed %v", err)
+		}
+	}
+	<-done
+}
+
+func TestGetAuditMessagesWithStatus(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		pending: []NetlinkMessage{
+			testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`),
+		},
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{
+				testReplyMessage(request, uint16(AUDIT_GET), testStatusData(auditStatus{Enabled: 1, Lost: 5, Backlog: 2})),
+				testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:21): auid=1000 ses=2 op=remove_rule key="passwd" list=4 res=1`),
+			}
+		},
+	}
+	var stamps []StatusCounters
+	done := make(chan bool)
+	GetAuditMessagesWithStatus(s, func(e *AuditEvent, c StatusCounters, err error, args ...interface{}) {
+		if err != nil {
+			t.Fatalf("GetAuditMessagesWithStatus: unexpected error %v", err)
+		}
+		stamps = append(stamps, c)
+		if e.Serial == "21" {
+			close(done)
+		}
+	}, time.Hour, &done)
+	if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_GET) || s.sent[0].Header.Flags != syscall.NLM_F_REQUEST {
+		t.Errorf("GetAuditMessagesWithStatus: expected one status request, found %v", s.sent)
+	}
+	if len(stamps) != 2 {
+		t.Fatalf("GetAuditMessagesWithStatus: expected 2 events, found %d", len(stamps))
+	}
+	if !stamps[0].Updated.IsZero() || stamps[1].Lost != 5 || stamps[1].Backlog != 2 || stamps[1].Updated.IsZero() {
+		t.Errorf("GetAuditMessagesWithStatus: unexpected counters %+v", stamps)
+	}
+
+	// a status request on every receive would flood the kernel
+	s.sent = nil
+	var errs []error
+	GetAuditMessagesWithStatus(s, func(e *AuditEvent, c StatusCounters, err error, args ...interface{}) {
+		errs = append(errs, err)
+	}, 0, &done)
+	if len(errs) != 1 || errs[0] == nil || len(s.sent) != 0 {
+		t.Errorf("GetAuditMessagesWithStatus: expected an error for a zero interval, found %v (%d requests)", errs, len(s.sent))
+	}
+}
+
+func TestUnknownTypes(t *testing.T) {
+	if !AUDIT_SYSCALL.IsKnown() || auditConstant(1334).IsKnown() {
+		t.Errorf("IsKnown: unexpected result")
+	}
+	msg := testEventMessage(auditConstant(1334), `audit(1464163771.720:20): op=new res=1`)
+	if _, err := NewAuditEvent(msg); err == nil {
+		t.Errorf("NewAuditEvent: expected error for unknown type")
+	}
+	SetAcceptUnknownTypes(true)
+	defer SetAcceptUnknownTypes(false)
+	x, err := NewAuditEvent(msg)
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed %v", err)
+	}
+	if x.Type != "UNKNOWN[1334]" || x.Data["op"] != "new" {
+		t.Errorf("NewAuditEvent: unexpected event %+v", x)
+	}
+}
+
+func TestGetRawAuditEventsUnknownTypes(t *testing.T) {
+	const unknown = auditConstant(1987)
+	if unknown.IsKnown() {
+		t.Fatalf("type %d is known", unknown)
+	}
+	receive := func() (string, error) {
+		s := &testReplyNetlinkConn{pending: []NetlinkMessage{testEventMessage(unknown, `audit(1464163771.720:20): op=new res=1`)}}
+		type result struct {
+			m   string
+			err error
+		}
+		results := make(chan result, 1)
+		r := GetRawAuditEvents(s, func(m string, err error, args ...interface{}) {
+			select {
+			case results <- result{m, err}:
+			default:
+			}
+		})
+		res := <-results
+		r.Stop()
+		r.Wait()
+		return res.m, res.err
+	}
+	if _, err := receive(); err == nil || err.Error() != "Unknown Type: 1987" {
+		t.Errorf("GetRawAuditEvents: expected unknown type error, found %v", err)
+	}
+	SetAcceptUnknownTypes(true)
+	defer SetAcceptUnknownTypes(false)
+	if m, err := receive(); err != nil || m != "type=1987 msg=audit(1464163771.720:20): op=new res=1\n" {
+		t.Errorf("GetRawAuditEvents: expected the raw message, found %q %v", m, err)
+	}
+}
+
+func TestRegisterMessageType(t *testing.T) {
+	defer RegisterMessageType(1334, "")
+	defer RegisterMessageType(uint16(AUDIT_PATH), "")
+	if err := RegisterMessageType(1334, "new type"); err == nil {
+		t.Errorf("RegisterMessageType: expected error for invalid name")
+	}
+	if err := RegisterMessageType(1334, "AUDIT_NEW_TYPE"); err != nil {
+		t.Fatalf("RegisterMessageType failed %v", err)
+	}
+	if !auditConstant(1334).IsKnown() || auditConstant(1334).String() != "AUDIT_NEW_TYPE" {
+		t.Errorf("RegisterMessageType: unexpected name %v", auditConstant(1334))
+	}
+	x, err := NewAuditEvent(testEventMessage(auditConstant(1334), `audit(1464163771.720:20): op=new res=1`))
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed %v", err)
+	}
+	if x.Type != "NEW_TYPE" {
+		t.Errorf("NewAuditEvent: expected type NEW_TYPE, found %v", x.Type)
+	}
+
+	// registered names override the built-in ones
+	if err := RegisterMessageType(uint16(AUDIT_PATH), "FILE_PATH"); err != nil {
+		t.Fatalf("RegisterMessageType failed %v", err)
+	}
+	if AUDIT_PATH.typeName() != "FILE_PATH" {
+		t.Errorf("RegisterMessageType: expected FILE_PATH, found %v", AUDIT_PATH.typeName())
+	}
+	RegisterMessageType(uint16(AUDIT_PATH), "")
+	RegisterMessageType(1334, "")
+	if AUDIT_PATH.String() != "AUDIT_PATH" || auditConstant(1334).IsKnown() || auditConstant(1334).String() != "auditConstant(1334)" {
+		t.Errorf("RegisterMessageType: registrations not removed")
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	SetIdleTimeout(20 * time.Millisecond)
+	defer SetIdleTimeout(0)
+	s := &testReplyNetlinkConn{pending: []NetlinkMessage{
+		testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:020): arch=c000003e syscall=2 success=yes exit=3`),
+	}}
+	events := make(chan *AuditEvent, 1)
+	idle := make(chan time.Time, 16)
+	start := time.Now()
+	r := GetAuditEvents(s, func(e *AuditEvent, err error, args ...interface{}) {
+		switch {
+		case err == ErrIdle && e == nil:
+			select {
+			case idle <- time.Now():
+			default:
+			}
+		case err != nil:
+			t.Errorf("GetAuditEvents: unexpected error %v", err)
+		default:
+			events <- e
+		}
+	})
+	defer r.Wait()
+	defer r.Stop()
+
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("GetAuditEvents: no event received")
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case at := <-idle:
+			if at.Sub(start) < 20*time.Millisecond*time.Duration(i+1) {
+				t.Errorf("ErrIdle: heartbeat %d after %v, expected at least %v", i, at.Sub(start), 20*time.Millisecond*time.Duration(i+1))
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ErrIdle: no heartbeat")
+		}
+	}
+}
+
+func TestErrorCoalescing(t *testing.T) {
+	if cb := coalesceEventErrors(nil); cb != nil {
+		t.Errorf("coalesceEventErrors: expected the callback unchanged by default")
+	}
+	SetErrorCoalescing(50 * time.Millisecond)
+	defer SetErrorCoalescing(0)
+
+	var errs []error
+	cb := coalesceEventErrors(func(e *AuditEvent, err error, args ...interface{}) {
+		errs = append(errs, err)
+	})
+	failed := errors.New("receive failed")
+	for i := 0; i < 5; i++ {
+		cb(nil, failed)
+	}
+	cb(nil, ErrIdle)
+	cb(&AuditEvent{}, failed)
+	if len(errs) != 3 || errs[0] != failed || errs[1] != ErrIdle || errs[2] != failed {
+		t.Fatalf("coalesce: expected the first occurrence and the errors not coalesced, found %v", errs)
+	}
+	time.Sleep(60 * time.Millisecond)
+	cb(nil, failed)
+	if r, ok := errs[len(errs)-1].(*RepeatedError); len(errs) != 4 || !ok || r.Count != 5 || errors.Cause(r) != failed {
+		t.Fatalf("coalesce: expected a summary of 5 occurrences, found %v", errs)
+	}
+	if errs[3].Error() != "still failing (5 occurrences): receive failed" {
+		t.Errorf("RepeatedError: unexpected message %q", errs[3].Error())
+	}
+	cb(nil, failed)
+	other := errors.New("other")
+	cb(nil, other)
+	if r, ok := errs[4].(*RepeatedError); len(errs) != 6 || !ok || r.Count != 1 || errs[5] != other {
+		t.Errorf("coalesce: expected the pending summary before a different error, found %v", errs)
+	}
+}
+
+// testRecvTONetlinkConn fails to set the receive timeout
+type testRecvTONetlinkConn struct {
+	testReplyNetlinkConn
+}
+
+func (t *testRecvTONetlinkConn) SetsockRecvTO(recvto int64) error {
+	return syscall.EBADF
+}
+
+func TestGetAuditMessagesCtx(t *testing.T) {
+	pending := []NetlinkMessage{
+		testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:020): arch=c000003e syscall=2 success=yes exit=3`),
+		testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:021): arch=c000003e syscall=2 success=yes exit=3`),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var serials []string
+	err := GetAuditMessagesCtx(ctx, &testReplyNetlinkConn{pending: pending}, func(e *AuditEvent, err error, args ...interface{}) {
+		if err != nil {
+			t.Errorf("GetAuditMessagesCtx: unexpected error %v", err)
+			return
+		}
+		serials = append(serials, e.Serial)
+		if len(serials) == 2 {
+			cancel()
+		}
+	})
+	if err != context.Canceled || len(serials) != 2 {
+		t.Errorf("GetAuditMessagesCtx: expected to return %v after 2 events, found %v %v", context.Canceled, err, serials)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	var raw []uint16
+	err = GetRawAuditMessagesCtx(ctx, &testReplyNetlinkConn{pending: pending}, func(msgType uint16, m string, err error, args ...interface{}) {
+		raw = append(raw, msgType)
+		if len(raw) == 2 {
+			cancel()
+		}
+	})
+	if err != context.Canceled || len(raw) != 2 || raw[0] != uint16(AUDIT_SYSCALL) {
+		t.Errorf("GetRawAuditMessagesCtx: expected to return %v after 2 messages, found %v %v", context.Canceled, err, raw)
+	}
+
+	// a cancelled context and setup failures are reported without receiving
+	cb := func(*AuditEvent, error, ...interface{}) {
+		t.Errorf("GetAuditMessagesCtx: unexpected callback")
+	}
+	if err := GetAuditMessagesCtx(ctx, &testReplyNetlinkConn{pending: pending}, cb); errors.Cause(err) != context.Canceled {
+		t.Errorf("GetAuditMessagesCtx: expected %v, found %v", context.Canceled, err)
+	}
+	if err := GetAuditMessagesCtx(context.Background(), &testRecvTONetlinkConn{}, cb); errors.Cause(err) != syscall.EBADF {
+		t.Errorf("GetAuditMessagesCtx: expected %v, found %v", syscall.EBADF, err)
+	}
+	if err := GetRawAuditMessagesCtx(context.Background(), nil, nil); err == nil {
+		t.Errorf("GetRawAuditMessagesCtx: expected error without connection")
+	}
+}
+
+// testWouldBlockNetlinkConn is a connection in non-blocking mode that never receives a message
+type testWouldBlockNetlinkConn struct {
+	testReplyNetlinkConn
+	receives int32
+}
+
+func (t *testWouldBlockNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	atomic.AddInt32(&t.receives, 1)
+	return nil, ErrWouldBlock
+}
+
+func TestReceiveBackoff(t *testing.T) {
+	s := &testWouldBlockNetlinkConn{}
+	r := GetAuditEvents(s, func(e *AuditEvent, err error, args ...interface{}) {})
+	time.Sleep(300 * time.Millisecond)
+	r.Stop()
+	select {
+	case <-r.Stopped():
+	case <-time.After(time.Second):
+		t.Fatalf("GetAuditEvents: loop didn't stop")
+	}
+	// the loop backs off up to receivePollInterval instead of spinning
+	if n := atomic.LoadInt32(&s.receives); n < 2 || n > 30 {
+		t.Errorf("GetAuditEvents: expected a few receives, found %d", n)
+	}
+}
+
+// testOverflowNetlinkConn fails every other receive with ErrEventsLost
+type testOverflowNetlinkConn struct {
+	testReplyNetlinkConn
+	receives int
+}
+
+func (t *testOverflowNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	t.receives++
+	if t.receives%2 == 1 {
+		return nil, errors.Wrap(ErrEventsLost, "recvfrom failed")
+	}
+	return t.testReplyNetlinkConn.Receive(bytesize, block, rb)
+}
+
+func TestEventsLost(t *testing.T) {
+	s := &testOverflowNetlinkConn{}
+	s.pending = []NetlinkMessage{
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:020): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`),
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:021): auid=1000 ses=2 op=add_rule key="shadow" list=4 res=1`),
+	}
+	var (
+		lost   int
+		serial []string
+	)
+	cb := func(e *AuditEvent, err error, args ...interface{}) {
+		if errors.Cause(err) == ErrEventsLost {
+			lost++
+		} else if err != nil {
+			t.Errorf("ProcessOnce: unexpected error %v", err)
+		}
+		if e != nil {
+			serial = append(serial, e.Serial)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		err := ProcessOnce(s, cb)
+		if overflow := errors.Cause(err) == ErrEventsLost; overflow != (i%2 == 0) {
+			t.Errorf("ProcessOnce: unexpected error %v for receive %d", err, i)
+		}
+	}
+	// the events of the receives after the overflows are passed
+	if lost != 2 || !reflect.DeepEqual(serial, []string{"020", "021"}) {
+		t.Errorf("ProcessOnce: expected 2 overflows and 2 events, found %d %v", lost, serial)
+	}
+}
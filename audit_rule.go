@@ -0,0 +1,1080 @@
+package libaudit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/lacework/libaudit-go/headers"
+	"github.com/pkg/errors"
+)
+
+// AuditRule is the structured form of an audit rule.
+// It can be built by hand for declarative rule management or obtained from the
+// kernel with ListAllRulesParsed.
+type AuditRule struct {
+	Flags  RuleFilter // filter list, AUDIT_FILTER_{TASK,EXIT,USER,EXCLUDE}
+	Action RuleAction // AUDIT_NEVER, AUDIT_ALWAYS
+	// Syscalls holds syscall names (or numbers for unknown syscalls).
+	// nil means all syscalls, it is ignored for the task, user and exclude filters.
+	Syscalls []string
+	Fields   []AuditRuleField
+	// raw holds the kernel representation of rules listed from the kernel.
+	raw *AuditRuleData
+}
+
+// RuleFilter is the filter list of an audit rule (AUDIT_FILTER_*), its String form is the name used by auditctl.
+type RuleFilter uint32
+
+func (f RuleFilter) String() string {
+	if name, ok := flagLookup[int(f)]; ok {
+		return name
+	}
+	return fmt.Sprintf("filter(%d)", uint32(f))
+}
+
+// ParseFilter converts the name of a filter list (task, exit, user, exclude) to its value.
+func ParseFilter(name string) (RuleFilter, error) {
+	for f, n := range flagLookup {
+		if n == name {
+			return RuleFilter(f), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown filter list %q", name)
+}
+
+// RuleAction is the action of an audit rule (AUDIT_NEVER, AUDIT_POSSIBLE, AUDIT_ALWAYS), its String
+// form is the name used by auditctl.
+type RuleAction uint32
+
+func (a RuleAction) String() string {
+	if name, ok := actionLookup[int(a)]; ok {
+		return name
+	}
+	return fmt.Sprintf("action(%d)", uint32(a))
+}
+
+// ParseAction converts the name of a rule action (never, possible, always) to its value.
+func ParseAction(name string) (RuleAction, error) {
+	for a, n := range actionLookup {
+		if n == name {
+			return RuleAction(a), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action %q", name)
+}
+
+// AuditRuleField is a single field comparison of an AuditRule, i.e. `-F auid>=1000`.
+// The comparisons of two fields of auditctl -C are field_compare fields, their value names both fields,
+// i.e. {Name: "field_compare", Op: "!=", Value: "auid:uid"} for `-C auid!=uid`.
+// A rule has a key field for every key (-k), the kernel stores them joined with \x01.
+type AuditRuleField struct {
+	Name  string `json:"name"` // field name as used by auditctl (uid, arch, path, key, ...)
+	Op    string `json:"op"`   // operator symbol (=, !=, >, >=, <, <=, &, &=) or name (eq, nt_eq, ...), see ParseOperator
+	Value string `json:"value"`
+}
+
+// auditFieldIsString reports whether the value of the field is carried in the rule buffer
+func auditFieldIsString(field uint32) bool {
+	switch field {
+	case AUDIT_SUBJ_USER, AUDIT_SUBJ_ROLE, AUDIT_SUBJ_TYPE, AUDIT_SUBJ_SEN, AUDIT_SUBJ_CLR,
+		AUDIT_OBJ_USER, AUDIT_OBJ_ROLE, AUDIT_OBJ_TYPE, AUDIT_OBJ_LEV_LOW, AUDIT_OBJ_LEV_HIGH,
+		AUDIT_WATCH, AUDIT_DIR, AUDIT_FILTERKEY, AUDIT_EXE:
+		return true
+	}
+	return false
+}
+
+// symbolToOperator converts an operator symbol to its integer value
+func symbolToOperator(symbol string) (uint32, bool) {
+	for k, v := range opLookup {
+		if v == symbol {
+			return uint32(k), true
+		}
+	}
+	return 0, false
+}
+
+// ruleFieldValue converts the value of the field to the form expected by auditRuleFieldPairData
+// i.e. float64 for numbers and string otherwise
+func ruleFieldValue(f AuditRuleField) interface{} {
+	switch f.Name {
+	case "perm", "filetype", "arch", "field_compare":
+		return f.Value
+	}
+	if id, ok := headers.FieldMap[f.Name]; ok && auditFieldIsString(uint32(id)) {
+		return f.Value
+	}
+	if v, err := strconv.ParseInt(f.Value, 0, 64); err == nil {
+		return float64(v)
+	}
+	if v, err := strconv.ParseUint(f.Value, 0, 64); err == nil {
+		return float64(v)
+	}
+	return f.Value
+}
+
+// NewAuditRule converts the kernel representation of a rule to an AuditRule
+func NewAuditRule(rule *AuditRuleData) AuditRule {
+	var (
+		r            AuditRule
+		bufferOffset int
+	)
+	raw := *rule
+	r.raw = &raw
+	r.Flags = RuleFilter(rule.Flags & AUDIT_FILTER_MASK)
+	r.Action = RuleAction(rule.Action)
+
+	switch r.Flags {
+	case AUDIT_FILTER_USER, AUDIT_FILTER_TASK, AUDIT_FILTER_EXCLUDE:
+		// rules on these filters do not take a syscall
+	default:
+		if !auditRuleAllSyscalls(rule) {
+			r.Syscalls = []string{}
+			for i := 0; i < AUDIT_BITMASK_SIZE*32; i++ {
+				if (rule.Mask[auditWord(i)] & auditBit(i)) > 0 {
+					name, err := AuditArchSyscallToName(strconv.Itoa(i), ruleDataArch(rule))
+					if err != nil {
+						name = strconv.Itoa(i)
+					}
+					r.Syscalls = append(r.Syscalls, name)
+				}
+			}
+		}
+	}
+
+	for i := 0; i < int(rule.FieldCount); i++ {
+		field := rule.Fields[i] & (^uint32(AUDIT_OPERATORS))
+		op := rule.Fieldflags[i] & uint32(AUDIT_OPERATORS)
+		f := AuditRuleField{Name: fieldToName(field), Op: operatorToSymbol(op)}
+		if len(f.Name) == 0 {
+			f.Name = fmt.Sprintf("f%d", field)
+		}
+		value := rule.Values[i]
+		switch {
+		case field == AUDIT_FILTERKEY:
+			end := bufferOffset + int(value)
+			if end > len(rule.Buf) {
+				end = len(rule.Buf)
+			}
+			// one key field per key like auditctl -l lists them
+			keys := strings.Split(string(rule.Buf[bufferOffset:end]), "\x01")
+			bufferOffset = end
+			for _, key := range keys[:len(keys)-1] {
+				r.Fields = append(r.Fields, AuditRuleField{Name: f.Name, Op: f.Op, Value: key})
+			}
+			f.Value = keys[len(keys)-1]
+		case auditFieldIsString(field):
+			end := bufferOffset + int(value)
+			if end > len(rule.Buf) {
+				end = len(rule.Buf)
+			}
+			f.Value = string(rule.Buf[bufferOffset:end])
+			bufferOffset = end
+		case field == AUDIT_ARCH:
+			f.Value = archToName(value)
+		case field == AUDIT_PERM:
+			f.Value = DecodePerm(int(value))
+		case field == AUDIT_MSGTYPE:
+			f.Value = strconv.Itoa(int(value))
+			if msgType := auditConstant(value); msgType.IsKnown() {
+				if _, ok := MsgTypeTab[msgType.typeName()]; ok {
+					f.Value = msgType.typeName()
+				}
+			}
+		case field == AUDIT_FILETYPE:
+			f.Value = strconv.Itoa(int(value))
+			for k, v := range headers.FtypeTab {
+				if uint32(v) == value {
+					f.Value = k
+				}
+			}
+		case field == AUDIT_FIELD_COMPARE:
+			f.Value = strconv.Itoa(int(value))
+			if c, ok := fieldCompares[auditConstant(value)]; ok {
+				f.Value = c[0] + ":" + c[1]
+			}
+		case field == AUDIT_EXIT:
+			f.Value = strconv.Itoa(int(int32(value)))
+		case field >= AUDIT_ARG0 && field <= AUDIT_ARG3:
+			f.Value = fmt.Sprintf("0x%x", value)
+		default:
+			f.Value = strconv.FormatUint(uint64(value), 10)
+		}
+		r.Fields = append(r.Fields, f)
+	}
+	return r
+}
+
+// auditRuleAllSyscalls reports whether all syscall bits are set in the rule
+func auditRuleAllSyscalls(rule *AuditRuleData) bool {
+	for i := 0; i < AUDIT_BITMASK_SIZE-1; i++ {
+		if rule.Mask[i] != ^uint32(0) {
+			return false
+		}
+	}
+	return true
+}
+
+// toRuleData builds the kernel representation of the rule
+// Rules listed from the kernel are returned as they were received.
+func (r *AuditRule) toRuleData() (*AuditRuleData, error) {
+	if r.raw != nil {
+		raw := *r.raw
+		return &raw, nil
+	}
+	var rule AuditRuleData
+	rule.Buf = make([]byte, 0)
+	filter := int(r.Flags & AUDIT_FILTER_MASK)
+
+	switch filter {
+	case AUDIT_FILTER_USER, AUDIT_FILTER_TASK, AUDIT_FILTER_EXCLUDE:
+		if len(r.Syscalls) != 0 {
+			return nil, fmt.Errorf("toRuleData failed: syscalls can't be used with %v filter", flagToName(uint32(filter)))
+		}
+	default:
+		if r.Syscalls == nil {
+			for i := 0; i < AUDIT_BITMASK_SIZE-1; i++ {
+				rule.Mask[i] = 0xFFFFFFFF
+			}
+		}
+		_, arch := NativeArch()
+		for _, f := range r.Fields {
+			if f.Name == "arch" {
+				a, err := auditNameToArch(ruleFieldValue(f))
+				if err != nil {
+					return nil, errors.Wrap(err, "toRuleData failed")
+				}
+				arch = a
+			}
+		}
+		for _, name := range r.Syscalls {
+			nr, err := AuditSyscallToNumber(name, arch)
+			if n, errNum := strconv.Atoi(name); errNum == nil {
+				nr, err = n, nil
+			}
+			if err != nil {
+				return nil, errors.Wrap(err, "toRuleData failed")
+			}
+			if err := auditRuleSyscallData(&rule, nr); err != nil {
+				return nil, errors.Wrap(err, "toRuleData failed")
+			}
+		}
+	}
+	// auditRuleFieldPairData checks the ordering of keys and arch against it
+	st := ruleFieldState{syscallAdded: true}
+
+	keys := r.Keys()
+	for _, f := range r.Fields {
+		if f.Name == "key" {
+			// the kernel takes a single key field, several keys are joined with \x01 in the place of the first
+			if keys == nil {
+				continue
+			}
+			f.Value, keys = strings.Join(keys, "\x01"), nil
+		}
+		if err := addRuleField(&rule, &st, f, filter); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("toRuleData failed for field %v", f.Name))
+		}
+	}
+	rule.Flags = uint32(r.Flags)
+	rule.Action = uint32(r.Action)
+	return &rule, nil
+}
+
+// Keys returns the keys of the rule, the values of its key fields.
+func (r AuditRule) Keys() []string {
+	var keys []string
+	for _, f := range r.Fields {
+		if f.Name == "key" {
+			keys = append(keys, f.Value)
+		}
+	}
+	return keys
+}
+
+// addRuleField adds the field comparison f to the kernel representation of a rule on the filter list
+func addRuleField(rule *AuditRuleData, st *ruleFieldState, f AuditRuleField, filter int) error {
+	op, err := ParseOperator(f.Op)
+	if err != nil {
+		return err
+	}
+	if f.Name == "key" {
+		filter = AUDIT_FILTER_UNSET
+	}
+	return auditRuleFieldPairData(rule, st, ruleFieldValue(f), op, f.Name, filter)
+}
+
+// RuleError is the error returned by ValidateRules for an invalid rule.
+type RuleError struct {
+	Index int    // index of the rule in the rule set
+	Line  int    // line of the rule in the JSON document (LoadRulesFromJSON) or rule file (ParseAuditctlRules), 0 otherwise
+	Field string // name of the offending field or syscall, empty if the rule itself is invalid
+	Err   error
+}
+
+func (e *RuleError) Error() string {
+	prefix := fmt.Sprintf("rule %d", e.Index)
+	if e.Line > 0 {
+		prefix = fmt.Sprintf("line %d: %s", e.Line, prefix)
+	}
+	if len(e.Field) == 0 {
+		return fmt.Sprintf("%s: %v", prefix, e.Err)
+	}
+	return fmt.Sprintf("%s: %v: %v", prefix, e.Field, e.Err)
+}
+
+// RuleErrors is the error returned by LoadRulesFromJSON, it holds a *RuleError for every invalid rule.
+type RuleErrors []error
+
+func (e RuleErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Cause returns the error found in the rule, it makes errors.Cause return the underlying error (i.e. ErrKeyTooLong)
+func (e *RuleError) Cause() error {
+	return e.Err
+}
+
+// ValidateRules checks the rules without talking to the kernel: filters and actions, field names and
+// operators, field values against the filter list and syscall names against the table of the rule's arch.
+// The rules rejected by the kernel with EINVAL (i.e. the deprecated entry list and possible action,
+// syscalls on the user list) are reported with the offending field or syscall.
+// It returns a *RuleError for every invalid rule, nil if all rules are valid.
+func ValidateRules(rules []AuditRule) []error {
+	var errs []error
+	for i := range rules {
+		if err := validateRule(&rules[i]); err != nil {
+			err.Index = i
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// validateRule returns the first error found in the rule
+func validateRule(r *AuditRule) *RuleError {
+	filter := int(r.Flags & AUDIT_FILTER_MASK)
+	switch filter {
+	case AUDIT_FILTER_USER, AUDIT_FILTER_TASK, AUDIT_FILTER_EXIT, AUDIT_FILTER_EXCLUDE:
+	case AUDIT_FILTER_ENTRY:
+		return &RuleError{Err: errEntryDep}
+	default:
+		return &RuleError{Err: fmt.Errorf("unknown filter list %v", r.Flags)}
+	}
+	switch r.Action {
+	case AUDIT_NEVER, AUDIT_ALWAYS:
+	case AUDIT_POSSIBLE:
+		return &RuleError{Err: errPossibleDep}
+	default:
+		return &RuleError{Err: fmt.Errorf("unknown action %v", r.Action)}
+	}
+	if len(r.Syscalls) > 0 && filter != AUDIT_FILTER_EXIT {
+		return &RuleError{Field: r.Syscalls[0], Err: fmt.Errorf("syscalls can't be used with %v filter", flagToName(uint32(filter)))}
+	}
+
+	_, arch := NativeArch()
+	for _, f := range r.Fields {
+		if _, ok := headers.FieldMap[f.Name]; !ok {
+			return &RuleError{Field: f.Name, Err: fmt.Errorf("unknown field")}
+		}
+		if _, err := ParseOperator(f.Op); err != nil {
+			return &RuleError{Field: f.Name, Err: err}
+		}
+		if f.Name == "arch" {
+			a, err := auditNameToArch(ruleFieldValue(f))
+			if err != nil {
+				return &RuleError{Field: f.Name, Err: err}
+			}
+			arch = a
+		}
+	}
+	for _, name := range r.Syscalls {
+		if _, err := strconv.Atoi(name); err == nil {
+			continue
+		}
+		if _, err := AuditSyscallToNumber(name, arch); err != nil {
+			return &RuleError{Field: name, Err: err}
+		}
+	}
+	// check every field value on its own, ordering constraints are satisfied like in toRuleData
+	for _, f := range r.Fields {
+		var scratch AuditRuleData
+		st := ruleFieldState{syscallAdded: true, permAdded: true}
+		if err := addRuleField(&scratch, &st, f, filter); err != nil {
+			return &RuleError{Field: f.Name, Err: err}
+		}
+	}
+	// the rule as a whole (field count, syscalls on the filter list ...)
+	if _, err := r.toRuleData(); err != nil {
+		return &RuleError{Err: err}
+	}
+	return nil
+}
+
+// ruleDataKey returns a canonical representation of the rule that is the same for
+// rules the kernel treats as identical, regardless of field ordering.
+func ruleDataKey(rule *AuditRuleData) string {
+	filter := rule.Flags & AUDIT_FILTER_MASK
+	key := fmt.Sprintf("%d,%d", filter, rule.Action)
+	switch filter {
+	case AUDIT_FILTER_USER, AUDIT_FILTER_TASK, AUDIT_FILTER_EXCLUDE:
+	default:
+		if auditRuleAllSyscalls(rule) {
+			key += " all"
+		} else {
+			key += fmt.Sprintf(" %x", rule.Mask)
+		}
+	}
+	return key + " " + strings.Join(ruleDataFields(rule, true), ";")
+}
+
+// ruleDataFields returns the canonical representation of the field comparisons of the rule
+// sorted, the key is left out unless withKey is set
+func ruleDataFields(rule *AuditRuleData, withKey bool) []string {
+	var (
+		fields       []string
+		bufferOffset int
+	)
+	for i := 0; i < int(rule.FieldCount); i++ {
+		field := rule.Fields[i] & (^uint32(AUDIT_OPERATORS))
+		op := rule.Fieldflags[i] & uint32(AUDIT_OPERATORS)
+		if op == 0 {
+			// old style rules carry the operator in the field
+			op = rule.Fields[i] & uint32(AUDIT_OPERATORS)
+		}
+		if auditFieldIsString(field) {
+			end := bufferOffset + int(rule.Values[i])
+			if end > len(rule.Buf) {
+				end = len(rule.Buf)
+			}
+			if field != AUDIT_FILTERKEY || withKey {
+				fields = append(fields, fmt.Sprintf("%d %d %q", field, op, rule.Buf[bufferOffset:end]))
+			}
+			bufferOffset = end
+		} else {
+			fields = append(fields, fmt.Sprintf("%d %d %d", field, op, rule.Values[i]))
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// normalize returns the canonical representation of the rule used for comparisons
+func (r *AuditRule) normalize() string {
+	rule, err := r.toRuleData()
+	if err != nil {
+		// rules which fail to encode only compare equal to themselves
+		return fmt.Sprintf("invalid %+v", *r)
+	}
+	return ruleDataKey(rule)
+}
+
+// Equal reports whether both rules are semantically identical, field ordering and
+// the notation of values (numbers, names, operators) don't matter.
+func (r AuditRule) Equal(other AuditRule) bool {
+	return r.normalize() == other.normalize()
+}
+
+// DiffRules compares the desired rule set against the actual rules (usually obtained
+// from ListAllRulesParsed) and returns the rules that have to be added and deleted
+// for the kernel to end up with the desired rules.
+func DiffRules(desired, actual []AuditRule) (toAdd, toDelete []AuditRule) {
+	var (
+		want = make(map[string]bool)
+		have = make(map[string]bool)
+	)
+	for i := range actual {
+		have[actual[i].normalize()] = true
+	}
+	for i := range desired {
+		key := desired[i].normalize()
+		if want[key] {
+			continue
+		}
+		want[key] = true
+		if !have[key] {
+			toAdd = append(toAdd, desired[i])
+		}
+	}
+	for i := range actual {
+		key := actual[i].normalize()
+		if !want[key] {
+			toDelete = append(toDelete, actual[i])
+			// only delete a rule once
+			want[key] = true
+		}
+	}
+	return toAdd, toDelete
+}
+
+// RuleWarningKind is the kind of problem AnalyzeRules found
+type RuleWarningKind int
+
+const (
+	// RuleDuplicate is a rule identical to an earlier rule, the kernel rejects it with EEXIST
+	RuleDuplicate RuleWarningKind = iota
+	// RuleShadowed is a rule that never matches as an earlier never rule on the same filter list
+	// matches all of its events (same or more syscalls, fewer field comparisons)
+	RuleShadowed
+)
+
+func (k RuleWarningKind) String() string {
+	switch k {
+	case RuleDuplicate:
+		return "duplicate"
+	case RuleShadowed:
+		return "shadowed"
+	}
+	return fmt.Sprintf("RuleWarningKind(%d)", int(k))
+}
+
+// RuleWarning is a problem of a rule set found by AnalyzeRules.
+type RuleWarning struct {
+	Kind  RuleWarningKind
+	Index int // index of the rule the warning is about
+	Other int // index of the earlier rule it duplicates or that shadows it
+}
+
+func (w RuleWarning) String() string {
+	if w.Kind == RuleDuplicate {
+		return fmt.Sprintf("rule %d: duplicate of rule %d", w.Index, w.Other)
+	}
+	return fmt.Sprintf("rule %d: shadowed by never rule %d", w.Index, w.Other)
+}
+
+// AnalyzeRules checks the rule set for rules that duplicate an earlier rule and for always rules that are
+// shadowed by an earlier never rule, the kernel evaluates the rules of a filter list in order and stops at
+// the first match. Rules are compared like with Equal. It doesn't talk to the kernel, invalid rules (see
+// ValidateRules) are skipped.
+func AnalyzeRules(rules []AuditRule) []RuleWarning {
+	var (
+		warnings []RuleWarning
+		seen     = make(map[string]int)
+		data     = make([]*AuditRuleData, len(rules))
+	)
+	for i := range rules {
+		rule, err := rules[i].toRuleData()
+		if err != nil {
+			continue
+		}
+		data[i] = rule
+		key := ruleDataKey(rule)
+		if first, ok := seen[key]; ok {
+			warnings = append(warnings, RuleWarning{Kind: RuleDuplicate, Index: i, Other: first})
+			continue
+		}
+		seen[key] = i
+		if rule.Action == AUDIT_NEVER {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if data[j] != nil && data[j].Action == AUDIT_NEVER && ruleDataShadows(data[j], rule) {
+				warnings = append(warnings, RuleWarning{Kind: RuleShadowed, Index: i, Other: j})
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// ruleDataShadows reports whether rule a matches all events rule b matches, the keys don't matter
+func ruleDataShadows(a, b *AuditRuleData) bool {
+	filter := a.Flags & AUDIT_FILTER_MASK
+	if filter != b.Flags&AUDIT_FILTER_MASK {
+		return false
+	}
+	switch filter {
+	case AUDIT_FILTER_USER, AUDIT_FILTER_TASK, AUDIT_FILTER_EXCLUDE:
+	default:
+		for i := range a.Mask {
+			if a.Mask[i]&b.Mask[i] != b.Mask[i] {
+				return false
+			}
+		}
+	}
+	fields := make(map[string]bool)
+	for _, f := range ruleDataFields(b, false) {
+		fields[f] = true
+	}
+	for _, f := range ruleDataFields(a, false) {
+		if !fields[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// ExpandArchRules returns the rules with the exit and entry rules naming syscalls without arch field
+// replaced by an arch=b64 and an arch=b32 rule like auditctl installs them. Each variant names the syscalls
+// of its arch, a variant is left out if its arch has none of them. Syscalls only the compat arch has (i.e.
+// socketcall on x86_64) are an error like with auditctl, rules have to name the arch for them.
+// Rules are left as they are on archs without compat arch or without syscall table for it.
+func ExpandArchRules(rules []AuditRule) ([]AuditRule, error) {
+	var expanded []AuditRule
+	for i, r := range rules {
+		filter := r.Flags & AUDIT_FILTER_MASK
+		if (filter != AUDIT_FILTER_EXIT && filter != AUDIT_FILTER_ENTRY) || len(r.Syscalls) == 0 || r.raw != nil ||
+			r.hasField("arch") {
+			expanded = append(expanded, r)
+			continue
+		}
+		native, compat, ok, err := splitArchSyscalls(r.Syscalls)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("ExpandArchRules failed: rule %d", i))
+		}
+		if !ok {
+			expanded = append(expanded, r)
+			continue
+		}
+		for _, v := range []struct {
+			arch     string
+			syscalls []string
+		}{{"b64", native}, {"b32", compat}} {
+			if len(v.syscalls) == 0 {
+				continue
+			}
+			variant := r
+			variant.Syscalls = v.syscalls
+			variant.Fields = append([]AuditRuleField{{Name: "arch", Op: "=", Value: v.arch}}, r.Fields...)
+			expanded = append(expanded, variant)
+		}
+	}
+	return expanded, nil
+}
+
+// hasField reports whether the rule has a field of the name
+func (r *AuditRule) hasField(name string) bool {
+	for _, f := range r.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ListAllRulesParsed lists all audit rules currently loaded in the kernel as AuditRule structs.
+func ListAllRulesParsed(s Netlink) ([]AuditRule, error) {
+	_, ruleArray, err := ListAllRules(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListAllRulesParsed failed")
+	}
+	rules := make([]AuditRule, 0, len(ruleArray))
+	for _, r := range ruleArray {
+		rules = append(rules, NewAuditRule(r))
+	}
+	return rules, nil
+}
+
+// DeleteRule deletes the rule r from the kernel, leaving the other rules in place unlike DeleteAllRules.
+// The kernel deletes the loaded rule with the same fields in the same order (unlike Equal, which ignores
+// the order of the fields), the rules listed by ListAllRulesParsed can be passed as they are. An error
+// wrapping ErrRuleNotFound is returned if no such rule is loaded.
+func DeleteRule(s Netlink, r AuditRule) error {
+	rule, err := r.toRuleData()
+	if err != nil {
+		return errors.Wrap(err, "DeleteRule failed")
+	}
+	if err := auditDeleteRuleData(s, rule, uint32(r.Flags), uint32(r.Action)); err != nil {
+		// ENOENT of AUDIT_DEL_RULE means no matching rule is loaded, other requests return it for missing paths
+		if errors.Cause(err) == syscall.ENOENT {
+			return errors.Wrap(ErrRuleNotFound, "DeleteRule failed")
+		}
+		return errors.Wrap(err, "DeleteRule failed")
+	}
+	return nil
+}
+
+// EnsureRules makes the rules loaded in the kernel equal to the desired rule set, it lists the loaded rules and
+// only adds the desired rules which are missing and deletes the loaded rules which are not desired (see DiffRules).
+// Missing rules are added before the stale rules are deleted, so there is no window in which neither is loaded
+// and rules which are already loaded are left untouched. Added rules are appended to their filter list, the
+// order of the rules is only kept for rule sets loaded in full (SetRulesFromJSON).
+// The desired rules are validated before the kernel is changed, invalid rules are reported as RuleErrors.
+// It returns the rules it added and deleted, if it fails midway the rule set is partially applied and EnsureRules
+// can be called again.
+func EnsureRules(s Netlink, desired []AuditRule) (added, deleted []AuditRule, err error) {
+	if errs := ValidateRules(desired); errs != nil {
+		return nil, nil, errors.Wrap(RuleErrors(errs), "EnsureRules failed")
+	}
+	actual, err := ListAllRulesParsed(s)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "EnsureRules failed")
+	}
+	toAdd, toDelete := DiffRules(desired, actual)
+	for i := range toAdd {
+		rule, err := toAdd[i].toRuleData()
+		if err == nil {
+			err = auditAddRuleData(s, rule, int(toAdd[i].Flags), int(toAdd[i].Action))
+		}
+		if err != nil {
+			return added, deleted, errors.Wrap(err, "EnsureRules failed")
+		}
+		added = append(added, toAdd[i])
+	}
+	for i := range toDelete {
+		if err := DeleteRule(s, toDelete[i]); err != nil && errors.Cause(err) != ErrRuleNotFound {
+			return added, deleted, errors.Wrap(err, "EnsureRules failed")
+		}
+		deleted = append(deleted, toDelete[i])
+	}
+	return added, deleted, nil
+}
+
+// NewWatchRule returns the watch rule of auditctl -w <path> -p <perms> -k <key>, a rule on the exit list
+// matching the accesses of path. Like auditctl it watches the whole directory tree if path is a directory
+// (the dir field, path otherwise), path must be absolute. perms is a combination of "rwxa" (all if empty)
+// and key is optional.
+func NewWatchRule(path, perms, key string) (AuditRule, error) {
+	if len(path) == 0 {
+		return AuditRule{}, errors.Wrap(errPathStart, "NewWatchRule failed")
+	}
+	if err := checkPath(path); err != nil {
+		return AuditRule{}, errors.Wrap(err, "NewWatchRule failed")
+	}
+	if len(perms) == 0 {
+		perms = "rwxa"
+	}
+	if _, err := EncodePerm(perms); err != nil {
+		return AuditRule{}, errors.Wrap(err, "NewWatchRule failed")
+	}
+	name := "path"
+	if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+		name = "dir"
+	}
+	// Trim trailing '/' should they exist, but keep the root
+	if trimmed := strings.TrimRight(path, "/"); len(trimmed) > 0 {
+		path = trimmed
+	}
+	r := AuditRule{
+		Flags:  AUDIT_FILTER_EXIT,
+		Action: AUDIT_ALWAYS,
+		Fields: []AuditRuleField{{Name: name, Op: "=", Value: path}, {Name: "perm", Op: "=", Value: perms}},
+	}
+	if len(key) > 0 {
+		r.Fields = append(r.Fields, AuditRuleField{Name: "key", Op: "=", Value: key})
+	}
+	return r, nil
+}
+
+// jsonAuditRule is the JSON representation of an AuditRule, see LoadRulesFromJSON
+type jsonAuditRule struct {
+	List     string           `json:"list"`
+	Action   string           `json:"action"`
+	Syscalls []string         `json:"syscalls"`
+	Fields   []AuditRuleField `json:"fields"`
+	// the watch rules of NewWatchRule
+	Watch string   `json:"watch"`
+	Perm  string   `json:"perm"`
+	Key   jsonKeys `json:"key"`
+}
+
+// jsonKeys are the keys of a rule of a JSON rule set, a single key or a list of keys
+type jsonKeys []string
+
+func (k *jsonKeys) UnmarshalJSON(data []byte) error {
+	var key string
+	if err := json.Unmarshal(data, &key); err == nil {
+		*k = jsonKeys{key}
+		return nil
+	}
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("key: expected a string or a list of strings, found %s", data)
+	}
+	*k = keys
+	return nil
+}
+
+// lineOf returns the line of the offset in data, starting at 1
+func lineOf(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// LoadRulesFromJSON parses and validates a JSON rule set without talking to the kernel, e.g. to lint
+// rule files. The rule set is a list of rules mirroring AuditRule:
+//
+//	[
+//	    {
+//	        "list": "exit",
+//	        "action": "always",
+//	        "syscalls": ["rename", "renameat"],
+//	        "fields": [
+//	            {"name": "arch", "op": "=", "value": "b64"},
+//	            {"name": "auid", "op": ">=", "value": "1000"},
+//	            {"name": "key", "op": "=", "value": "rename"}
+//	        ]
+//	    }
+//	]
+//
+// list is one of task, exit, user and exclude (ParseFilter), action one of never, possible and always
+// (ParseAction). syscalls may be omitted for all syscalls, operators are the symbols or names accepted by
+// ParseOperator and values are always strings. Unknown keys are rejected.
+// Watch rules (see NewWatchRule) are written without list, action, syscalls and fields, perm and key
+// are optional, key is a single key or a list of keys:
+//
+//	{"watch": "/etc/passwd", "perm": "wa", "key": "identity"}
+//	{"watch": "/etc/shadow", "key": ["identity", "secrets"]}
+//
+// Malformed JSON is reported with its line, invalid rules are reported as RuleErrors holding a *RuleError
+// with the line of the rule for every invalid rule.
+func LoadRulesFromJSON(data []byte) ([]AuditRule, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	t, err := dec.Token()
+	if err != nil {
+		if serr, ok := err.(*json.SyntaxError); ok {
+			return nil, fmt.Errorf("LoadRulesFromJSON failed: line %d: %v", lineOf(data, serr.Offset), err)
+		}
+		return nil, errors.Wrap(err, "LoadRulesFromJSON failed")
+	}
+	if d, ok := t.(json.Delim); !ok || d != '[' {
+		return nil, fmt.Errorf("LoadRulesFromJSON failed: line 1: rule set is not a JSON list")
+	}
+	var (
+		rules []AuditRule
+		errs  RuleErrors
+	)
+	for i := 0; dec.More(); i++ {
+		// the rule starts after the separator following the previous token
+		start := dec.InputOffset()
+		for start < int64(len(data)) && strings.IndexByte(" \t\r\n,", data[start]) >= 0 {
+			start++
+		}
+		line := lineOf(data, start)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if serr, ok := err.(*json.SyntaxError); ok {
+				return nil, fmt.Errorf("LoadRulesFromJSON failed: line %d: %v", lineOf(data, serr.Offset), err)
+			}
+			return nil, errors.Wrap(err, fmt.Sprintf("LoadRulesFromJSON failed: line %d", line))
+		}
+		rule, rerr := jsonToAuditRule(raw)
+		if rerr == nil {
+			rerr = validateRule(&rule)
+		}
+		if rerr != nil {
+			rerr.Index = i
+			if rerr.Line == 0 {
+				rerr.Line = line
+			} else {
+				rerr.Line += line - 1
+			}
+			errs = append(errs, rerr)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, errors.Wrap(err, "LoadRulesFromJSON failed")
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return rules, nil
+}
+
+// jsonToAuditRule converts a single rule of a JSON rule set, the line of the returned error
+// is relative to the rule
+func jsonToAuditRule(raw json.RawMessage) (AuditRule, *RuleError) {
+	var (
+		jr   jsonAuditRule
+		rule AuditRule
+	)
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&jr); err != nil {
+		rerr := &RuleError{Err: err}
+		if terr, ok := err.(*json.UnmarshalTypeError); ok {
+			rerr.Line = lineOf(raw, terr.Offset)
+			rerr.Field = terr.Field
+		}
+		return rule, rerr
+	}
+	if len(jr.Watch) > 0 {
+		if len(jr.List) > 0 || len(jr.Action) > 0 || jr.Syscalls != nil || jr.Fields != nil {
+			return rule, &RuleError{Field: "watch", Err: fmt.Errorf("watches don't take list, action, syscalls or fields")}
+		}
+		rule, err := NewWatchRule(jr.Watch, jr.Perm, "")
+		if err != nil {
+			return rule, &RuleError{Field: "watch", Err: errors.Cause(err)}
+		}
+		for _, key := range jr.Key {
+			rule.Fields = append(rule.Fields, AuditRuleField{Name: "key", Op: "=", Value: key})
+		}
+		return rule, nil
+	}
+	if len(jr.Perm) > 0 || len(jr.Key) > 0 {
+		return rule, &RuleError{Field: "perm", Err: fmt.Errorf("perm and key are only valid for watches, use fields")}
+	}
+	filter, err := ParseFilter(jr.List)
+	if err != nil {
+		return rule, &RuleError{Field: "list", Err: err}
+	}
+	action, err := ParseAction(jr.Action)
+	if err != nil {
+		return rule, &RuleError{Field: "action", Err: err}
+	}
+	for _, f := range jr.Fields {
+		if len(f.Name) == 0 || len(f.Op) == 0 {
+			return rule, &RuleError{Field: "fields", Err: fmt.Errorf("name and op are required")}
+		}
+	}
+	rule.Flags = filter
+	rule.Action = action
+	rule.Syscalls = jr.Syscalls
+	rule.Fields = jr.Fields
+	return rule, nil
+}
+
+// MarshalRule is the dry run of adding the rule r, it returns the payload of the AUDIT_ADD_RULE request
+// and the rule as auditctl command (without the auditctl prefix, i.e. -a always,exit -S rename -k rename)
+// without talking to the kernel. The encoding is the one of SetRulesFromJSON.
+func MarshalRule(r AuditRule) ([]byte, string, error) {
+	rule, err := r.toRuleData()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "MarshalRule failed")
+	}
+	payload, err := auditRuleWireFormat(rule, int(r.Flags), int(r.Action))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "MarshalRule failed")
+	}
+	return payload, printRule(rule), nil
+}
+
+// MarshalRulesText returns the rules as rule file in auditctl syntax, one rule per line in the format of
+// auditctl -l (see ParseAuditctlRules for the inverse). Rules prepended with AUDIT_FILTER_PREPEND are
+// written with -a, the text lists the rules in the order they are evaluated like auditctl -l.
+func MarshalRulesText(rules []AuditRule) ([]byte, error) {
+	var buf bytes.Buffer
+	for i := range rules {
+		rule, err := rules[i].toRuleData()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("MarshalRulesText failed: rule %d", i))
+		}
+		buf.WriteString(printRule(rule))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// DumpRulesText lists all audit rules currently loaded in the kernel in the text format of auditctl -l,
+// one rule per line. Unlike auditctl it returns an empty listing rather than "No rules" if no rules are
+// loaded, so the output can be diffed against rule files.
+func DumpRulesText(s Netlink) ([]byte, error) {
+	printed, _, err := ListAllRules(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "DumpRulesText failed")
+	}
+	var buf bytes.Buffer
+	for _, p := range printed {
+		buf.WriteString(p)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// SetRulesFromJSON loads the JSON rule set (see LoadRulesFromJSON) into the kernel, SetRules takes
+// these rule sets as well. The whole rule set is validated before the first rule is sent.
+func SetRulesFromJSON(s Netlink, data []byte) error {
+	if _, err := setJSONRuleList(s, data, SetRulesOptions{}); err != nil {
+		return errors.Wrap(err, "SetRulesFromJSON failed")
+	}
+	return nil
+}
+
+// isJSONRuleList reports whether content is a JSON rule set in the list form of LoadRulesFromJSON
+func isJSONRuleList(content []byte) bool {
+	content = bytes.TrimLeft(content, " \t\r\n")
+	return len(content) > 0 && content[0] == '['
+}
+
+// setJSONRuleList adds the rules of a JSON rule set in the list form of LoadRulesFromJSON, the errors
+// are those of LoadRulesFromJSON or identify the rule the kernel rejected
+func setJSONRuleList(s Netlink, content []byte, opts SetRulesOptions) ([]*AuditRuleData, error) {
+	rules, err := LoadRulesFromJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ExpandArch {
+		if rules, err = ExpandArchRules(rules); err != nil {
+			return nil, err
+		}
+	}
+	var ruleArray []*AuditRuleData
+	for i := range rules {
+		rule, err := rules[i].toRuleData()
+		if err == nil {
+			err = auditAddRuleData(s, rule, int(rules[i].Flags), int(rules[i].Action))
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("rule %d", i))
+		}
+		ruleArray = append(ruleArray, rule)
+	}
+	return ruleArray, nil
+}
+
+// dumpedAuditRule is jsonAuditRule as written by MarshalRulesJSON, syscalls are omitted for rules on
+// all syscalls while an empty list is kept
+type dumpedAuditRule struct {
+	List     string           `json:"list"`
+	Action   string           `json:"action"`
+	Syscalls *[]string        `json:"syscalls,omitempty"`
+	Fields   []AuditRuleField `json:"fields,omitempty"`
+}
+
+// MarshalRulesJSON is the inverse of LoadRulesFromJSON, it returns the JSON rule set of the rules.
+// Syscalls are written by name (numbers are kept for syscalls unknown to the arch of the rule) and
+// operators by symbol, loading the result returns rules equal to rules.
+func MarshalRulesJSON(rules []AuditRule) ([]byte, error) {
+	dumped := make([]dumpedAuditRule, 0, len(rules))
+	for i, r := range rules {
+		d := dumpedAuditRule{
+			List:   r.Flags.String(),
+			Action: r.Action.String(),
+			Fields: r.Fields,
+		}
+		if _, err := ParseFilter(d.List); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("MarshalRulesJSON failed: rule %d", i))
+		}
+		if _, err := ParseAction(d.Action); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("MarshalRulesJSON failed: rule %d", i))
+		}
+		if r.Syscalls != nil {
+			syscalls := r.Syscalls
+			d.Syscalls = &syscalls
+		}
+		dumped = append(dumped, d)
+	}
+	data, err := json.MarshalIndent(dumped, "", "    ")
+	if err != nil {
+		return nil, errors.Wrap(err, "MarshalRulesJSON failed")
+	}
+	return data, nil
+}
+
+// DumpRulesJSON lists the rules loaded in the kernel as JSON rule set (see MarshalRulesJSON), which
+// SetRulesFromJSON restores, e.g. to back up the rules or to detect drift against a rule file.
+func DumpRulesJSON(s Netlink) ([]byte, error) {
+	rules, err := ListAllRulesParsed(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "DumpRulesJSON failed")
+	}
+	data, err := MarshalRulesJSON(rules)
+	if err != nil {
+		return nil, errors.Wrap(err, "DumpRulesJSON failed")
+	}
+	return data, nil
+}
@@ -0,0 +1,646 @@
+package libaudit
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	testRuleRename = AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"rename", "renameat"},
+		Fields: []AuditRuleField{
+			{Name: "arch", Op: "=", Value: "b64"},
+			{Name: "auid", Op: ">=", Value: "1000"},
+			{Name: "key", Op: "=", Value: "rename"},
+		},
+	}
+	testRuleWatch = AuditRule{
+		Flags:  AUDIT_FILTER_EXIT,
+		Action: AUDIT_ALWAYS,
+		Fields: []AuditRuleField{
+			{Name: "path", Op: "=", Value: "/etc/passwd"},
+			{Name: "perm", Op: "=", Value: "wa"},
+			{Name: "key", Op: "=", Value: "passwd"},
+		},
+	}
+	testRuleExec = AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"execve"},
+		Fields: []AuditRuleField{
+			{Name: "arch", Op: "=", Value: "b64"},
+			{Name: "key", Op: "=", Value: "exec"},
+		},
+	}
+)
+
+func TestAuditRuleEqual(t *testing.T) {
+	// same rule with reordered fields, syscalls and a different value notation
+	reordered := AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"renameat", "rename"},
+		Fields: []AuditRuleField{
+			{Name: "arch", Op: "=", Value: "b64"},
+			{Name: "loginuid", Op: ">=", Value: "0x3e8"},
+			{Name: "key", Op: "=", Value: "rename"},
+		},
+	}
+	if !testRuleRename.Equal(reordered) {
+		t.Errorf("Equal: expected %v and %v to be equal", testRuleRename, reordered)
+	}
+	changed := reordered
+	changed.Fields = []AuditRuleField{
+		{Name: "arch", Op: "=", Value: "b64"},
+		{Name: "auid", Op: ">", Value: "1000"},
+		{Name: "key", Op: "=", Value: "rename"},
+	}
+	if testRuleRename.Equal(changed) {
+		t.Errorf("Equal: expected %v and %v to differ", testRuleRename, changed)
+	}
+}
+
+// TestAuditRuleConcurrent runs the helpers building the kernel representation of rules in parallel,
+// go test -race reports shared state
+func TestAuditRuleConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if !testRuleRename.Equal(testRuleRename) {
+					t.Errorf("Equal: expected %v to equal itself", testRuleRename)
+					return
+				}
+				if err := ValidateRules([]AuditRule{testRuleRename, testRuleWatch}); err != nil {
+					t.Errorf("ValidateRules failed %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNewAuditRule(t *testing.T) {
+	for _, r := range []AuditRule{testRuleRename, testRuleWatch, testRuleExec} {
+		data, err := r.toRuleData()
+		if err != nil {
+			t.Fatalf("toRuleData failed %v", err)
+		}
+		parsed := NewAuditRule(data)
+		if !r.Equal(parsed) {
+			t.Errorf("NewAuditRule: expected %v, found %v", r, parsed)
+		}
+		parsed.raw = nil
+		if !reflect.DeepEqual(r, parsed) {
+			t.Errorf("NewAuditRule: expected %+v, found %+v", r, parsed)
+		}
+	}
+}
+
+func TestDiffRules(t *testing.T) {
+	var actual []AuditRule
+	for _, r := range []AuditRule{testRuleWatch, testRuleExec} {
+		data, err := r.toRuleData()
+		if err != nil {
+			t.Fatalf("toRuleData failed %v", err)
+		}
+		actual = append(actual, NewAuditRule(data))
+	}
+	toAdd, toDelete := DiffRules([]AuditRule{testRuleRename, testRuleWatch, testRuleRename}, actual)
+	if len(toAdd) != 1 || !toAdd[0].Equal(testRuleRename) {
+		t.Errorf("DiffRules: expected to add %v, found %v", testRuleRename, toAdd)
+	}
+	if len(toDelete) != 1 || !toDelete[0].Equal(testRuleExec) {
+		t.Errorf("DiffRules: expected to delete %v, found %v", testRuleExec, toDelete)
+	}
+
+	toAdd, toDelete = DiffRules(actual, actual)
+	if len(toAdd) != 0 || len(toDelete) != 0 {
+		t.Errorf("DiffRules: expected no changes, found add %v delete %v", toAdd, toDelete)
+	}
+}
+
+func TestListAllRulesParsed(t *testing.T) {
+	data, err := testRuleWatch.toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{
+				testReplyMessage(request, uint16(AUDIT_LIST_RULES), data.toWireFormat()),
+				testReplyMessage(request, syscall.NLMSG_DONE, nil),
+			}
+		},
+	}
+	rules, err := ListAllRulesParsed(s)
+	if err != nil {
+		t.Fatalf("ListAllRulesParsed failed %v", err)
+	}
+	if len(rules) != 1 || !rules[0].Equal(testRuleWatch) {
+		t.Errorf("ListAllRulesParsed: expected %v, found %v", testRuleWatch, rules)
+	}
+}
+
+func TestDeleteRule(t *testing.T) {
+	loaded := true
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			if !loaded {
+				return []NetlinkMessage{testAckMessage(request, int32(syscall.ENOENT))}
+			}
+			loaded = false
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	if err := DeleteRule(s, testRuleRename); err != nil {
+		t.Fatalf("DeleteRule failed %v", err)
+	}
+	payload, _, err := MarshalRule(testRuleRename)
+	if err != nil {
+		t.Fatalf("MarshalRule failed %v", err)
+	}
+	if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_DEL_RULE) || !bytes.Equal(s.sent[0].Data, payload) {
+		t.Errorf("DeleteRule: expected AUDIT_DEL_RULE request of the rule, found %+v", s.sent)
+	}
+	if err := DeleteRule(s, testRuleRename); errors.Cause(err) != ErrRuleNotFound {
+		t.Errorf("DeleteRule: expected ErrRuleNotFound, found %v", err)
+	}
+	if err := DeleteRule(s, AuditRule{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Syscalls: []string{"nosuchsyscall"}}); err == nil {
+		t.Errorf("DeleteRule: expected error for an invalid rule")
+	}
+}
+
+// testKernelRulesConn returns a testReplyNetlinkConn emulating the rule list of the kernel
+func testKernelRulesConn(loaded []AuditRule) (*testReplyNetlinkConn, *[][]byte) {
+	var rules [][]byte
+	for _, r := range loaded {
+		data, err := r.toRuleData()
+		if err != nil {
+			panic(err)
+		}
+		rules = append(rules, data.toWireFormat())
+	}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			switch request.Header.Type {
+			case uint16(AUDIT_LIST_RULES):
+				var msgs []NetlinkMessage
+				for _, data := range rules {
+					msgs = append(msgs, testReplyMessage(request, uint16(AUDIT_LIST_RULES), data))
+				}
+				return append(msgs, testReplyMessage(request, syscall.NLMSG_DONE, nil))
+			case uint16(AUDIT_ADD_RULE):
+				rules = append(rules, request.Data)
+			case uint16(AUDIT_DEL_RULE):
+				for i, data := range rules {
+					if bytes.Equal(data, request.Data) {
+						rules = append(rules[:i], rules[i+1:]...)
+						return []NetlinkMessage{testAckMessage(request, 0)}
+					}
+				}
+				return []NetlinkMessage{testAckMessage(request, int32(syscall.ENOENT))}
+			}
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	return s, &rules
+}
+
+func TestEnsureRules(t *testing.T) {
+	s, loaded := testKernelRulesConn([]AuditRule{testRuleRename, testRuleWatch})
+	added, deleted, err := EnsureRules(s, []AuditRule{testRuleWatch, testRuleExec})
+	if err != nil {
+		t.Fatalf("EnsureRules failed %v", err)
+	}
+	if len(added) != 1 || !added[0].Equal(testRuleExec) || len(deleted) != 1 || !deleted[0].Equal(testRuleRename) {
+		t.Errorf("EnsureRules: expected to add %v and delete %v, found %v %v", testRuleExec, testRuleRename, added, deleted)
+	}
+	var types []uint16
+	for _, m := range s.sent {
+		types = append(types, m.Header.Type)
+	}
+	// the missing rule is added before the stale one is deleted
+	expected := []uint16{uint16(AUDIT_LIST_RULES), uint16(AUDIT_ADD_RULE), uint16(AUDIT_DEL_RULE)}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("EnsureRules: expected requests %v, found %v", expected, types)
+	}
+	if len(*loaded) != 2 {
+		t.Errorf("EnsureRules: expected 2 loaded rules, found %d", len(*loaded))
+	}
+
+	s.sent = nil
+	added, deleted, err = EnsureRules(s, []AuditRule{testRuleExec, testRuleWatch})
+	if err != nil || len(added) != 0 || len(deleted) != 0 || len(s.sent) != 1 {
+		t.Errorf("EnsureRules: expected no changes, found %v %v %v (%d requests)", added, deleted, err, len(s.sent))
+	}
+
+	s.sent = nil
+	_, _, err = EnsureRules(s, []AuditRule{{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Syscalls: []string{"nosuchsyscall"}}})
+	if _, ok := errors.Cause(err).(RuleErrors); !ok || len(s.sent) != 0 {
+		t.Errorf("EnsureRules: expected RuleErrors before changing the kernel, found %v (%d requests)", err, len(s.sent))
+	}
+}
+
+func TestWatchRulesJSON(t *testing.T) {
+	rules, err := LoadRulesFromJSON([]byte(`[
+	{"watch": "/etc/passwd", "perm": "wa", "key": "passwd"},
+	{"watch": "/etc/shadow"},
+	{"watch": "/etc/shadow", "key": ["identity", "secrets"]}
+]`))
+	if err != nil {
+		t.Fatalf("LoadRulesFromJSON failed %v", err)
+	}
+	shadow, err := NewWatchRule("/etc/shadow", "rwxa", "")
+	if err != nil {
+		t.Fatalf("NewWatchRule failed %v", err)
+	}
+	if len(rules) != 3 || !reflect.DeepEqual(rules[0], testRuleWatch) || !reflect.DeepEqual(rules[1], shadow) {
+		t.Errorf("LoadRulesFromJSON: expected %v %v, found %v", testRuleWatch, shadow, rules)
+	}
+	if keys := rules[2].Keys(); !reflect.DeepEqual(keys, []string{"identity", "secrets"}) {
+		t.Errorf("LoadRulesFromJSON: expected keys identity and secrets, found %q", keys)
+	}
+
+	_, err = LoadRulesFromJSON([]byte(`[
+	{"watch": "/etc/passwd", "list": "exit"},
+	{"watch": "etc/passwd"},
+	{"watch": "/etc/passwd", "perm": "rwz"},
+	{"list": "exit", "action": "always", "key": "passwd"},
+	{"watch": "/etc/passwd", "key": ["passwd", 1]}
+]`))
+	errs, ok := err.(RuleErrors)
+	if !ok || len(errs) != 5 {
+		t.Fatalf("LoadRulesFromJSON: expected 5 errors, found %v", err)
+	}
+	for i, err := range errs {
+		if rerr := err.(*RuleError); rerr.Line != i+2 {
+			t.Errorf("LoadRulesFromJSON: expected error in line %d, found %v", i+2, rerr)
+		}
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	rules := []AuditRule{
+		testRuleRename,
+		{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Syscalls: []string{"execve", "nosuchsyscall"}},
+		{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Fields: []AuditRuleField{{Name: "nosuchfield", Op: "=", Value: "1"}}},
+		{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Fields: []AuditRuleField{{Name: "uid", Op: "=~", Value: "1"}}},
+		{Flags: AUDIT_FILTER_TASK, Action: AUDIT_ALWAYS, Fields: []AuditRuleField{{Name: "perm", Op: "=", Value: "wa"}}},
+		{Flags: AUDIT_FILTER_ENTRY, Action: AUDIT_ALWAYS},
+		testRuleWatch,
+		{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_POSSIBLE, Syscalls: []string{"execve"}},
+		{Flags: AUDIT_FILTER_USER, Action: AUDIT_ALWAYS, Syscalls: []string{"execve"}},
+		{Flags: AUDIT_FILTER_TASK, Action: AUDIT_ALWAYS, Fields: []AuditRuleField{{Name: "filetype", Op: "=", Value: "file"}}},
+	}
+	expected := []struct {
+		index int
+		field string
+	}{
+		{1, "nosuchsyscall"},
+		{2, "nosuchfield"},
+		{3, "uid"},
+		{4, "perm"},
+		{5, ""},
+		{7, ""},
+		{8, "execve"},
+		{9, "filetype"},
+	}
+	errs := ValidateRules(rules)
+	if len(errs) != len(expected) {
+		t.Fatalf("ValidateRules: expected %d errors, found %v", len(expected), errs)
+	}
+	for i, err := range errs {
+		rerr, ok := err.(*RuleError)
+		if !ok {
+			t.Fatalf("ValidateRules: expected *RuleError, found %T", err)
+		}
+		if rerr.Index != expected[i].index || rerr.Field != expected[i].field {
+			t.Errorf("ValidateRules: expected rule %d field %q, found %v", expected[i].index, expected[i].field, rerr)
+		}
+	}
+	if errs := ValidateRules([]AuditRule{testRuleRename, testRuleWatch, testRuleExec}); errs != nil {
+		t.Errorf("ValidateRules: unexpected errors %v", errs)
+	}
+}
+
+func TestLoadRulesFromJSON(t *testing.T) {
+	valid := []byte(`[
+    {
+        "list": "exit",
+        "action": "always",
+        "syscalls": ["rename", "renameat"],
+        "fields": [
+            {"name": "arch", "op": "=", "value": "b64"},
+            {"name": "auid", "op": "gt_or_eq", "value": "1000"},
+            {"name": "key", "op": "=", "value": "rename"}
+        ]
+    },
+    {
+        "list": "exit",
+        "action": "always",
+        "fields": [
+            {"name": "path", "op": "=", "value": "/etc/passwd"},
+            {"name": "perm", "op": "=", "value": "wa"},
+            {"name": "key", "op": "=", "value": "passwd"}
+        ]
+    }
+]`)
+	rules, err := LoadRulesFromJSON(valid)
+	if err != nil {
+		t.Fatalf("LoadRulesFromJSON failed %v", err)
+	}
+	if len(rules) != 2 || !rules[0].Equal(testRuleRename) || !rules[1].Equal(testRuleWatch) {
+		t.Errorf("LoadRulesFromJSON: expected %v %v, found %v", testRuleRename, testRuleWatch, rules)
+	}
+
+	invalid := []byte(`[
+    {"list": "exit", "action": "always", "syscalls": ["execve"]},
+    {"list": "exitt", "action": "always"},
+    {"list": "exit", "action": "always", "syscalls": ["nosuchsyscall"]},
+    {"list": "exit", "action": "always", "priority": 1},
+    {
+        "list": "exit",
+        "action": "always",
+        "fields": [{"name": "uid", "op": "=", "value": 0}]
+    }
+]`)
+	expected := []struct {
+		index, line int
+		field       string
+	}{
+		{1, 3, "list"},
+		{2, 4, "nosuchsyscall"},
+		{3, 5, ""},
+		{4, 9, "fields"},
+	}
+	_, err = LoadRulesFromJSON(invalid)
+	errs, ok := err.(RuleErrors)
+	if !ok || len(errs) != len(expected) {
+		t.Fatalf("LoadRulesFromJSON: expected %d rule errors, found %v", len(expected), err)
+	}
+	for i, err := range errs {
+		rerr := err.(*RuleError)
+		if rerr.Index != expected[i].index || rerr.Line != expected[i].line || !strings.HasPrefix(rerr.Field, expected[i].field) {
+			t.Errorf("LoadRulesFromJSON: expected rule %d line %d field %q, found %v", expected[i].index, expected[i].line, expected[i].field, rerr)
+		}
+	}
+
+	if _, err := LoadRulesFromJSON([]byte("[\n{\"list\": \"exit\",,}\n]")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("LoadRulesFromJSON: expected syntax error on line 2, found %v", err)
+	}
+	if _, err := LoadRulesFromJSON([]byte(`{"syscall_rules": []}`)); err == nil {
+		t.Errorf("LoadRulesFromJSON: expected error for an object")
+	}
+}
+
+func TestDumpRulesJSON(t *testing.T) {
+	var replies [][]byte
+	for _, r := range []AuditRule{testRuleRename, testRuleWatch, {Flags: AUDIT_FILTER_EXIT, Action: AUDIT_NEVER}} {
+		data, err := r.toRuleData()
+		if err != nil {
+			t.Fatalf("toRuleData failed %v", err)
+		}
+		replies = append(replies, data.toWireFormat())
+	}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			var msgs []NetlinkMessage
+			for _, data := range replies {
+				msgs = append(msgs, testReplyMessage(request, uint16(AUDIT_LIST_RULES), data))
+			}
+			return append(msgs, testReplyMessage(request, syscall.NLMSG_DONE, nil))
+		},
+	}
+	dumped, err := DumpRulesJSON(s)
+	if err != nil {
+		t.Fatalf("DumpRulesJSON failed %v", err)
+	}
+	if !strings.Contains(string(dumped), `"renameat"`) || strings.Count(string(dumped), `"syscalls"`) != 1 {
+		t.Errorf("DumpRulesJSON: unexpected rule set %s", dumped)
+	}
+	rules, err := LoadRulesFromJSON(dumped)
+	if err != nil {
+		t.Fatalf("LoadRulesFromJSON failed %v for %s", err, dumped)
+	}
+	if len(rules) != 3 || !rules[0].Equal(testRuleRename) || !rules[1].Equal(testRuleWatch) || rules[2].Syscalls != nil || rules[2].Action != AUDIT_NEVER {
+		t.Errorf("DumpRulesJSON: expected %v %v, found %v", testRuleRename, testRuleWatch, rules)
+	}
+	again, err := MarshalRulesJSON(rules)
+	if err != nil || string(again) != string(dumped) {
+		t.Errorf("MarshalRulesJSON: expected %s, found %s %v", dumped, again, err)
+	}
+
+	if _, err := MarshalRulesJSON([]AuditRule{{Flags: 42, Action: AUDIT_ALWAYS}}); err == nil {
+		t.Errorf("MarshalRulesJSON: expected error for an unknown filter")
+	}
+	if empty, err := MarshalRulesJSON(nil); err != nil || string(empty) != "[]" {
+		t.Errorf("MarshalRulesJSON: expected an empty list, found %s %v", empty, err)
+	}
+}
+
+func TestDumpRulesText(t *testing.T) {
+	keys := testRuleWatch
+	keys.Fields = append([]AuditRuleField(nil), testRuleWatch.Fields...)
+	keys.Fields[2].Value = "passwd\x01identity"
+	var replies [][]byte
+	for _, r := range []AuditRule{testRuleRename, keys} {
+		data, err := r.toRuleData()
+		if err != nil {
+			t.Fatalf("toRuleData failed %v", err)
+		}
+		replies = append(replies, data.toWireFormat())
+	}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			var msgs []NetlinkMessage
+			for _, data := range replies {
+				msgs = append(msgs, testReplyMessage(request, uint16(AUDIT_LIST_RULES), data))
+			}
+			return append(msgs, testReplyMessage(request, syscall.NLMSG_DONE, nil))
+		},
+	}
+	dumped, err := DumpRulesText(s)
+	if err != nil {
+		t.Fatalf("DumpRulesText failed %v", err)
+	}
+	expected := "-a always,exit -F arch=b64 -S rename,renameat -F auid>=1000 -F key=rename\n" +
+		"-w /etc/passwd -p wa -k passwd -k identity\n"
+	if string(dumped) != expected {
+		t.Errorf("DumpRulesText: expected %q, found %q", expected, dumped)
+	}
+}
+func TestSetRulesFromJSON(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	if err := SetRulesFromJSON(s, []byte(`[{"list": "exit", "action": "always", "syscalls": ["nosuchsyscall"]}]`)); err == nil || len(s.sent) != 0 {
+		t.Errorf("SetRulesFromJSON: expected error before sending, found %v %v", err, s.sent)
+	}
+	if err := SetRulesFromJSON(s, []byte(`[{"list": "exit", "action": "always", "syscalls": ["execve"], "fields": [{"name": "arch", "op": "=", "value": "b64"}, {"name": "key", "op": "=", "value": "exec"}]}]`)); err != nil {
+		t.Fatalf("SetRulesFromJSON failed %v", err)
+	}
+	if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_ADD_RULE) {
+		t.Fatalf("SetRulesFromJSON: expected AUDIT_ADD_RULE, found %v", s.sent)
+	}
+	if rule := NewAuditRule(testUnpackRule(t, s.sent[0].Data)); !rule.Equal(testRuleExec) {
+		t.Errorf("SetRulesFromJSON: expected %v, found %v", testRuleExec, rule)
+	}
+
+	// SetRules takes the list form the same way
+	s.sent = nil
+	rules, err := SetRules(s, []byte(`  [{"list": "exit", "action": "always", "syscalls": ["execve"], "fields": [{"name": "arch", "op": "=", "value": "b64"}, {"name": "key", "op": "=", "value": "exec"}]}]`))
+	if err != nil {
+		t.Fatalf("SetRules failed %v", err)
+	}
+	if len(rules) != 1 || len(s.sent) != 1 || !NewAuditRule(testUnpackRule(t, s.sent[0].Data)).Equal(testRuleExec) {
+		t.Errorf("SetRules: expected %v, found %v", testRuleExec, s.sent)
+	}
+	s.sent = nil
+	if _, err := SetRules(s, []byte(`[{"list": "exit", "action": "always", "syscalls": ["nosuchsyscall"]}]`)); err == nil || len(s.sent) != 0 {
+		t.Errorf("SetRules: expected error before sending, found %v %v", err, s.sent)
+	} else if _, ok := errors.Cause(err).(RuleErrors); !ok {
+		t.Errorf("SetRules: expected RuleErrors, found %v", err)
+	}
+}
+
+func TestRuleFilterAction(t *testing.T) {
+	for name, value := range map[string]RuleFilter{
+		"task":    AUDIT_FILTER_TASK,
+		"exit":    AUDIT_FILTER_EXIT,
+		"user":    AUDIT_FILTER_USER,
+		"exclude": AUDIT_FILTER_EXCLUDE,
+	} {
+		f, err := ParseFilter(name)
+		if err != nil || f != value {
+			t.Errorf("ParseFilter: expected %v to be %d, found %d %v", name, value, f, err)
+		}
+		if f.String() != name {
+			t.Errorf("RuleFilter: expected %d to be %v, found %v", value, name, f)
+		}
+	}
+	for name, value := range map[string]RuleAction{
+		"never":    AUDIT_NEVER,
+		"possible": AUDIT_POSSIBLE,
+		"always":   AUDIT_ALWAYS,
+	} {
+		a, err := ParseAction(name)
+		if err != nil || a != value {
+			t.Errorf("ParseAction: expected %v to be %d, found %d %v", name, value, a, err)
+		}
+		if a.String() != name {
+			t.Errorf("RuleAction: expected %d to be %v, found %v", value, name, a)
+		}
+	}
+	if _, err := ParseFilter("exitt"); err == nil {
+		t.Errorf("ParseFilter: expected error for an unknown filter")
+	}
+	if _, err := ParseAction("sometimes"); err == nil {
+		t.Errorf("ParseAction: expected error for an unknown action")
+	}
+	if RuleFilter(9).String() != "filter(9)" || RuleAction(7).String() != "action(7)" {
+		t.Errorf("String: unexpected form of unknown values %v %v", RuleFilter(9), RuleAction(7))
+	}
+
+	// the kernel values are kept when converting rules
+	data, err := testRuleRename.toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if data.Flags != AUDIT_FILTER_EXIT || data.Action != AUDIT_ALWAYS {
+		t.Errorf("toRuleData: expected flags %d action %d, found %d %d", AUDIT_FILTER_EXIT, AUDIT_ALWAYS, data.Flags, data.Action)
+	}
+	if r := NewAuditRule(data); r.Flags.String() != "exit" || r.Action.String() != "always" {
+		t.Errorf("NewAuditRule: expected exit,always found %v,%v", r.Flags, r.Action)
+	}
+}
+
+func TestAnalyzeRules(t *testing.T) {
+	neverExec := AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_NEVER,
+		Syscalls: []string{"execve", "execveat"},
+		Fields:   []AuditRuleField{{Name: "arch", Op: "=", Value: "b64"}},
+	}
+	shadowed := AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"execve"},
+		Fields: []AuditRuleField{
+			{Name: "arch", Op: "=", Value: "b64"},
+			{Name: "auid", Op: ">=", Value: "1000"},
+			{Name: "key", Op: "=", Value: "user_exec"},
+		},
+	}
+	reordered := AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"renameat", "rename"},
+		Fields: []AuditRuleField{
+			{Name: "key", Op: "=", Value: "rename"},
+			{Name: "arch", Op: "=", Value: "b64"},
+			{Name: "auid", Op: "gt_or_eq", Value: "1000"},
+		},
+	}
+	rules := []AuditRule{
+		testRuleRename,
+		testRuleExec, // before the never rule, it is not shadowed
+		reordered,
+		neverExec,
+		shadowed,
+		testRuleWatch,
+		{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Syscalls: []string{"nosuchsyscall"}},
+	}
+	expected := []RuleWarning{
+		{Kind: RuleDuplicate, Index: 2, Other: 0},
+		{Kind: RuleShadowed, Index: 4, Other: 3},
+	}
+	if warnings := AnalyzeRules(rules); !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("AnalyzeRules: expected %v, found %v", expected, warnings)
+	}
+	if warnings := AnalyzeRules([]AuditRule{testRuleRename, testRuleWatch, testRuleExec}); len(warnings) != 0 {
+		t.Errorf("AnalyzeRules: unexpected warnings %v", warnings)
+	}
+}
+
+func TestMarshalRule(t *testing.T) {
+	payload, printed, err := MarshalRule(testRuleRename)
+	if err != nil {
+		t.Fatalf("MarshalRule failed %v", err)
+	}
+	if printed != "-a always,exit -F arch=b64 -S rename,renameat -F auid>=1000 -F key=rename" {
+		t.Errorf("MarshalRule: unexpected rule %q", printed)
+	}
+	// the dry run encodes the rule like adding it
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	data, err := testRuleRename.toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if err := auditAddRuleData(s, data, AUDIT_FILTER_EXIT, AUDIT_ALWAYS); err != nil {
+		t.Fatalf("auditAddRuleData failed %v", err)
+	}
+	if len(s.sent) != 1 || !reflect.DeepEqual(s.sent[0].Data[:len(payload)], payload) {
+		t.Errorf("MarshalRule: payload differs from the request sent")
+	}
+	if _, _, err := MarshalRule(AuditRule{Flags: AUDIT_FILTER_ENTRY, Action: AUDIT_ALWAYS}); err == nil {
+		t.Errorf("MarshalRule: expected error for the entry filter")
+	}
+}
@@ -4,7 +4,7 @@ package libaudit
 
 import "strconv"
 
-const _auditConstant_name = "AUDIT_COMPARE_UID_TO_OBJ_UIDAUDIT_COMPARE_GID_TO_OBJ_GIDAUDIT_COMPARE_EUID_TO_OBJ_UIDAUDIT_COMPARE_EGID_TO_OBJ_GIDAUDIT_COMPARE_AUID_TO_OBJ_UIDAUDIT_COMPARE_SUID_TO_OBJ_UIDAUDIT_COMPARE_SGID_TO_OBJ_GIDAUDIT_COMPARE_FSUID_TO_OBJ_UIDAUDIT_COMPARE_FSGID_TO_OBJ_GIDAUDIT_COMPARE_UID_TO_AUIDAUDIT_COMPARE_UID_TO_EUIDAUDIT_COMPARE_UID_TO_FSUIDAUDIT_COMPARE_UID_TO_SUIDAUDIT_COMPARE_AUID_TO_FSUIDAUDIT_COMPARE_AUID_TO_SUIDAUDIT_COMPARE_AUID_TO_EUIDAUDIT_COMPARE_EUID_TO_SUIDAUDIT_COMPARE_EUID_TO_FSUIDAUDIT_COMPARE_SUID_TO_FSUIDAUDIT_COMPARE_GID_TO_EGIDAUDIT_COMPARE_GID_TO_FSGIDAUDIT_COMPARE_GID_TO_SGIDAUDIT_COMPARE_EGID_TO_FSGIDAUDIT_COMPARE_EGID_TO_SGIDAUDIT_COMPARE_SGID_TO_FSGIDAUDIT_GETAUDIT_SETAUDIT_LISTAUDIT_ADDAUDIT_DELAUDIT_USERAUDIT_LOGINAUDIT_WATCH_INSAUDIT_WATCH_REMAUDIT_WATCH_LISTAUDIT_SIGNAL_INFOAUDIT_ADD_RULEAUDIT_DEL_RULEAUDIT_LIST_RULESAUDIT_TRIMAUDIT_MAKE_EQUIVAUDIT_TTY_GETAUDIT_TTY_SETAUDIT_SET_FEATUREAUDIT_GET_FEATUREAUDIT_FIRST_USER_MSGAUDIT_USER_ACCTAUDIT_USER_MGMTAUDIT_CRED_ACQAUDIT_CRED_DISPAUDIT_USER_STARTAUDIT_USER_ENDAUDIT_USER_AVCAUDIT_USER_CHAUTHTOKAUDIT_USER_ERRAUDIT_CRED_REFRAUDIT_USYS_CONFIGAUDIT_USER_LOGINAUDIT_USER_LOGOUTAUDIT_ADD_USERAUDIT_DEL_USERAUDIT_ADD_GROUPAUDIT_DEL_GROUPAUDIT_DAC_CHECKAUDIT_CHGRP_IDAUDIT_TESTAUDIT_TRUSTED_APPAUDIT_USER_SELINUX_ERRAUDIT_USER_CMDAUDIT_USER_TTYAUDIT_CHUSER_IDAUDIT_GRP_AUTHAUDIT_SYSTEM_BOOTAUDIT_SYSTEM_SHUTDOWNAUDIT_SYSTEM_RUNLEVELAUDIT_SERVICE_STARTAUDIT_SERVICE_STOPAUDIT_GRP_MGMTAUDIT_GRP_CHAUTHTOKAUDIT_MAC_CHECKAUDIT_ACCT_LOCKAUDIT_ACCT_UNLOCKAUDIT_LAST_USER_MSGAUDIT_FIRST_DAEMONAUDIT_DAEMON_CONFIGAUDIT_DAEMON_RECONFIGAUDIT_DAEMON_ROTATEAUDIT_DAEMON_RESUMEAUDIT_DAEMON_ACCEPTAUDIT_DAEMON_CLOSEAUDIT_LAST_DAEMONAUDIT_SYSCALLAUDIT_PATHAUDIT_IPCAUDIT_SOCKETCALLAUDIT_CONFIG_CHANGEAUDIT_SOCKADDRAUDIT_CWDAUDIT_EXECVEAUDIT_IPC_SET_PERMAUDIT_MQ_OPENAUDIT_MQ_SENDRECVAUDIT_MQ_NOTIFYAUDIT_MQ_GETSETATTRAUDIT_KERNEL_OTHERAUDIT_FD_PAIRAUDIT_OBJ_PIDAUDIT_TTYAUDIT_EOEAUDIT_BPRM_FCAPSAUDIT_CAPSETAUDIT_MMAPAUDIT_NETFILTER_PKTAUDIT_NETFILTER_CFGAUDIT_SECCOMPAUDIT_PROCTITLEAUDIT_FEATURE_CHANGEAUDIT_LAST_EVENTAUDIT_AVCAUDIT_SELINUX_ERRAUDIT_AVC_PATHAUDIT_MAC_POLICY_LOADAUDIT_MAC_STATUSAUDIT_MAC_CONFIG_CHANGEAUDIT_MAC_UNLBL_ALLOWAUDIT_MAC_CIPSOV4_ADDAUDIT_MAC_CIPSOV4_DELAUDIT_MAC_MAP_ADDAUDIT_MAC_MAP_DELAUDIT_MAC_IPSEC_ADDSAAUDIT_MAC_IPSEC_DELSAAUDIT_MAC_IPSEC_ADDSPDAUDIT_MAC_IPSEC_DELSPDAUDIT_MAC_IPSEC_EVENTAUDIT_MAC_UNLBL_STCADDAUDIT_MAC_UNLBL_STCDELAUDIT_LAST_SELINUXAUDIT_FIRST_APPARMORAUDIT_APPARMOR_AUDITAUDIT_APPARMOR_ALLOWEDAUDIT_APPARMOR_DENIEDAUDIT_APPARMOR_HTAUDIT_APPARMOR_STATUSAUDIT_APPARMOR_ERRORAUDIT_LAST_APPARMORAUDIT_FIRST_KERN_CRYPTO_MSGAUDIT_LAST_KERN_CRYPTO_MSGAUDIT_ANOM_PROMISCUOUSAUDIT_ANOM_ABENDAUDIT_ANOM_LINKAUDIT_LAST_KERN_ANOM_MSGAUDIT_INTEGRITY_FIRST_MSGAUDIT_INTEGRITY_METADATAAUDIT_INTEGRITY_STATUSAUDIT_INTEGRITY_HASHAUDIT_INTEGRITY_PCRAUDIT_INTEGRITY_RULEAUDIT_TINTEGRITY_LAST_MSGAUDIT_KERNELAUDIT_FIRST_ANOM_MSGAUDIT_ANOM_LOGIN_TIMEAUDIT_ANOM_LOGIN_SESSIONSAUDIT_ANOM_LOGIN_ACCTAUDIT_ANOM_LOGIN_LOCATIONAUDIT_ANOM_MAX_DACAUDIT_ANOM_MAX_MACAUDIT_ANOM_AMTU_FAILAUDIT_ANOM_RBAC_FAILAUDIT_ANOM_RBAC_INTEGRITY_FAILAUDIT_ANOM_CRYPTO_FAILAUDIT_ANOM_ACCESS_FSAUDIT_ANOM_EXECAUDIT_ANOM_MK_EXECAUDIT_ANOM_ADD_ACCTAUDIT_ANOM_DEL_ACCTAUDIT_ANOM_MOD_ACCTAUDIT_ANOM_ROOT_TRANSAUDIT_LAST_ANOM_MSGAUDIT_FIRST_ANOM_RESPAUDIT_RESP_ALERTAUDIT_RESP_KILL_PROCAUDIT_RESP_TERM_ACCESSAUDIT_RESP_ACCT_REMOTEAUDIT_RESP_ACCT_LOCK_TIMEDAUDIT_RESP_ACCT_UNLOCK_TIMEDAUDIT_RESP_ACCT_LOCKAUDIT_RESP_TERM_LOCKAUDIT_RESP_SEBOOLAUDIT_RESP_EXECAUDIT_RESP_SINGLEAUDIT_RESP_HALTAUDIT_LAST_ANOM_RESPAUDIT_FIRST_USER_LSPP_MSGAUDIT_ROLE_ASSIGNAUDIT_ROLE_REMOVEAUDIT_LABEL_OVERRIDEAUDIT_LABEL_LEVEL_CHANGEAUDIT_USER_LABELED_EXPORTAUDIT_USER_UNLABELED_EXPORTAUDIT_DEV_ALLOCAUDIT_DEV_DEALLOCAUDIT_FS_RELABELAUDIT_USER_MAC_POLICY_LOADAUDIT_ROLE_MODIFYAUDIT_USER_MAC_CONFIG_CHANGEAUDIT_LAST_USER_LSPP_MSGAUDIT_FIRST_CRYPTO_MSGAUDIT_CRYPTO_PARAM_CHANGE_USERAUDIT_CRYPTO_LOGINAUDIT_CRYPTO_LOGOUTAUDIT_CRYPTO_KEY_USERAUDIT_CRYPTO_FAILURE_USERAUDIT_CRYPTO_REPLAY_USERAUDIT_CRYPTO_SESSIONAUDIT_CRYPTO_IKE_SAAUDIT_CRYPTO_IPSEC_SAAUDIT_LAST_CRYPTO_MSGAUDIT_FIRST_VIRT_MSGAUDIT_VIRT_RESOURCEAUDIT_VIRT_MACHINE_IDAUDIT_LAST_VIRT_MSGAUDIT_LAST_USER_MSG2"
+const _auditConstant_name = "AUDIT_COMPARE_UID_TO_OBJ_UIDAUDIT_COMPARE_GID_TO_OBJ_GIDAUDIT_COMPARE_EUID_TO_OBJ_UIDAUDIT_COMPARE_EGID_TO_OBJ_GIDAUDIT_COMPARE_AUID_TO_OBJ_UIDAUDIT_COMPARE_SUID_TO_OBJ_UIDAUDIT_COMPARE_SGID_TO_OBJ_GIDAUDIT_COMPARE_FSUID_TO_OBJ_UIDAUDIT_COMPARE_FSGID_TO_OBJ_GIDAUDIT_COMPARE_UID_TO_AUIDAUDIT_COMPARE_UID_TO_EUIDAUDIT_COMPARE_UID_TO_FSUIDAUDIT_COMPARE_UID_TO_SUIDAUDIT_COMPARE_AUID_TO_FSUIDAUDIT_COMPARE_AUID_TO_SUIDAUDIT_COMPARE_AUID_TO_EUIDAUDIT_COMPARE_EUID_TO_SUIDAUDIT_COMPARE_EUID_TO_FSUIDAUDIT_COMPARE_SUID_TO_FSUIDAUDIT_COMPARE_GID_TO_EGIDAUDIT_COMPARE_GID_TO_FSGIDAUDIT_COMPARE_GID_TO_SGIDAUDIT_COMPARE_EGID_TO_FSGIDAUDIT_COMPARE_EGID_TO_SGIDAUDIT_COMPARE_SGID_TO_FSGIDAUDIT_GETAUDIT_SETAUDIT_LISTAUDIT_ADDAUDIT_DELAUDIT_USERAUDIT_LOGINAUDIT_WATCH_INSAUDIT_WATCH_REMAUDIT_WATCH_LISTAUDIT_SIGNAL_INFOAUDIT_ADD_RULEAUDIT_DEL_RULEAUDIT_LIST_RULESAUDIT_TRIMAUDIT_MAKE_EQUIVAUDIT_TTY_GETAUDIT_TTY_SETAUDIT_SET_FEATUREAUDIT_GET_FEATUREAUDIT_FIRST_USER_MSGAUDIT_USER_ACCTAUDIT_USER_MGMTAUDIT_CRED_ACQAUDIT_CRED_DISPAUDIT_USER_STARTAUDIT_USER_ENDAUDIT_USER_AVCAUDIT_USER_CHAUTHTOKAUDIT_USER_ERRAUDIT_CRED_REFRAUDIT_USYS_CONFIGAUDIT_USER_LOGINAUDIT_USER_LOGOUTAUDIT_ADD_USERAUDIT_DEL_USERAUDIT_ADD_GROUPAUDIT_DEL_GROUPAUDIT_DAC_CHECKAUDIT_CHGRP_IDAUDIT_TESTAUDIT_TRUSTED_APPAUDIT_USER_SELINUX_ERRAUDIT_USER_CMDAUDIT_USER_TTYAUDIT_CHUSER_IDAUDIT_GRP_AUTHAUDIT_SYSTEM_BOOTAUDIT_SYSTEM_SHUTDOWNAUDIT_SYSTEM_RUNLEVELAUDIT_SERVICE_STARTAUDIT_SERVICE_STOPAUDIT_GRP_MGMTAUDIT_GRP_CHAUTHTOKAUDIT_MAC_CHECKAUDIT_ACCT_LOCKAUDIT_ACCT_UNLOCKAUDIT_LAST_USER_MSGAUDIT_FIRST_DAEMONAUDIT_DAEMON_CONFIGAUDIT_DAEMON_RECONFIGAUDIT_DAEMON_ROTATEAUDIT_DAEMON_RESUMEAUDIT_DAEMON_ACCEPTAUDIT_DAEMON_CLOSEAUDIT_LAST_DAEMONAUDIT_SYSCALLAUDIT_PATHAUDIT_IPCAUDIT_SOCKETCALLAUDIT_CONFIG_CHANGEAUDIT_SOCKADDRAUDIT_CWDAUDIT_EXECVEAUDIT_IPC_SET_PERMAUDIT_MQ_OPENAUDIT_MQ_SENDRECVAUDIT_MQ_NOTIFYAUDIT_MQ_GETSETATTRAUDIT_KERNEL_OTHERAUDIT_FD_PAIRAUDIT_OBJ_PIDAUDIT_TTYAUDIT_EOEAUDIT_BPRM_FCAPSAUDIT_CAPSETAUDIT_MMAPAUDIT_NETFILTER_PKTAUDIT_NETFILTER_CFGAUDIT_SECCOMPAUDIT_PROCTITLEAUDIT_FEATURE_CHANGEAUDIT_LAST_EVENTAUDIT_AVCAUDIT_SELINUX_ERRAUDIT_AVC_PATHAUDIT_MAC_POLICY_LOADAUDIT_MAC_STATUSAUDIT_MAC_CONFIG_CHANGEAUDIT_MAC_UNLBL_ALLOWAUDIT_MAC_CIPSOV4_ADDAUDIT_MAC_CIPSOV4_DELAUDIT_MAC_MAP_ADDAUDIT_MAC_MAP_DELAUDIT_MAC_IPSEC_ADDSAAUDIT_MAC_IPSEC_DELSAAUDIT_MAC_IPSEC_ADDSPDAUDIT_MAC_IPSEC_DELSPDAUDIT_MAC_IPSEC_EVENTAUDIT_MAC_UNLBL_STCADDAUDIT_MAC_UNLBL_STCDELAUDIT_LAST_SELINUXAUDIT_FIRST_APPARMORAUDIT_APPARMOR_AUDITAUDIT_APPARMOR_ALLOWEDAUDIT_APPARMOR_DENIEDAUDIT_APPARMOR_HTAUDIT_APPARMOR_STATUSAUDIT_APPARMOR_ERRORAUDIT_LAST_APPARMORAUDIT_FIRST_KERN_CRYPTO_MSGAUDIT_LAST_KERN_CRYPTO_MSGAUDIT_ANOM_PROMISCUOUSAUDIT_ANOM_ABENDAUDIT_ANOM_LINKAUDIT_ANOM_CREATAUDIT_LAST_KERN_ANOM_MSGAUDIT_INTEGRITY_DATAAUDIT_INTEGRITY_METADATAAUDIT_INTEGRITY_STATUSAUDIT_INTEGRITY_HASHAUDIT_INTEGRITY_PCRAUDIT_INTEGRITY_RULEAUDIT_INTEGRITY_EVM_XATTRAUDIT_INTEGRITY_POLICY_RULEAUDIT_TINTEGRITY_LAST_MSGAUDIT_KERNELAUDIT_ANOM_LOGIN_FAILURESAUDIT_ANOM_LOGIN_TIMEAUDIT_ANOM_LOGIN_SESSIONSAUDIT_ANOM_LOGIN_ACCTAUDIT_ANOM_LOGIN_LOCATIONAUDIT_ANOM_MAX_DACAUDIT_ANOM_MAX_MACAUDIT_ANOM_AMTU_FAILAUDIT_ANOM_RBAC_FAILAUDIT_ANOM_RBAC_INTEGRITY_FAILAUDIT_ANOM_CRYPTO_FAILAUDIT_ANOM_ACCESS_FSAUDIT_ANOM_EXECAUDIT_ANOM_MK_EXECAUDIT_ANOM_ADD_ACCTAUDIT_ANOM_DEL_ACCTAUDIT_ANOM_MOD_ACCTAUDIT_ANOM_ROOT_TRANSAUDIT_ANOM_LOGIN_SERVICEAUDIT_ANOM_LOGIN_ROOTAUDIT_ANOM_ORIGIN_FAILURESAUDIT_ANOM_SESSIONAUDIT_LAST_ANOM_MSGAUDIT_RESP_ANOMALYAUDIT_RESP_ALERTAUDIT_RESP_KILL_PROCAUDIT_RESP_TERM_ACCESSAUDIT_RESP_ACCT_REMOTEAUDIT_RESP_ACCT_LOCK_TIMEDAUDIT_RESP_ACCT_UNLOCK_TIMEDAUDIT_RESP_ACCT_LOCKAUDIT_RESP_TERM_LOCKAUDIT_RESP_SEBOOLAUDIT_RESP_EXECAUDIT_RESP_SINGLEAUDIT_RESP_HALTAUDIT_LAST_ANOM_RESPAUDIT_FIRST_USER_LSPP_MSGAUDIT_ROLE_ASSIGNAUDIT_ROLE_REMOVEAUDIT_LABEL_OVERRIDEAUDIT_LABEL_LEVEL_CHANGEAUDIT_USER_LABELED_EXPORTAUDIT_USER_UNLABELED_EXPORTAUDIT_DEV_ALLOCAUDIT_DEV_DEALLOCAUDIT_FS_RELABELAUDIT_USER_MAC_POLICY_LOADAUDIT_ROLE_MODIFYAUDIT_USER_MAC_CONFIG_CHANGEAUDIT_LAST_USER_LSPP_MSGAUDIT_FIRST_CRYPTO_MSGAUDIT_CRYPTO_PARAM_CHANGE_USERAUDIT_CRYPTO_LOGINAUDIT_CRYPTO_LOGOUTAUDIT_CRYPTO_KEY_USERAUDIT_CRYPTO_FAILURE_USERAUDIT_CRYPTO_REPLAY_USERAUDIT_CRYPTO_SESSIONAUDIT_CRYPTO_IKE_SAAUDIT_CRYPTO_IPSEC_SAAUDIT_LAST_CRYPTO_MSGAUDIT_FIRST_VIRT_MSGAUDIT_VIRT_RESOURCEAUDIT_VIRT_MACHINE_IDAUDIT_LAST_VIRT_MSGAUDIT_LAST_USER_MSG2"
 
 var _auditConstant_map = map[auditConstant]string{
 	1:    _auditConstant_name[0:28],
@@ -157,81 +157,88 @@ var _auditConstant_map = map[auditConstant]string{
 	1700: _auditConstant_name[2662:2684],
 	1701: _auditConstant_name[2684:2700],
 	1702: _auditConstant_name[2700:2715],
-	1799: _auditConstant_name[2715:2739],
-	1800: _auditConstant_name[2739:2764],
-	1801: _auditConstant_name[2764:2788],
-	1802: _auditConstant_name[2788:2810],
-	1803: _auditConstant_name[2810:2830],
-	1804: _auditConstant_name[2830:2849],
-	1805: _auditConstant_name[2849:2869],
-	1899: _auditConstant_name[2869:2894],
-	2000: _auditConstant_name[2894:2906],
-	2100: _auditConstant_name[2906:2926],
-	2101: _auditConstant_name[2926:2947],
-	2102: _auditConstant_name[2947:2972],
-	2103: _auditConstant_name[2972:2993],
-	2104: _auditConstant_name[2993:3018],
-	2105: _auditConstant_name[3018:3036],
-	2106: _auditConstant_name[3036:3054],
-	2107: _auditConstant_name[3054:3074],
-	2108: _auditConstant_name[3074:3094],
-	2109: _auditConstant_name[3094:3124],
-	2110: _auditConstant_name[3124:3146],
-	2111: _auditConstant_name[3146:3166],
-	2112: _auditConstant_name[3166:3181],
-	2113: _auditConstant_name[3181:3199],
-	2114: _auditConstant_name[3199:3218],
-	2115: _auditConstant_name[3218:3237],
-	2116: _auditConstant_name[3237:3256],
-	2117: _auditConstant_name[3256:3277],
-	2199: _auditConstant_name[3277:3296],
-	2200: _auditConstant_name[3296:3317],
-	2201: _auditConstant_name[3317:3333],
-	2202: _auditConstant_name[3333:3353],
-	2203: _auditConstant_name[3353:3375],
-	2204: _auditConstant_name[3375:3397],
-	2205: _auditConstant_name[3397:3423],
-	2206: _auditConstant_name[3423:3451],
-	2207: _auditConstant_name[3451:3471],
-	2208: _auditConstant_name[3471:3491],
-	2209: _auditConstant_name[3491:3508],
-	2210: _auditConstant_name[3508:3523],
-	2211: _auditConstant_name[3523:3540],
-	2212: _auditConstant_name[3540:3555],
-	2299: _auditConstant_name[3555:3575],
-	2300: _auditConstant_name[3575:3600],
-	2301: _auditConstant_name[3600:3617],
-	2302: _auditConstant_name[3617:3634],
-	2303: _auditConstant_name[3634:3654],
-	2304: _auditConstant_name[3654:3678],
-	2305: _auditConstant_name[3678:3703],
-	2306: _auditConstant_name[3703:3730],
-	2307: _auditConstant_name[3730:3745],
-	2308: _auditConstant_name[3745:3762],
-	2309: _auditConstant_name[3762:3778],
-	2310: _auditConstant_name[3778:3804],
-	2311: _auditConstant_name[3804:3821],
-	2312: _auditConstant_name[3821:3849],
-	2399: _auditConstant_name[3849:3873],
-	2400: _auditConstant_name[3873:3895],
-	2401: _auditConstant_name[3895:3925],
-	2402: _auditConstant_name[3925:3943],
-	2403: _auditConstant_name[3943:3962],
-	2404: _auditConstant_name[3962:3983],
-	2405: _auditConstant_name[3983:4008],
-	2406: _auditConstant_name[4008:4032],
-	2407: _auditConstant_name[4032:4052],
-	2408: _auditConstant_name[4052:4071],
-	2409: _auditConstant_name[4071:4092],
-	2499: _auditConstant_name[4092:4113],
-	2500: _auditConstant_name[4113:4133],
-	2501: _auditConstant_name[4133:4152],
-	2502: _auditConstant_name[4152:4173],
-	2599: _auditConstant_name[4173:4192],
-	2999: _auditConstant_name[4192:4212],
+	1703: _auditConstant_name[2715:2731],
+	1799: _auditConstant_name[2731:2755],
+	1800: _auditConstant_name[2755:2775],
+	1801: _auditConstant_name[2775:2799],
+	1802: _auditConstant_name[2799:2821],
+	1803: _auditConstant_name[2821:2841],
+	1804: _auditConstant_name[2841:2860],
+	1805: _auditConstant_name[2860:2880],
+	1806: _auditConstant_name[2880:2905],
+	1807: _auditConstant_name[2905:2932],
+	1899: _auditConstant_name[2932:2957],
+	2000: _auditConstant_name[2957:2969],
+	2100: _auditConstant_name[2969:2994],
+	2101: _auditConstant_name[2994:3015],
+	2102: _auditConstant_name[3015:3040],
+	2103: _auditConstant_name[3040:3061],
+	2104: _auditConstant_name[3061:3086],
+	2105: _auditConstant_name[3086:3104],
+	2106: _auditConstant_name[3104:3122],
+	2107: _auditConstant_name[3122:3142],
+	2108: _auditConstant_name[3142:3162],
+	2109: _auditConstant_name[3162:3192],
+	2110: _auditConstant_name[3192:3214],
+	2111: _auditConstant_name[3214:3234],
+	2112: _auditConstant_name[3234:3249],
+	2113: _auditConstant_name[3249:3267],
+	2114: _auditConstant_name[3267:3286],
+	2115: _auditConstant_name[3286:3305],
+	2116: _auditConstant_name[3305:3324],
+	2117: _auditConstant_name[3324:3345],
+	2118: _auditConstant_name[3345:3369],
+	2119: _auditConstant_name[3369:3390],
+	2120: _auditConstant_name[3390:3416],
+	2121: _auditConstant_name[3416:3434],
+	2199: _auditConstant_name[3434:3453],
+	2200: _auditConstant_name[3453:3471],
+	2201: _auditConstant_name[3471:3487],
+	2202: _auditConstant_name[3487:3507],
+	2203: _auditConstant_name[3507:3529],
+	2204: _auditConstant_name[3529:3551],
+	2205: _auditConstant_name[3551:3577],
+	2206: _auditConstant_name[3577:3605],
+	2207: _auditConstant_name[3605:3625],
+	2208: _auditConstant_name[3625:3645],
+	2209: _auditConstant_name[3645:3662],
+	2210: _auditConstant_name[3662:3677],
+	2211: _auditConstant_name[3677:3694],
+	2212: _auditConstant_name[3694:3709],
+	2299: _auditConstant_name[3709:3729],
+	2300: _auditConstant_name[3729:3754],
+	2301: _auditConstant_name[3754:3771],
+	2302: _auditConstant_name[3771:3788],
+	2303: _auditConstant_name[3788:3808],
+	2304: _auditConstant_name[3808:3832],
+	2305: _auditConstant_name[3832:3857],
+	2306: _auditConstant_name[3857:3884],
+	2307: _auditConstant_name[3884:3899],
+	2308: _auditConstant_name[3899:3916],
+	2309: _auditConstant_name[3916:3932],
+	2310: _auditConstant_name[3932:3958],
+	2311: _auditConstant_name[3958:3975],
+	2312: _auditConstant_name[3975:4003],
+	2399: _auditConstant_name[4003:4027],
+	2400: _auditConstant_name[4027:4049],
+	2401: _auditConstant_name[4049:4079],
+	2402: _auditConstant_name[4079:4097],
+	2403: _auditConstant_name[4097:4116],
+	2404: _auditConstant_name[4116:4137],
+	2405: _auditConstant_name[4137:4162],
+	2406: _auditConstant_name[4162:4186],
+	2407: _auditConstant_name[4186:4206],
+	2408: _auditConstant_name[4206:4225],
+	2409: _auditConstant_name[4225:4246],
+	2499: _auditConstant_name[4246:4267],
+	2500: _auditConstant_name[4267:4287],
+	2501: _auditConstant_name[4287:4306],
+	2502: _auditConstant_name[4306:4327],
+	2599: _auditConstant_name[4327:4346],
+	2999: _auditConstant_name[4346:4366],
 }
 
-func (i auditConstant) String() string {
+func (i auditConstant) builtinString() string {
 	if str, ok := _auditConstant_map[i]; ok {
 		return str
 	}
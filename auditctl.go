@@ -0,0 +1,376 @@
+package libaudit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lacework/libaudit-go/headers"
+	"github.com/pkg/errors"
+)
+
+// AuditctlRules is a rule file in the syntax of auditctl (/etc/audit/audit.rules, the output of augenrules
+// or auditctl -l), see ParseAuditctlRules.
+type AuditctlRules struct {
+	DeleteAll bool        // -D, the loaded rules are deleted before the rules are added
+	Config    AuditConfig // -e, -f, -r, -b and --backlog_wait_time
+	// ContinueOnError is set by -c and -i, the remaining rules are added if adding a rule fails
+	ContinueOnError bool
+	// LoginuidImmutable is set by --loginuid-immutable, the loginuids can't be changed once they are set
+	// and the feature is locked until reboot (see AuditSetFeature)
+	LoginuidImmutable bool
+	Rules             []AuditRule
+}
+
+// ParseAuditctlRules parses a rule file in auditctl syntax without talking to the kernel, one auditctl
+// command per line:
+//
+//	-D
+//	-b 8192
+//	-a always,exit -F arch=b64 -S execve -k exec
+//	-w /etc/passwd -p wa -k identity
+//	-a never,exclude -F msgtype=CWD
+//
+// Empty lines and comments (#) are skipped. Rules are added with -a (or -A to prepend them) and watches
+// with -w, the syscalls (-S), fields (-F), field comparisons (-C, see the field_compare field of AuditRule)
+// and keys (-k) of the rule follow on the same line. The commands setting the audit status (-e, -f, -r, -b,
+// --backlog_wait_time) are collected in Config, --loginuid-immutable sets LoginuidImmutable.
+// Invalid lines are reported as RuleErrors holding a *RuleError with the line for every invalid rule,
+// the rules are validated like with ValidateRules.
+func ParseAuditctlRules(data []byte) (*AuditctlRules, error) {
+	var (
+		rf   AuditctlRules
+		errs RuleErrors
+		line int
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 4096), PATH_MAX*4)
+	for scanner.Scan() {
+		line++
+		args, err := splitAuditctlLine(scanner.Text())
+		if err == nil && len(args) == 0 {
+			continue
+		}
+		var rule *AuditRule
+		if err == nil {
+			rule, err = rf.parseCommand(args)
+		}
+		if err == nil && rule != nil {
+			if rerr := validateRule(rule); rerr != nil {
+				err = rerr
+			}
+		}
+		if err != nil {
+			rerr, ok := err.(*RuleError)
+			if !ok {
+				rerr = &RuleError{Err: err}
+			}
+			rerr.Index, rerr.Line = len(rf.Rules), line
+			errs = append(errs, rerr)
+			continue
+		}
+		if rule != nil {
+			rf.Rules = append(rf.Rules, *rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("ParseAuditctlRules failed: line %d", line+1))
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return &rf, nil
+}
+
+// splitAuditctlLine splits a line of a rule file into its arguments, comments are dropped and
+// double quotes group arguments with spaces
+func splitAuditctlLine(line string) ([]string, error) {
+	var (
+		args   []string
+		arg    []byte
+		inArg  bool
+		quoted bool
+	)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+			inArg = true
+		case quoted:
+			arg = append(arg, c)
+		case c == '#' && !inArg:
+			i = len(line)
+		case c == ' ' || c == '\t' || c == '\r':
+			if inArg {
+				args = append(args, string(arg))
+				arg, inArg = arg[:0], false
+			}
+		default:
+			arg = append(arg, c)
+			inArg = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inArg {
+		args = append(args, string(arg))
+	}
+	return args, nil
+}
+
+// parseCommand parses the arguments of a line, settings are stored in rf and the rule of the line
+// (if any) is returned
+func (rf *AuditctlRules) parseCommand(args []string) (*AuditRule, error) {
+	var (
+		rule     *AuditRule
+		watch    string
+		perms    string
+		keys     []string
+		syscalls []string
+		fields   []AuditRuleField
+		// -S all, other syscalls are redundant then
+		allSyscalls bool
+	)
+	for i := 0; i < len(args); i++ {
+		opt := args[i]
+		var value string
+		switch opt {
+		case "-D", "-c", "-i", "--loginuid-immutable":
+		default:
+			if !strings.HasPrefix(opt, "-") {
+				return nil, fmt.Errorf("unexpected argument %q", opt)
+			}
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("option %v requires an argument", opt)
+			}
+			i++
+			value = args[i]
+		}
+		switch opt {
+		case "-D":
+			rf.DeleteAll = true
+		case "-c", "-i":
+			rf.ContinueOnError = true
+		case "--loginuid-immutable":
+			rf.LoginuidImmutable = true
+		case "-e", "-f", "-r", "-b", "--backlog_wait_time":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("option %v: invalid value %q", opt, value)
+			}
+			switch opt {
+			case "-e":
+				rf.Config.Enabled = &n
+			case "-f":
+				rf.Config.Failure = &n
+			case "-r":
+				rf.Config.RateLimit = &n
+			case "-b":
+				rf.Config.BacklogLimit = &n
+			case "--backlog_wait_time":
+				rf.Config.BacklogWaitTime = &n
+			}
+		case "-a", "-A":
+			if rule != nil || watch != "" {
+				return nil, fmt.Errorf("only one rule per line")
+			}
+			r, err := parseAuditctlList(value)
+			if err != nil {
+				return nil, &RuleError{Field: opt, Err: err}
+			}
+			if opt == "-A" {
+				r.Flags |= AUDIT_FILTER_PREPEND
+			}
+			rule = r
+		case "-w":
+			if rule != nil || watch != "" {
+				return nil, fmt.Errorf("only one rule per line")
+			}
+			if err := checkPath(value); err != nil {
+				return nil, &RuleError{Field: "-w", Err: err}
+			}
+			watch = value
+		case "-p":
+			perms = value
+		case "-S":
+			for _, name := range strings.Split(value, ",") {
+				if name == "all" {
+					allSyscalls = true
+					continue
+				}
+				syscalls = append(syscalls, name)
+			}
+		case "-F":
+			f, err := parseAuditctlField(value)
+			if err != nil {
+				return nil, &RuleError{Field: "-F", Err: err}
+			}
+			fields = append(fields, f)
+		case "-C":
+			f, err := parseAuditctlCompare(value)
+			if err != nil {
+				return nil, &RuleError{Field: "-C", Err: err}
+			}
+			fields = append(fields, f)
+		case "-k":
+			keys = append(keys, value)
+		default:
+			return nil, fmt.Errorf("unsupported option %v", opt)
+		}
+	}
+
+	switch {
+	case watch != "":
+		if len(syscalls) > 0 || allSyscalls || len(fields) > 0 {
+			return nil, fmt.Errorf("watches don't take syscalls or fields, use -a always,exit -F path=")
+		}
+		w, err := NewWatchRule(watch, perms, "")
+		if err != nil {
+			return nil, &RuleError{Field: "-p", Err: errors.Cause(err)}
+		}
+		rule = &w
+	case rule != nil:
+		if perms != "" {
+			return nil, fmt.Errorf("-p is only valid for watches, use -F perm=")
+		}
+		if !allSyscalls {
+			rule.Syscalls = syscalls
+		}
+		rule.Fields = fields
+	default:
+		if len(syscalls) > 0 || allSyscalls || len(fields) > 0 || len(keys) > 0 || perms != "" {
+			return nil, fmt.Errorf("-S, -F, -C, -k and -p require a rule (-a or -w)")
+		}
+		return nil, nil
+	}
+	for _, key := range keys {
+		rule.Fields = append(rule.Fields, AuditRuleField{Name: "key", Op: "=", Value: key})
+	}
+	return rule, nil
+}
+
+// parseAuditctlList parses the list and action of -a, auditctl accepts them in either order
+func parseAuditctlList(value string) (*AuditRule, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected <action>,<list>, found %q", value)
+	}
+	if _, err := ParseAction(parts[0]); err != nil {
+		parts[0], parts[1] = parts[1], parts[0]
+	}
+	action, err := ParseAction(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	filter, err := ParseFilter(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	return &AuditRule{Flags: filter, Action: action}, nil
+}
+
+// auditctlOperators are the operators of -F in the order they are matched
+var auditctlOperators = []string{"!=", ">=", "<=", "&=", "=", ">", "<", "&"}
+
+// parseAuditctlField parses the <name><op><value> argument of -F
+func parseAuditctlField(value string) (AuditRuleField, error) {
+	i := strings.IndexAny(value, "=!<>&")
+	if i <= 0 {
+		return AuditRuleField{}, fmt.Errorf("expected <field><op><value>, found %q", value)
+	}
+	for _, op := range auditctlOperators {
+		if strings.HasPrefix(value[i:], op) {
+			f := AuditRuleField{Name: value[:i], Op: op, Value: value[i+len(op):]}
+			// -1 is the unset id like in auditctl
+			if id, ok := headers.FieldMap[f.Name]; ok && f.Value == "-1" && isIDField(uint32(id)) {
+				f.Value = "unset"
+			}
+			return f, nil
+		}
+	}
+	return AuditRuleField{}, fmt.Errorf("invalid operator in %q", value)
+}
+
+// parseAuditctlCompare parses the <field><op><field> argument of -C as field_compare field
+func parseAuditctlCompare(value string) (AuditRuleField, error) {
+	for _, op := range []string{"!=", "="} {
+		if i := strings.Index(value, op); i > 0 {
+			f := AuditRuleField{Name: "field_compare", Op: op, Value: value[:i] + ":" + value[i+len(op):]}
+			if _, err := parseFieldCompare(f.Value); err != nil {
+				return AuditRuleField{}, err
+			}
+			return f, nil
+		}
+	}
+	return AuditRuleField{}, fmt.Errorf("expected <field>=<field> or <field>!=<field>, found %q", value)
+}
+
+// isAuditctlRules reports whether the rule set content is in auditctl syntax rather than JSON,
+// rule files start with a command or a comment
+func isAuditctlRules(content []byte) bool {
+	content = bytes.TrimLeft(content, " \t\r\n")
+	return len(content) > 0 && (content[0] == '-' || content[0] == '#')
+}
+
+// setAuditctlRules is SetRulesWithOptions for rule files in auditctl syntax
+func setAuditctlRules(s Netlink, content []byte, opts SetRulesOptions) ([]*AuditRuleData, error) {
+	rf, err := ParseAuditctlRules(content)
+	if err != nil {
+		return nil, errors.Wrap(err, "SetRules failed")
+	}
+	if opts.ExpandArch {
+		if rf.Rules, err = ExpandArchRules(rf.Rules); err != nil {
+			return nil, errors.Wrap(err, "SetRules failed")
+		}
+	}
+	if rf.DeleteAll {
+		if err := DeleteAllRules(s); err != nil {
+			return nil, errors.Wrap(err, "SetRules failed")
+		}
+	}
+	// locking the configuration (-e 2) comes last like in the rule files
+	cfg := rf.Config
+	lock := cfg.Enabled != nil && *cfg.Enabled == AUDIT_LOCKED
+	if lock {
+		cfg.Enabled = nil
+	}
+	if err := AuditConfigure(s, cfg); err != nil {
+		return nil, errors.Wrap(err, "SetRules failed")
+	}
+	if rf.LoginuidImmutable {
+		if err := AuditSetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE, true, true); err != nil {
+			return nil, errors.Wrap(err, "SetRules failed")
+		}
+	}
+	var (
+		ruleArray []*AuditRuleData
+		errs      RuleErrors
+	)
+	for i := range rf.Rules {
+		rule, err := rf.Rules[i].toRuleData()
+		if err == nil {
+			err = auditAddRuleData(s, rule, int(rf.Rules[i].Flags), int(rf.Rules[i].Action))
+		}
+		if err != nil {
+			if !rf.ContinueOnError {
+				return nil, errors.Wrap(err, fmt.Sprintf("SetRules failed: rule %d", i))
+			}
+			errs = append(errs, &RuleError{Index: i, Err: err})
+			continue
+		}
+		ruleArray = append(ruleArray, rule)
+	}
+	if lock {
+		if err := AuditSetImmutable(s); err != nil {
+			return nil, errors.Wrap(err, "SetRules failed")
+		}
+	}
+	if errs != nil {
+		return ruleArray, errors.Wrap(errs, "SetRules failed")
+	}
+	return ruleArray, nil
+}
@@ -0,0 +1,256 @@
+package libaudit
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testAuditctlRules = `## First rule - delete all
+-D
+
+# Increase the buffers to survive stress events.
+-b 8192
+--backlog_wait_time 60000
+-f 1
+
+-a always,exit -F arch=b64 -S rename,renameat -F auid>=1000 -k rename
+-w /etc/passwd -p wa -k passwd
+-a exit,always -F arch=b64 -S all -F auid!=-1 -k all
+-A never,exclude -F msgtype=CWD
+-w /tmp/
+-e 2
+`
+
+func TestParseAuditctlRules(t *testing.T) {
+	rf, err := ParseAuditctlRules([]byte(testAuditctlRules))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed: %v", err)
+	}
+	if !rf.DeleteAll || rf.ContinueOnError {
+		t.Errorf("ParseAuditctlRules: unexpected DeleteAll %v, ContinueOnError %v", rf.DeleteAll, rf.ContinueOnError)
+	}
+	c := rf.Config
+	if c.BacklogLimit == nil || *c.BacklogLimit != 8192 || c.BacklogWaitTime == nil || *c.BacklogWaitTime != 60000 ||
+		c.Failure == nil || *c.Failure != 1 || c.Enabled == nil || *c.Enabled != AUDIT_LOCKED || c.RateLimit != nil {
+		t.Errorf("ParseAuditctlRules: unexpected config %+v", c)
+	}
+
+	expected := []AuditRule{
+		testRuleRename,
+		testRuleWatch,
+		{
+			Flags:  AUDIT_FILTER_EXIT,
+			Action: AUDIT_ALWAYS,
+			Fields: []AuditRuleField{
+				{Name: "arch", Op: "=", Value: "b64"},
+				{Name: "auid", Op: "!=", Value: "unset"},
+				{Name: "key", Op: "=", Value: "all"},
+			},
+		},
+		{
+			Flags:  AUDIT_FILTER_EXCLUDE | AUDIT_FILTER_PREPEND,
+			Action: AUDIT_NEVER,
+			Fields: []AuditRuleField{{Name: "msgtype", Op: "=", Value: "CWD"}},
+		},
+		{
+			Flags:  AUDIT_FILTER_EXIT,
+			Action: AUDIT_ALWAYS,
+			Fields: []AuditRuleField{{Name: "dir", Op: "=", Value: "/tmp"}, {Name: "perm", Op: "=", Value: "rwxa"}},
+		},
+	}
+	if len(rf.Rules) != len(expected) {
+		t.Fatalf("ParseAuditctlRules: expected %d rules, found %d: %+v", len(expected), len(rf.Rules), rf.Rules)
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(rf.Rules[i], expected[i]) {
+			t.Errorf("ParseAuditctlRules: rule %d: expected %+v, found %+v", i, expected[i], rf.Rules[i])
+		}
+	}
+
+	// quoted values and trailing comments
+	rf, err = ParseAuditctlRules([]byte(`-a always,exit -F "path=/tmp/my file" -F perm=w # watch it`))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed: %v", err)
+	}
+	if len(rf.Rules) != 1 || rf.Rules[0].Fields[0].Value != "/tmp/my file" || len(rf.Rules[0].Fields) != 2 {
+		t.Errorf("ParseAuditctlRules: unexpected rules %+v", rf.Rules)
+	}
+}
+
+func TestParseAuditctlRulesErrors(t *testing.T) {
+	_, err := ParseAuditctlRules([]byte(`-D
+-a always,exit -S open -k ok
+-a always,bogus -S open
+-a always,exit -S nosuchsyscall
+-w /etc/shadow -S open
+-k orphan
+-W /etc/passwd
+-b lots
+-a always,exit -F "path=/tmp
+`))
+	errs, ok := err.(RuleErrors)
+	if !ok {
+		t.Fatalf("ParseAuditctlRules: expected RuleErrors, found %v", err)
+	}
+	lines := []int{3, 4, 5, 6, 7, 8, 9}
+	if len(errs) != len(lines) {
+		t.Fatalf("ParseAuditctlRules: expected %d errors, found %v", len(lines), errs)
+	}
+	for i, err := range errs {
+		rerr, ok := err.(*RuleError)
+		if !ok || rerr.Line != lines[i] {
+			t.Errorf("ParseAuditctlRules: error %d: expected line %d, found %v", i, lines[i], err)
+		}
+	}
+	if errs[1].(*RuleError).Field != "nosuchsyscall" {
+		t.Errorf("ParseAuditctlRules: expected error of syscall nosuchsyscall, found %v", errs[1])
+	}
+}
+
+// TestMarshalRulesText checks that the listing of parsed rules is the rule file in the format of auditctl -l
+func TestMarshalRulesText(t *testing.T) {
+	const listed = `-a always,exit -F arch=b64 -S rename,renameat -F auid>=1000 -F auid!=-1 -F key=rename
+-a always,exit -F arch=b32 -S all -F exit=-13 -F a1&0x3 -F key=access
+-w /etc -p wa -k etc
+-w /etc/passwd -p wa -k passwd
+-a never,exclude -F msgtype=CWD
+-a always,user -F uid=0
+-a always,exit -F arch=b64 -S execve -C uid!=euid -C auid=obj_uid -F euid=0 -F key=escalation
+`
+	rf, err := ParseAuditctlRules([]byte(listed))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed: %v", err)
+	}
+	text, err := MarshalRulesText(rf.Rules)
+	if err != nil {
+		t.Fatalf("MarshalRulesText failed: %v", err)
+	}
+	if string(text) != listed {
+		t.Errorf("MarshalRulesText: expected\n%s\nfound\n%s", listed, text)
+	}
+}
+
+func TestAuditctlCompare(t *testing.T) {
+	rf, err := ParseAuditctlRules([]byte("-a always,exit -S all -C euid!=uid -C loginuid=fsuid -k cmp\n"))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed: %v", err)
+	}
+	expected := []AuditRuleField{
+		{Name: "field_compare", Op: "!=", Value: "euid:uid"},
+		{Name: "field_compare", Op: "=", Value: "loginuid:fsuid"},
+		{Name: "key", Op: "=", Value: "cmp"},
+	}
+	if len(rf.Rules) != 1 || !reflect.DeepEqual(rf.Rules[0].Fields, expected) {
+		t.Fatalf("ParseAuditctlRules: expected fields %v, found %+v", expected, rf.Rules)
+	}
+	data, err := rf.Rules[0].toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed: %v", err)
+	}
+	// either order of the fields is encoded the same
+	if data.Fields[0] != AUDIT_FIELD_COMPARE || data.Values[0] != uint32(AUDIT_COMPARE_UID_TO_EUID) ||
+		data.Fieldflags[0] != AUDIT_NOT_EQUAL || data.Values[1] != uint32(AUDIT_COMPARE_AUID_TO_FSUID) {
+		t.Errorf("toRuleData: unexpected field comparisons %v %v %v", data.Fields[:2], data.Fieldflags[:2], data.Values[:2])
+	}
+	parsed := NewAuditRule(data)
+	if parsed.Fields[0].Value != "uid:euid" || !parsed.Equal(rf.Rules[0]) {
+		t.Errorf("NewAuditRule: expected %v, found %v", rf.Rules[0], parsed)
+	}
+
+	for _, line := range []string{
+		"-a always,exit -S all -C uid>euid",
+		"-a always,exit -S all -C uid=pid",
+		"-a always,user -C uid!=euid",
+	} {
+		if _, err := ParseAuditctlRules([]byte(line)); err == nil {
+			t.Errorf("ParseAuditctlRules: expected error for %q", line)
+		}
+	}
+}
+
+func TestAuditctlKeys(t *testing.T) {
+	rf, err := ParseAuditctlRules([]byte("-a always,exit -F arch=b64 -S execve -k exec -F euid=0 -k root\n-w /etc/shadow -p r -k identity -k secrets\n"))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed: %v", err)
+	}
+	if len(rf.Rules) != 2 || !reflect.DeepEqual(rf.Rules[0].Keys(), []string{"exec", "root"}) ||
+		!reflect.DeepEqual(rf.Rules[1].Keys(), []string{"identity", "secrets"}) {
+		t.Fatalf("ParseAuditctlRules: unexpected rules %+v", rf.Rules)
+	}
+	data, err := rf.Rules[0].toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed: %v", err)
+	}
+	// a single key field holding both keys
+	if data.FieldCount != 3 || data.Fields[2] != AUDIT_FILTERKEY || string(data.Buf) != "exec\x01root" {
+		t.Errorf("toRuleData: unexpected fields %v buffer %q", data.Fields[:data.FieldCount], data.Buf)
+	}
+	parsed := NewAuditRule(data)
+	if !reflect.DeepEqual(parsed.Keys(), []string{"exec", "root"}) || !parsed.Equal(rf.Rules[0]) {
+		t.Errorf("NewAuditRule: expected %v, found %v", rf.Rules[0], parsed)
+	}
+	text, err := MarshalRulesText(rf.Rules)
+	if err != nil {
+		t.Fatalf("MarshalRulesText failed: %v", err)
+	}
+	expected := "-a always,exit -F arch=b64 -S execve -F euid=0 -F key=exec -F key=root\n-w /etc/shadow -p r -k identity -k secrets\n"
+	if string(text) != expected {
+		t.Errorf("MarshalRulesText: expected\n%s\nfound\n%s", expected, text)
+	}
+}
+
+// TestAuditctlStockRules checks the constructs of the rule files shipped with auditd, errno names of the
+// exit field and --loginuid-immutable
+func TestAuditctlStockRules(t *testing.T) {
+	const stock = `--loginuid-immutable
+-a always,exit -F arch=b64 -S open,openat -F exit=-EACCES -F auid>=1000 -k access
+-a always,exit -F arch=b64 -S open,openat -F exit=-EPERM -F auid>=1000 -k access
+`
+	rf, err := ParseAuditctlRules([]byte(stock))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed: %v", err)
+	}
+	if !rf.LoginuidImmutable || len(rf.Rules) != 2 {
+		t.Fatalf("ParseAuditctlRules: unexpected LoginuidImmutable %v, rules %+v", rf.LoginuidImmutable, rf.Rules)
+	}
+	text, err := MarshalRulesText(rf.Rules)
+	if err != nil {
+		t.Fatalf("MarshalRulesText failed: %v", err)
+	}
+	expected := `-a always,exit -F arch=b64 -S open,openat -F exit=-13 -F auid>=1000 -F key=access
+-a always,exit -F arch=b64 -S open,openat -F exit=-1 -F auid>=1000 -F key=access
+`
+	if string(text) != expected {
+		t.Errorf("MarshalRulesText: expected\n%s\nfound\n%s", expected, text)
+	}
+	if _, err := ParseAuditctlRules([]byte("-a always,exit -S open -F exit=-ENOSUCHERRNO\n")); err == nil {
+		t.Errorf("ParseAuditctlRules: expected error for an unknown errno")
+	}
+
+	known := auditFeatureToMask(AUDIT_FEATURE_ONLY_UNSET_LOGINUID) | auditFeatureToMask(AUDIT_FEATURE_LOGINUID_IMMUTABLE)
+	s := testFeaturesConn(auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: known}, true)
+	if _, err := SetRules(s, []byte(stock)); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	var types []auditConstant
+	for _, m := range s.sent {
+		types = append(types, auditConstant(m.Header.Type))
+	}
+	if !reflect.DeepEqual(types, []auditConstant{AUDIT_GET_FEATURE, AUDIT_SET_FEATURE, AUDIT_ADD_RULE, AUDIT_ADD_RULE}) {
+		t.Errorf("SetRules: unexpected requests %v", types)
+	}
+}
+
+func TestIsAuditctlRules(t *testing.T) {
+	for content, expected := range map[string]bool{
+		"-D\n":                        true,
+		"\n  # rules\n-a always,exit": true,
+		`{"audit_rules": []}`:         false,
+		"\n\t[]":                      false,
+		"":                            false,
+	} {
+		if found := isAuditctlRules([]byte(content)); found != expected {
+			t.Errorf("isAuditctlRules(%q): expected %v, found %v", content, expected, found)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package libaudit
+
+import (
+	"sync"
+	"time"
+)
+
+// GroupCallback is the function signature of the callback receiving the events coalesced by a Coalescer,
+// errors passed to the EventCallback of the Coalescer are passed without group.
+type GroupCallback func(*EventGroup, error, ...interface{})
+
+// Coalescer assembles multi-record events like auparse. The kernel sends the SYSCALL, CWD, PATH, PROCTITLE,
+// EXECVE ... records of one event as separate messages sharing the serial and ends the event with an
+// AUDIT_EOE record. The Coalescer buffers the records by serial and passes the EventGroup of the event
+// to its callback once the EOE record arrives, EOE records themselves are left out of the groups.
+// Events of user space and daemon messages consist of a single record and are passed at once, events whose
+// EOE record is lost are passed once they are older than the timeout.
+// A Coalescer is safe for concurrent use, its callback is called with the Coalescer locked and must not
+// call it.
+type Coalescer struct {
+	timeout time.Duration
+	cb      GroupCallback
+	mu      sync.Mutex
+	pending map[string]*pendingGroup
+	// order holds the pending groups in the order of their first record for the timeout
+	order []*pendingGroup
+}
+
+// pendingGroup is an event of a Coalescer waiting for its EOE record
+type pendingGroup struct {
+	key     string
+	group   *EventGroup
+	started time.Time
+	done    bool
+}
+
+// NewCoalescer returns a Coalescer passing events to cb, a timeout of 0 disables the timeout so that
+// events without EOE record are only passed by Flush.
+func NewCoalescer(timeout time.Duration, cb GroupCallback) *Coalescer {
+	return &Coalescer{
+		timeout: timeout,
+		cb:      cb,
+		pending: make(map[string]*pendingGroup),
+	}
+}
+
+// EventCallback returns the EventCallback feeding the receive loops (GetAuditEvents ...) into the Coalescer.
+// Errors are passed to the callback of the Coalescer, ErrIdle heartbeats (SetIdleTimeout) pass the events
+// which timed out on quiet streams as well.
+func (c *Coalescer) EventCallback() EventCallback {
+	return func(e *AuditEvent, err error, args ...interface{}) {
+		if e != nil {
+			c.Add(e, args...)
+		}
+		if err != nil {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.expire(time.Now(), args...)
+			c.cb(nil, err, args...)
+		}
+	}
+}
+
+// singleRecordType reports whether events of the record type consist of a single record, i.e.
+// user space and daemon messages which are not followed by an EOE record
+func singleRecordType(msgType string) bool {
+	t, ok := MsgTypeTab[msgType]
+	if !ok {
+		return false
+	}
+	return (t >= AUDIT_FIRST_USER_MSG && t <= AUDIT_LAST_DAEMON) ||
+		(t >= AUDIT_FIRST_ANOM_MSG && t <= AUDIT_LAST_USER_MSG2)
+}
+
+// Add adds the record e to its event, args are passed to the callback of complete events.
+func (c *Coalescer) Add(e *AuditEvent, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.expire(now, args...)
+
+	key := e.Timestamp + ":" + e.Serial
+	p, ok := c.pending[key]
+	switch {
+	case e.Type == "EOE":
+		if ok {
+			c.pass(p, args...)
+		}
+		return
+	case !ok && singleRecordType(e.Type):
+		c.cb(&EventGroup{Serial: e.Serial, Timestamp: e.Timestamp, Records: []*AuditEvent{e}}, nil, args...)
+		return
+	case !ok:
+		p = &pendingGroup{key: key, group: &EventGroup{}, started: now}
+		c.pending[key] = p
+		c.order = append(c.order, p)
+		if len(c.order) > 2*len(c.pending)+16 {
+			// passed events behind a pending one are removed from the order here, expire stops at it
+			order := c.order[:0]
+			for _, p := range c.order {
+				if !p.done {
+					order = append(order, p)
+				}
+			}
+			c.order = order
+		}
+	}
+	// the key matches, Add can't fail
+	p.group.Add(e)
+}
+
+// Flush passes the pending events regardless of their EOE record, i.e. before shutting down.
+func (c *Coalescer) Flush(args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.order {
+		if !p.done {
+			c.pass(p, args...)
+		}
+	}
+	c.order = nil
+}
+
+// expire passes the pending events older than the timeout
+func (c *Coalescer) expire(now time.Time, args ...interface{}) {
+	for len(c.order) > 0 {
+		p := c.order[0]
+		if !p.done {
+			if c.timeout <= 0 || now.Sub(p.started) < c.timeout {
+				return
+			}
+			c.pass(p, args...)
+		}
+		c.order = c.order[1:]
+	}
+}
+
+// pass removes the pending event p and passes it to the callback
+func (c *Coalescer) pass(p *pendingGroup, args ...interface{}) {
+	p.done = true
+	delete(c.pending, p.key)
+	c.cb(p.group, nil, args...)
+}
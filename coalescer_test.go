@@ -0,0 +1,78 @@
+package libaudit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoalescer(t *testing.T) {
+	msgs := []struct {
+		msgType auditConstant
+		msg     string
+	}{
+		{AUDIT_SYSCALL, `audit(1464163771.720:020): arch=c000003e syscall=59 success=yes exit=0 items=1 pid=2345`},
+		{AUDIT_USER_LOGIN, `audit(1464163771.721:021): pid=2346 uid=0 auid=1000 ses=2 msg='op=login acct="bob" res=success'`},
+		{AUDIT_EXECVE, `audit(1464163771.720:020): argc=1 a0="ls"`},
+		{AUDIT_SYSCALL, `audit(1464163771.722:022): arch=c000003e syscall=2 success=yes exit=3 pid=2347`},
+		{AUDIT_CWD, `audit(1464163771.720:020): cwd="/home/bob"`},
+		{AUDIT_PATH, `audit(1464163771.720:020): item=0 name="/bin/ls" inode=1234 dev=08:01 mode=0100755`},
+		{AUDIT_EOE, `audit(1464163771.720:020): `},
+		{AUDIT_EOE, `audit(1464163771.720:099): `},
+	}
+	var groups []*EventGroup
+	c := NewCoalescer(0, func(g *EventGroup, err error, args ...interface{}) {
+		if err != nil {
+			if err != ErrIdle {
+				t.Errorf("Coalescer: unexpected error %v", err)
+			}
+			return
+		}
+		groups = append(groups, g)
+	})
+	cb := c.EventCallback()
+	for _, m := range msgs {
+		x, err := ParseAuditEvent(m.msg, m.msgType, false)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		cb(x, nil)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Coalescer: expected 2 events, found %d", len(groups))
+	}
+	// the single record event is passed at once, the syscall event with its EOE record
+	if groups[0].Serial != "021" || len(groups[0].Records) != 1 {
+		t.Errorf("Coalescer: unexpected event %+v", groups[0])
+	}
+	g := groups[1]
+	if g.Serial != "020" || len(g.Records) != 4 || g.Record("EXECVE") == nil || g.Record("PATH") == nil || g.Record("EOE") != nil {
+		t.Errorf("Coalescer: unexpected event %+v", g)
+	}
+	c.Flush()
+	if len(groups) != 3 || groups[2].Serial != "022" {
+		t.Errorf("Flush: expected the pending event, found %v", groups)
+	}
+	c.Flush()
+	if len(groups) != 3 {
+		t.Errorf("Flush: expected no pending event, found %v", groups)
+	}
+
+	// events without EOE record are passed after the timeout
+	groups = nil
+	c = NewCoalescer(10*time.Millisecond, c.cb)
+	for _, serial := range []string{"023", "024"} {
+		x, err := ParseAuditEvent(`audit(1464163771.723:`+serial+`): arch=c000003e syscall=2 success=yes exit=3`, AUDIT_SYSCALL, false)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		c.Add(x)
+		time.Sleep(20 * time.Millisecond)
+	}
+	if len(groups) != 1 || groups[0].Serial != "023" {
+		t.Errorf("Coalescer: expected the first event to time out, found %v", groups)
+	}
+	c.EventCallback()(nil, ErrIdle)
+	if len(groups) != 2 || groups[1].Serial != "024" {
+		t.Errorf("Coalescer: expected the second event to time out on ErrIdle, found %v", groups)
+	}
+}
@@ -0,0 +1,61 @@
+package libaudit
+
+import (
+	"fmt"
+	"io"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// Decoder reads audit events from a netlink connection one at a time.
+// It owns the receive buffer and keeps the messages of a receive call that were not returned yet,
+// netlink messages are datagrams so no partial messages have to be reassembled.
+// A Decoder must not be used concurrently.
+type Decoder struct {
+	s       Netlink
+	rb      []byte
+	pending []NetlinkMessage
+}
+
+// NewDecoder returns a Decoder reading from the netlink connection s.
+func NewDecoder(s Netlink) *Decoder {
+	return &Decoder{
+		s:  s,
+		rb: make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH),
+	}
+}
+
+// Next returns the next audit event, receiving from the connection if no messages are buffered.
+// Errors reported by the kernel, parsing errors and receive errors (i.e. a receive timeout) are returned
+// and the decoder can be used further. io.EOF is returned once the connection is closed.
+func (d *Decoder) Next() (*AuditEvent, error) {
+	for {
+		for len(d.pending) > 0 {
+			msg := d.pending[0]
+			d.pending = d.pending[1:]
+			if isSuppressed(msg.Header.Type) {
+				continue
+			}
+			if msg.Header.Type == syscall.NLMSG_ERROR {
+				v := int32(nativeEndian().Uint32(msg.Data[0:4]))
+				if v != 0 {
+					return nil, fmt.Errorf("error receiving events %d", v)
+				}
+				continue
+			}
+			nae, err := newAuditEvent(msg, keepRaw.get())
+			observeEvent(nae, err)
+			return nae, err
+		}
+		msgs, err := d.s.Receive(syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH, 0, d.rb)
+		if err != nil {
+			if cause := errors.Cause(err); cause == ErrClosed || cause == syscall.EBADF {
+				return nil, io.EOF
+			}
+			return nil, errors.Wrap(err, "Decoder: Next failed")
+		}
+		observeMessages(msgs)
+		d.pending = msgs
+	}
+}
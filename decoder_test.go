@@ -0,0 +1,53 @@
+package libaudit
+
+import (
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// testClosedNetlinkConn behaves like a closed netlink connection once its pending messages are read
+type testClosedNetlinkConn struct {
+	testReplyNetlinkConn
+}
+
+func (t *testClosedNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	if len(t.pending) == 0 {
+		return nil, errors.Wrap(syscall.EBADF, "recvfrom failed")
+	}
+	return t.testReplyNetlinkConn.Receive(bytesize, block, rb)
+}
+
+func TestDecoder(t *testing.T) {
+	s := &testClosedNetlinkConn{}
+	s.pending = []NetlinkMessage{
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`),
+		testAckMessage(NetlinkMessage{}, 0),
+		testAckMessage(NetlinkMessage{}, int32(syscall.EPERM)),
+		testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:21): auid=1000 ses=2 op=remove_rule key="passwd" list=4 res=1`),
+	}
+	dec := NewDecoder(s)
+	ev, err := dec.Next()
+	if err != nil || ev.Serial != "20" {
+		t.Fatalf("Next: expected event 20, found %v %v", ev, err)
+	}
+	// acks are skipped, kernel errors are returned
+	if _, err := dec.Next(); err == nil {
+		t.Errorf("Next: expected kernel error")
+	}
+	ev, err = dec.Next()
+	if err != nil || ev.Serial != "21" {
+		t.Fatalf("Next: expected event 21, found %v %v", ev, err)
+	}
+	if _, err := dec.Next(); err != io.EOF {
+		t.Errorf("Next: expected io.EOF, found %v", err)
+	}
+
+	// a receive timeout is not terminal
+	dec = NewDecoder(&testReplyNetlinkConn{})
+	if _, err := dec.Next(); errors.Cause(err) != syscall.EAGAIN {
+		t.Errorf("Next: expected EAGAIN, found %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package libaudit
+
+import (
+	"sync"
+	"syscall"
+)
+
+// Demux shares one netlink connection between a receive loop and the commands (SetRules, AuditIsEnabled, ...)
+// issued concurrently from other go-routines. Run the receive loop on Events() and the commands on Control():
+// whichever of them receives from the socket routes the replies to the requests sent on Control (matched by
+// sequence number) to Control and everything else (audit events, replies to requests sent on Events) to Events,
+// so neither consumes the messages of the other.
+// Each view is meant to be used by one go-routine at a time, i.e. commands on Control have to be serialized.
+// SetsockRecvTO applies to the underlying connection and therefore to both views.
+type Demux struct {
+	s       Netlink
+	rb      []byte
+	mu      sync.Mutex
+	cond    *sync.Cond
+	reading bool
+	events  []NetlinkMessage
+	replies []NetlinkMessage
+	// the sequence numbers of the latest requests sent on Control
+	controlSeqs [64]uint32
+	nextSeq     int
+}
+
+// NewDemux returns a Demux sharing s, s must not be used directly anymore.
+func NewDemux(s Netlink) *Demux {
+	d := &Demux{
+		s:  s,
+		rb: make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH),
+	}
+	d.cond = sync.NewCond(&d.mu)
+	return d
+}
+
+// Events returns the view of the connection receiving the audit events, to be passed to the receive loops.
+func (d *Demux) Events() Netlink {
+	return demuxView{d: d}
+}
+
+// Control returns the view of the connection receiving the replies to requests, to be passed to the commands.
+func (d *Demux) Control() Netlink {
+	return demuxView{d: d, replies: true}
+}
+
+// receive returns the queued messages of one kind (replies or events), if there are none it receives from
+// the socket unless another view is receiving already, then it waits for it to queue messages
+func (d *Demux) receive(replies bool, block int) ([]NetlinkMessage, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for {
+		queue := &d.events
+		if replies {
+			queue = &d.replies
+		}
+		if len(*queue) > 0 {
+			msgs := *queue
+			*queue = nil
+			return msgs, nil
+		}
+		if d.reading {
+			if block&syscall.MSG_DONTWAIT != 0 {
+				return nil, ErrWouldBlock
+			}
+			d.cond.Wait()
+			continue
+		}
+
+		d.reading = true
+		d.mu.Unlock()
+		msgs, err := d.s.Receive(len(d.rb), block, d.rb)
+		d.mu.Lock()
+		d.reading = false
+		for _, m := range msgs {
+			// the messages point into rb which is reused by the next receive
+			data := make([]byte, len(m.Data))
+			copy(data, m.Data)
+			m.Data = data
+			if d.isControlSeq(m.Header.Seq) {
+				d.replies = append(d.replies, m)
+			} else {
+				d.events = append(d.events, m)
+			}
+		}
+		d.cond.Broadcast()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isControlSeq reports whether seq is the sequence number of a request sent on Control, d.mu is held
+func (d *Demux) isControlSeq(seq uint32) bool {
+	if seq == 0 {
+		return false
+	}
+	for _, s := range d.controlSeqs {
+		if s == seq {
+			return true
+		}
+	}
+	return false
+}
+
+// demuxView is one side of a Demux, it implements Netlink
+type demuxView struct {
+	d       *Demux
+	replies bool
+}
+
+func (v demuxView) Send(request *NetlinkMessage) error {
+	if v.replies {
+		// before sending, the reply can come before Send returns
+		v.d.mu.Lock()
+		v.d.controlSeqs[v.d.nextSeq] = request.Header.Seq
+		v.d.nextSeq = (v.d.nextSeq + 1) % len(v.d.controlSeqs)
+		v.d.mu.Unlock()
+	}
+	return v.d.s.Send(request)
+}
+
+func (v demuxView) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	return v.d.receive(v.replies, block)
+}
+
+func (v demuxView) ReceiveNoParse(bytesize int, block int, rb []byte) ([]byte, error) {
+	msgs, err := v.d.receive(v.replies, block)
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	for _, m := range msgs {
+		// like the kernel, pad every message to the netlink alignment
+		wire := make([]byte, nlmAlignOf(int(m.Header.Len)))
+		copy(wire, m.ToWireFormat(nil))
+		b = append(b, wire...)
+	}
+	// like the socket receive buffer leave room behind the messages, the
+	// workaround for the data length in the parsers may slice past the end
+	return append(b, make([]byte, syscall.NLMSG_HDRLEN)...)[:len(b)], nil
+}
+
+func (v demuxView) GetPID() (int, error) {
+	return v.d.s.GetPID()
+}
+
+func (v demuxView) SetsockRecvTO(recvto int64) error {
+	return v.d.s.SetsockRecvTO(recvto)
+}
@@ -0,0 +1,144 @@
+package libaudit
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// testChanNetlinkConn is similar to testReplyNetlinkConn but safe for concurrent use,
+// receive calls wait for messages like on a socket with a receive timeout
+type testChanNetlinkConn struct {
+	reply    func(request NetlinkMessage) []NetlinkMessage
+	messages chan NetlinkMessage
+}
+
+func (t *testChanNetlinkConn) Send(request *NetlinkMessage) error {
+	for _, m := range t.reply(*request) {
+		t.messages <- m
+	}
+	return nil
+}
+
+func (t *testChanNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	b, err := t.ReceiveNoParse(bytesize, block, rb)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuditNetlinkMessage(b)
+}
+
+func (t *testChanNetlinkConn) ReceiveNoParse(bytesize int, block int, rb []byte) ([]byte, error) {
+	select {
+	case m := <-t.messages:
+		b := m.ToWireFormat(nil)
+		rb = make([]byte, nlmAlignOf(len(b)), nlmAlignOf(len(b))+syscall.NLMSG_HDRLEN)
+		copy(rb, b)
+		return rb, nil
+	case <-time.After(10 * time.Millisecond):
+		return nil, syscall.EAGAIN
+	}
+}
+
+func (t *testChanNetlinkConn) GetPID() (int, error) {
+	return 0, nil
+}
+
+func (t *testChanNetlinkConn) SetsockRecvTO(recvto int64) error {
+	return nil
+}
+
+func TestDemux(t *testing.T) {
+	var serial int
+	s := &testChanNetlinkConn{
+		messages: make(chan NetlinkMessage, 64),
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			// an event comes before the reply to every request
+			serial++
+			// fixed length, unaligned so that the parser doesn't apply the workaround for kernel messages
+			event := testEventMessage(AUDIT_SYSCALL, fmt.Sprintf(`audit(1464163771.720:%03d): arch=c000003e syscall=2 success=yes exit=3`, serial))
+			if request.Header.Type == uint16(AUDIT_GET) {
+				return []NetlinkMessage{event, testAckMessage(request, 0),
+					testReplyMessage(request, uint16(AUDIT_GET), testStatusData(auditStatus{Enabled: 1, Pid: 42}))}
+			}
+			return []NetlinkMessage{event, testAckMessage(request, 0)}
+		},
+	}
+	d := NewDemux(s)
+
+	var mu sync.Mutex
+	var events int
+	r := GetAuditEvents(d.Events(), func(e *AuditEvent, err error, args ...interface{}) {
+		if err != nil {
+			t.Errorf("GetAuditEvents: unexpected error %v", err)
+			return
+		}
+		mu.Lock()
+		events++
+		mu.Unlock()
+	})
+	defer r.Wait()
+	defer r.Stop()
+
+	for i := 0; i < 10; i++ {
+		if err := AuditSetRateLimit(d.Control(), 100); err != nil {
+			t.Fatalf("AuditSetRateLimit failed %v", err)
+		}
+		state, pid, err := AuditIsEnabled(d.Control())
+		if err != nil || state != 1 || pid != 42 {
+			t.Fatalf("AuditIsEnabled: unexpected result %v %v %v", state, pid, err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := events
+		mu.Unlock()
+		if n == 20 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Demux: received %d events, expected 20", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReceiveBusy(t *testing.T) {
+	s, err := NewNetlinkConnection()
+	if err != nil {
+		t.Skipf("NewNetlinkConnection failed %v", err)
+	}
+	defer s.Close()
+	// a nonblocking receive returns at once unless the background receive holds the connection
+	s.SetsockRecvTO(500)
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		close(started)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, make([]byte, MAX_AUDIT_MESSAGE_LENGTH))
+			}
+		}
+	}()
+	<-started
+	defer close(done)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := s.Receive(MAX_AUDIT_MESSAGE_LENGTH, syscall.MSG_DONTWAIT, make([]byte, MAX_AUDIT_MESSAGE_LENGTH))
+		if err == ErrReceiveBusy {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Receive: expected ErrReceiveBusy, found %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
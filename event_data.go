@@ -0,0 +1,118 @@
+package libaudit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EventData holds the fields of an audit event by name, see AuditEvent. The Get methods parse the values of
+// numeric fields so that consumers don't have to, they fail for missing fields and values of other types
+// (e.g. interpreted ones).
+type EventData map[string]string
+
+// value returns the value of field, method names the Get method for errors
+func (d EventData) value(method, field string) (string, error) {
+	v, ok := d[field]
+	if !ok {
+		return "", fmt.Errorf("%v failed: no field %v", method, field)
+	}
+	return v, nil
+}
+
+// GetInt returns the value of the decimal field (pid, exit, items ...).
+func (d EventData) GetInt(field string) (int64, error) {
+	v, err := d.value("GetInt", field)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "GetInt failed: field %v", field)
+	}
+	return i, nil
+}
+
+// GetUint32 returns the value of the decimal 32 bit field, i.e. the ids (uid, auid, ses ...) for which
+// unset is 4294967295.
+func (d EventData) GetUint32(field string) (uint32, error) {
+	v, err := d.value("GetUint32", field)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "GetUint32 failed: field %v", field)
+	}
+	return uint32(i), nil
+}
+
+// GetTime returns the value of the time field, unix seconds with optional fraction like the event
+// timestamps (1464163771.720).
+func (d EventData) GetTime(field string) (time.Time, error) {
+	v, err := d.value("GetTime", field)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := parseUnixTime(v)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "GetTime failed: field %v", field)
+	}
+	return t, nil
+}
+
+// parseUnixTime parses "<sec>[.<fraction>]" as found in the timestamps of audit messages
+func parseUnixTime(value string) (time.Time, error) {
+	sec, frac := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		sec, frac = value[:i], value[i+1:]
+	}
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int64
+	if frac != "" {
+		if len(frac) > 9 {
+			return time.Time{}, fmt.Errorf("invalid fraction %q", frac)
+		}
+		if nsec, err = strconv.ParseInt(frac, 10, 64); err != nil || nsec < 0 {
+			return time.Time{}, fmt.Errorf("invalid fraction %q", frac)
+		}
+		for k := len(frac); k < 9; k++ {
+			nsec *= 10
+		}
+	}
+	return time.Unix(s, nsec), nil
+}
+
+// TypedAuditEvent is an AuditEvent with its header parsed, see (*AuditEvent).Typed.
+type TypedAuditEvent struct {
+	Serial    uint64
+	Timestamp time.Time
+	Type      string
+	Data      EventData
+	Raw       string
+}
+
+// Typed returns the event with the serial and timestamp parsed, it shares Data with e.
+func (e *AuditEvent) Typed() (*TypedAuditEvent, error) {
+	serial, err := strconv.ParseUint(e.Serial, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "Typed failed: invalid serial")
+	}
+	timestamp, err := parseUnixTime(e.Timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "Typed failed: invalid timestamp")
+	}
+	return &TypedAuditEvent{
+		Serial:    serial,
+		Timestamp: timestamp,
+		Type:      e.Type,
+		Data:      e.Data,
+		Raw:       e.Raw,
+	}, nil
+}
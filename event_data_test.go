@@ -0,0 +1,47 @@
+package libaudit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventData(t *testing.T) {
+	x, err := ParseAuditEvent(`audit(1464163771.720:020): arch=c000003e syscall=2 success=no exit=-2 pid=2735 auid=4294967295 comm="ls" sec=1464163000.5`, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("ParseAuditEvent failed %v", err)
+	}
+	if v, err := x.Data.GetInt("exit"); err != nil || v != -2 {
+		t.Errorf("GetInt: expected -2, found %v %v", v, err)
+	}
+	if v, err := x.Data.GetInt("pid"); err != nil || v != 2735 {
+		t.Errorf("GetInt: expected 2735, found %v %v", v, err)
+	}
+	if v, err := x.Data.GetUint32("auid"); err != nil || v != 4294967295 {
+		t.Errorf("GetUint32: expected 4294967295, found %v %v", v, err)
+	}
+	if v, err := x.Data.GetTime("sec"); err != nil || !v.Equal(time.Unix(1464163000, 500000000)) {
+		t.Errorf("GetTime: unexpected %v %v", v, err)
+	}
+	for _, field := range []string{"comm", "exit", "missing"} {
+		if _, err := x.Data.GetUint32(field); err == nil {
+			t.Errorf("GetUint32(%v): expected error", field)
+		}
+	}
+	if _, err := x.Data.GetInt("arch"); err == nil {
+		t.Errorf("GetInt(arch): expected error")
+	}
+
+	typed, err := x.Typed()
+	if err != nil {
+		t.Fatalf("Typed failed %v", err)
+	}
+	if typed.Serial != 20 || !typed.Timestamp.Equal(time.Unix(1464163771, 720000000)) || typed.Type != "SYSCALL" || typed.Data["comm"] != `"ls"` {
+		t.Errorf("Typed: unexpected event %+v", typed)
+	}
+	if _, err := (&AuditEvent{Serial: "x", Timestamp: "1464163771.720"}).Typed(); err == nil {
+		t.Errorf("Typed: expected error for invalid serial")
+	}
+	if _, err := (&AuditEvent{Serial: "20", Timestamp: "1464163771.72x"}).Typed(); err == nil {
+		t.Errorf("Typed: expected error for invalid timestamp")
+	}
+}
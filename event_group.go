@@ -0,0 +1,195 @@
+package libaudit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// EventGroup holds the records of one audit event, i.e. a SYSCALL record with its CWD and PATH records,
+// which the kernel emits as separate messages sharing the timestamp and serial.
+type EventGroup struct {
+	Serial    string
+	Timestamp string
+	Records   []*AuditEvent
+}
+
+// NewEventGroup returns an EventGroup of the records, which have to belong to one event.
+func NewEventGroup(records ...*AuditEvent) (*EventGroup, error) {
+	g := &EventGroup{}
+	for _, e := range records {
+		if err := g.Add(e); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Add appends the record e to the group, the first record sets the serial and timestamp of the group
+// and the following ones have to match them.
+func (g *EventGroup) Add(e *AuditEvent) error {
+	if len(g.Records) == 0 {
+		g.Serial, g.Timestamp = e.Serial, e.Timestamp
+	} else if e.Serial != g.Serial || e.Timestamp != g.Timestamp {
+		return fmt.Errorf("EventGroup: record %v:%v doesn't belong to event %v:%v", e.Timestamp, e.Serial, g.Timestamp, g.Serial)
+	}
+	g.Records = append(g.Records, e)
+	return nil
+}
+
+// ID returns the EventID of the records of the group.
+func (g *EventGroup) ID() EventID {
+	id, _ := ParseEventID(g.Timestamp + ":" + g.Serial)
+	return id
+}
+
+// Record returns the first record of type msgType (SYSCALL, CWD, ...) of the group or nil if there is none.
+func (g *EventGroup) Record(msgType string) *AuditEvent {
+	for _, e := range g.Records {
+		if e.Type == msgType {
+			return e
+		}
+	}
+	return nil
+}
+
+// Argv returns the arguments of the EXECVE records of the group in order, see (*AuditEvent).Argv.
+// The kernel continues long command lines in further EXECVE records sharing the serial.
+func (g *EventGroup) Argv() ([]string, error) {
+	var records []map[string]string
+	for _, e := range g.Records {
+		if e.Type == "EXECVE" {
+			records = append(records, e.Data)
+		}
+	}
+	argv, err := execveArgv(records)
+	if err != nil {
+		return nil, errors.Wrap(err, "Argv failed")
+	}
+	return argv, nil
+}
+
+// PathItem holds the fields of a PATH record, the file the syscall of the event worked on.
+type PathItem struct {
+	// Index is the item field, the position of the file among the ones of the syscall
+	// (items of the SYSCALL record), it is -1 if the record lacks a valid index.
+	Index    int
+	Name     string
+	NameType string // nametype, NORMAL, PARENT, CREATE, DELETE ...
+	Inode    string
+	Device   string
+	// Mode is the mode of the file including the file type bits (syscall.S_IFREG, ...), ModeOK is
+	// false if the record has no mode field or it can't be parsed, i.e. for files not found.
+	Mode   uint32
+	ModeOK bool
+	OUID   string
+	OGID   string
+	Record *AuditEvent
+}
+
+// Paths returns the PATH records of the group ordered by their item index, i.e. source and destination
+// of a rename. Names are decoded whether the records were interpreted or not (names the kernel encodes
+// in hex are detected by their upper case digits), records without a valid index come last in the order
+// they were added.
+func (g *EventGroup) Paths() []PathItem {
+	var paths []PathItem
+	for _, e := range g.Records {
+		if e.Type != "PATH" {
+			continue
+		}
+		p := PathItem{
+			Index:    -1,
+			Name:     decodeEncodedValue(e.Data["name"]),
+			NameType: unquote(e.Data["nametype"]),
+			Inode:    e.Data["inode"],
+			Device:   e.Data["dev"],
+			OUID:     e.Data["ouid"],
+			OGID:     e.Data["ogid"],
+			Record:   e,
+		}
+		if item, err := strconv.Atoi(e.Data["item"]); err == nil && item >= 0 {
+			p.Index = item
+		}
+		p.Mode, p.ModeOK = parseMode(e.Data["mode"])
+		paths = append(paths, p)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		if paths[i].Index < 0 || paths[j].Index < 0 {
+			return paths[j].Index < 0 && paths[i].Index >= 0
+		}
+		return paths[i].Index < paths[j].Index
+	})
+	return paths
+}
+
+// decodeEncodedValue returns a value which may contain any characters (names of PATH records, accounts),
+// quoted or hex encoded as reported by the kernel, or as it is if it was interpreted already
+func decodeEncodedValue(value string) string {
+	switch {
+	case value == "(null)":
+		return ""
+	case strings.HasPrefix(value, `"`):
+		return strings.Trim(value, `"`)
+	case len(value) >= 2 && len(value)%2 == 0 && strings.Trim(value, "0123456789ABCDEF") == "":
+		b, err := hex.DecodeString(value)
+		if err == nil {
+			return string(b)
+		}
+	}
+	return value
+}
+
+// modeFileTypes are the file types of interpreted modes, see printMode
+var modeFileTypes = map[string]uint32{
+	"socket":    syscall.S_IFSOCK,
+	"block":     syscall.S_IFBLK,
+	"file":      syscall.S_IFREG,
+	"dir":       syscall.S_IFDIR,
+	"character": syscall.S_IFCHR,
+	"fifo":      syscall.S_IFIFO,
+	"link":      syscall.S_IFLNK,
+}
+
+// parseMode parses a mode field, the octal value reported by the kernel or its interpretation
+// (file644, dir,sticky777 ...)
+func parseMode(value string) (uint32, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+	if mode, err := strconv.ParseUint(value, 8, 32); err == nil {
+		return uint32(mode), true
+	}
+	// the permissions are the last 3 digits, the file type and the special bits precede them
+	if len(value) < 3 {
+		return 0, false
+	}
+	perm, err := strconv.ParseUint(value[len(value)-3:], 8, 32)
+	if err != nil {
+		return 0, false
+	}
+	mode := uint32(perm)
+	parts := strings.Split(value[:len(value)-3], ",")
+	fileType, ok := modeFileTypes[parts[0]]
+	if !ok {
+		return 0, false
+	}
+	mode |= fileType
+	for _, special := range parts[1:] {
+		switch special {
+		case "suid":
+			mode |= syscall.S_ISUID
+		case "sgid":
+			mode |= syscall.S_ISGID
+		case "sticky":
+			mode |= syscall.S_ISVTX
+		default:
+			return 0, false
+		}
+	}
+	return mode, true
+}
@@ -0,0 +1,71 @@
+package libaudit
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestEventGroupPaths(t *testing.T) {
+	msgs := []struct {
+		msgType auditConstant
+		msg     string
+	}{
+		{AUDIT_SYSCALL, `audit(1464163771.720:20): arch=c000003e syscall=82 success=yes exit=0 items=4 pid=2345`},
+		{AUDIT_CWD, `audit(1464163771.720:20): cwd="/home/bob"`},
+		{AUDIT_PATH, `audit(1464163771.720:20): item=2 name="old.txt" inode=1234 dev=08:01 mode=0100644 ouid=1000 ogid=1000 nametype=DELETE`},
+		{AUDIT_PATH, `audit(1464163771.720:20): item=0 name="/home/bob" inode=1000 dev=08:01 mode=040755 ouid=1000 ogid=1000 nametype=PARENT`},
+		{AUDIT_PATH, `audit(1464163771.720:20): name=6E6577207478742E747874 inode=1234 dev=08:01 mode=0100644 ouid=1000 ogid=1000 nametype=CREATE`},
+		{AUDIT_PATH, `audit(1464163771.720:20): item=1 name=(null) inode=1001 dev=08:01 mode=041777 ouid=0 ogid=0 nametype=PARENT`},
+	}
+	for _, interpret := range []bool{false, true} {
+		g := &EventGroup{}
+		for _, m := range msgs {
+			x, err := ParseAuditEvent(m.msg, m.msgType, interpret)
+			if err != nil {
+				t.Fatalf("parse failed %v", err)
+			}
+			if err := g.Add(x); err != nil {
+				t.Fatalf("Add failed %v", err)
+			}
+		}
+		if g.Serial != "20" || g.Record("SYSCALL") == nil || g.Record("PATH") != g.Records[2] || g.Record("EXECVE") != nil {
+			t.Errorf("EventGroup: unexpected group %+v", g)
+		}
+		paths := g.Paths()
+		if len(paths) != 4 {
+			t.Fatalf("Paths: expected 4 items, found %d", len(paths))
+		}
+		expected := []struct {
+			index int
+			name  string
+			mode  uint32
+		}{
+			{0, "/home/bob", syscall.S_IFDIR | 0755},
+			{1, "", syscall.S_IFDIR | syscall.S_ISVTX | 0777},
+			{2, "old.txt", syscall.S_IFREG | 0644},
+			{-1, "new txt.txt", syscall.S_IFREG | 0644},
+		}
+		for i, e := range expected {
+			p := paths[i]
+			if p.Index != e.index || p.Name != e.name || !p.ModeOK || p.Mode != e.mode || p.Record == nil {
+				t.Errorf("Paths (interpret %v): item %d: expected %v %q %o, found %v %q %o", interpret, i, e.index, e.name, e.mode, p.Index, p.Name, p.Mode)
+			}
+		}
+		if paths[2].NameType != "DELETE" || paths[2].Inode != "1234" || paths[2].Device != "08:01" {
+			t.Errorf("Paths: unexpected item %+v", paths[2])
+		}
+	}
+
+	x, err := ParseAuditEvent(`audit(1464163771.720:21): cwd="/"`, AUDIT_CWD, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	g, err := NewEventGroup(x)
+	if err != nil || len(g.Paths()) != 0 {
+		t.Errorf("NewEventGroup: unexpected result %+v %v", g, err)
+	}
+	x.Serial = "22"
+	if err := g.Add(x); err == nil {
+		t.Errorf("Add: expected error for a record of another event")
+	}
+}
@@ -0,0 +1,723 @@
+package libaudit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// ConfigChange holds the fields of an AUDIT_CONFIG_CHANGE event which is emitted by the kernel
+// whenever the audit rules or the audit configuration are modified.
+// Rule changes set Op (add_rule, remove_rule, ...), Key and List, configuration changes
+// (op=set) set Setting to the name of the changed value (audit_enabled, audit_pid, ...) with
+// its New and Old values. Fields that are not part of the record are left empty.
+type ConfigChange struct {
+	Op      string
+	Key     string
+	List    string
+	Action  string
+	Setting string
+	Old     string
+	New     string
+	AUID    string
+	Session string
+	Result  string
+}
+
+// configChangeFields are the fields of CONFIG_CHANGE records that are not the changed setting
+var configChangeFields = map[string]bool{
+	"op": true, "key": true, "list": true, "action": true, "old": true, "auid": true,
+	"ses": true, "res": true, "subj": true, "pid": true, "uid": true, "dir": true,
+	"path": true, "exe": true, "comm": true, "tty": true,
+}
+
+// AsConfigChange returns the fields of a CONFIG_CHANGE event as ConfigChange.
+// The values are taken as they are in Data, i.e. interpreted if the event was parsed with interpretation,
+// only the quotes around the key are removed.
+func (e *AuditEvent) AsConfigChange() (*ConfigChange, error) {
+	if e.Type != "CONFIG_CHANGE" {
+		return nil, fmt.Errorf("AsConfigChange failed: event type is %v, expected CONFIG_CHANGE", e.Type)
+	}
+	c := &ConfigChange{
+		Op:      e.Data["op"],
+		Key:     strings.Trim(e.Data["key"], "\""),
+		List:    e.Data["list"],
+		Action:  e.Data["action"],
+		Old:     e.Data["old"],
+		AUID:    e.Data["auid"],
+		Session: e.Data["ses"],
+		Result:  e.Data["res"],
+	}
+	// configuration changes are reported as <setting>=<new> old=<old>
+	if _, ok := e.Data["old"]; ok {
+		for field, value := range e.Data {
+			if !configChangeFields[field] {
+				c.Setting, c.New = field, value
+				break
+			}
+		}
+	}
+	return c, nil
+}
+
+// IntegrityEvent holds the fields of the INTEGRITY_* records emitted by IMA/EVM, e.g. on a failed
+// appraisal (op=appraise_data cause=invalid-hash) or for a measured file (INTEGRITY_RULE with its hash).
+// File is taken from the name or file field whichever the record has, fields that are not part of
+// the record are left empty.
+type IntegrityEvent struct {
+	Type    string // record type, INTEGRITY_DATA, INTEGRITY_RULE, ...
+	Op      string
+	Cause   string
+	File    string
+	Hash    string
+	Device  string
+	Inode   string
+	Comm    string
+	Exe     string
+	PID     string
+	AUID    string
+	Session string
+	Result  string
+}
+
+// AsIntegrityEvent returns the fields of an INTEGRITY_* event as IntegrityEvent.
+// The values are taken as they are in Data with quotes removed.
+func (e *AuditEvent) AsIntegrityEvent() (*IntegrityEvent, error) {
+	if !strings.HasPrefix(e.Type, "INTEGRITY_") {
+		return nil, fmt.Errorf("AsIntegrityEvent failed: event type is %v, expected INTEGRITY_*", e.Type)
+	}
+	file := e.Data["name"]
+	if len(file) == 0 {
+		file = e.Data["file"]
+	}
+	return &IntegrityEvent{
+		Type:    e.Type,
+		Op:      unquote(e.Data["op"]),
+		Cause:   unquote(e.Data["cause"]),
+		File:    unquote(file),
+		Hash:    unquote(e.Data["hash"]),
+		Device:  unquote(e.Data["dev"]),
+		Inode:   e.Data["ino"],
+		Comm:    unquote(e.Data["comm"]),
+		Exe:     unquote(e.Data["exe"]),
+		PID:     e.Data["pid"],
+		AUID:    e.Data["auid"],
+		Session: e.Data["ses"],
+		Result:  e.Data["res"],
+	}, nil
+}
+
+// AnomalyEvent holds the fields of the ANOM_* records, both the kernel ones (ANOM_ABEND for crashed
+// processes with the signal, ANOM_PROMISCUOUS for devices entering promiscuous mode, ANOM_LINK and
+// ANOM_CREAT for suspicious links and files) and the ones of user space anomaly detection.
+// Fields that are not part of the record are left empty.
+type AnomalyEvent struct {
+	Type           string // record type, ANOM_ABEND, ANOM_LOGIN_FAILURES, ...
+	Op             string
+	Signal         string
+	Device         string
+	Promiscuous    string
+	OldPromiscuous string
+	Comm           string
+	Exe            string
+	Account        string
+	PID            string
+	UID            string
+	AUID           string
+	Session        string
+	Result         string
+}
+
+// AsAnomalyEvent returns the fields of an ANOM_* event as AnomalyEvent.
+// The values are taken as they are in Data with quotes removed.
+func (e *AuditEvent) AsAnomalyEvent() (*AnomalyEvent, error) {
+	if !strings.HasPrefix(e.Type, "ANOM_") {
+		return nil, fmt.Errorf("AsAnomalyEvent failed: event type is %v, expected ANOM_*", e.Type)
+	}
+	return &AnomalyEvent{
+		Type:           e.Type,
+		Op:             unquote(e.Data["op"]),
+		Signal:         e.Data["sig"],
+		Device:         unquote(e.Data["dev"]),
+		Promiscuous:    e.Data["prom"],
+		OldPromiscuous: e.Data["old_prom"],
+		Comm:           unquote(e.Data["comm"]),
+		Exe:            unquote(e.Data["exe"]),
+		Account:        unquote(e.Data["acct"]),
+		PID:            e.Data["pid"],
+		UID:            e.Data["uid"],
+		AUID:           e.Data["auid"],
+		Session:        e.Data["ses"],
+		Result:         e.Data["res"],
+	}, nil
+}
+
+// loginEventTypes are the record types of the login session lifecycle AsLoginEvent accepts
+var loginEventTypes = map[string]bool{
+	"USER_LOGIN": true, "USER_LOGOUT": true, "USER_START": true, "USER_END": true,
+	"USER_AUTH": true, "USER_ACCT": true, "CRED_ACQ": true, "CRED_DISP": true, "CRED_REFR": true,
+}
+
+// LoginEvent holds the fields of the records describing a login session: USER_AUTH and USER_ACCT for the
+// authentication, CRED_ACQ, USER_LOGIN and USER_START when the session opens, CRED_REFR while it runs and
+// USER_END, CRED_DISP and USER_LOGOUT when it ends. The placeholders of missing values (? and (null)) are
+// returned as empty strings, Success reports res as bool (false if res is missing too).
+type LoginEvent struct {
+	Type     string // record type, USER_LOGIN, USER_START, ...
+	Op       string // i.e. PAM:session_open, login
+	Account  string
+	Hostname string
+	Addr     string
+	Terminal string
+	Exe      string
+	PID      string
+	UID      string
+	AUID     string
+	Session  string
+	Success  bool
+	Result   string
+}
+
+// AsLoginEvent returns the fields of a login record as LoginEvent, see LoginEvent for the record types.
+func (e *AuditEvent) AsLoginEvent() (*LoginEvent, error) {
+	if !loginEventTypes[e.Type] {
+		return nil, fmt.Errorf("AsLoginEvent failed: event type is %v, expected a login record (USER_LOGIN, USER_START ...)", e.Type)
+	}
+	l := &LoginEvent{
+		Type:     e.Type,
+		Op:       loginValue(e.Data["op"]),
+		Account:  loginValue(decodeEncodedValue(e.Data["acct"])),
+		Hostname: loginValue(e.Data["hostname"]),
+		Addr:     loginValue(e.Data["addr"]),
+		Terminal: loginValue(e.Data["terminal"]),
+		Exe:      loginValue(e.Data["exe"]),
+		PID:      e.Data["pid"],
+		UID:      e.Data["uid"],
+		AUID:     e.Data["auid"],
+		Session:  e.Data["ses"],
+		Result:   loginValue(e.Data["res"]),
+	}
+	l.Success, _ = e.Success()
+	return l, nil
+}
+
+// loginValue returns the value of a field of a login record without quotes, the placeholders
+// of missing values (? and (null)) as empty string
+func loginValue(value string) string {
+	switch value = unquote(value); value {
+	case "?", "(null)", "(none)":
+		return ""
+	}
+	return value
+}
+
+// unquote removes the quotes the parser leaves around some values
+func unquote(value string) string {
+	return strings.Trim(value, "\"'")
+}
+
+// ProcessInfo describes the process that caused an event, as reported by SYSCALL records and the records of
+// user space programs (USER_*). Comm and Exe are decoded if the kernel hex encoded them, the ids are -1 if they
+// are missing or unset (auid and ses of processes not part of a login session) and TTY is empty without terminal.
+type ProcessInfo struct {
+	PID     int
+	PPID    int
+	Comm    string
+	Exe     string
+	TTY     string
+	UID     int
+	GID     int
+	AUID    int
+	Session int
+}
+
+// ProcessInfo returns the process that caused the event, it fails for events without process context (i.e.
+// CONFIG_CHANGE records of recent kernels or PATH records). Interpreted ids (user and group names) are looked
+// up to obtain the numeric ids.
+func (e *AuditEvent) ProcessInfo() (*ProcessInfo, error) {
+	pid, ok := processID(e.Data["pid"])
+	if !ok {
+		return nil, fmt.Errorf("ProcessInfo failed: %v event has no process context", e.Type)
+	}
+	p := &ProcessInfo{
+		PID:     pid,
+		PPID:    -1,
+		Comm:    loginValue(decodeEncodedValue(e.Data["comm"])),
+		Exe:     loginValue(decodeEncodedValue(e.Data["exe"])),
+		TTY:     loginValue(e.Data["tty"]),
+		UID:     -1,
+		GID:     -1,
+		AUID:    -1,
+		Session: -1,
+	}
+	if ppid, ok := processID(e.Data["ppid"]); ok {
+		p.PPID = ppid
+	}
+	if uid, ok := idValue(e.Data["uid"], true); ok {
+		p.UID = int(uid)
+	}
+	if gid, ok := groupIDValue(e.Data["gid"]); ok {
+		p.GID = int(gid)
+	}
+	if auid, ok := e.LoginUID(); ok {
+		p.AUID = auid
+	}
+	if ses, ok := e.SessionID(); ok {
+		p.Session = int(ses)
+	}
+	return p, nil
+}
+
+// processID returns the value of a pid field
+func processID(value string) (int, bool) {
+	pid, err := strconv.ParseUint(value, 10, 31)
+	if err != nil {
+		return 0, false
+	}
+	return int(pid), true
+}
+
+// groupIDValue is idValue for gid fields, interpreted values (group names) are looked up
+func groupIDValue(value string) (uint32, bool) {
+	if id, ok := idValue(value, false); ok {
+		return id, true
+	}
+	if g, err := user.LookupGroup(value); err == nil {
+		if id, err := strconv.ParseUint(g.Gid, 10, 32); err == nil {
+			return uint32(id), true
+		}
+	}
+	return 0, false
+}
+
+// auditUnset is the value of auid and ses for processes that are not part of a login session
+const auditUnset = "4294967295"
+
+// idSentinels are the values of the id fields (uid, auid, ses ...) meaning no value, raw or interpreted
+var idSentinels = map[string]bool{auditUnset: true, "-1": true, "unset": true, "unknown(" + auditUnset + ")": true}
+
+// sentinelFields are the string fields the kernel and the user space programs set to ? (user
+// records), (null) (keys and names) or (none) (tty) if they have no value
+var sentinelFields = map[string]bool{
+	"acct":     true,
+	"addr":     true,
+	"comm":     true,
+	"cwd":      true,
+	"exe":      true,
+	"grantors": true,
+	"hostname": true,
+	"key":      true,
+	"name":     true,
+	"terminal": true,
+	"tty":      true,
+}
+
+// isSentinel reports whether value stands for no value in field
+func isSentinel(field, value string) bool {
+	if ftype, ok := fieldLookupMap[field]; ok {
+		switch ftype {
+		case typeUID, typeGID, typeSession:
+			return idSentinels[value]
+		}
+	}
+	if sentinelFields[field] {
+		switch value {
+		case "?", "(null)", "(none)":
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeSentinels removes the fields of the event whose value only means that there is no value: unset ids
+// (4294967295, -1 or unset for uid, auid, ses and the other id fields) and the placeholders ?, (null) and (none)
+// of the string fields (acct, addr, exe, hostname, key, name, terminal, tty ...). Absent fields are the only
+// representation of missing values then, the accessors (LoginUID, SessionID, ...) report them as not ok.
+// See SetNormalizeSentinels to have the receive loops normalize the events.
+func (e *AuditEvent) NormalizeSentinels() {
+	for field, value := range e.Data {
+		if isSentinel(field, value) {
+			delete(e.Data, field)
+		}
+	}
+}
+
+// idValue returns the numeric value of an id field (auid, ses), interpreted values (unset,
+// user names, unknown(<id>)) are converted back. ok is false for unset and unparsable values.
+func idValue(value string, isUser bool) (uint32, bool) {
+	switch {
+	case value == auditUnset, value == "unset", value == "-1", len(value) == 0:
+		return 0, false
+	case strings.HasPrefix(value, "unknown(") && strings.HasSuffix(value, ")"):
+		value = value[len("unknown(") : len(value)-1]
+	}
+	if id, err := strconv.ParseUint(value, 10, 32); err == nil && value != auditUnset {
+		return uint32(id), true
+	}
+	if isUser {
+		if u, err := user.Lookup(value); err == nil {
+			if id, err := strconv.ParseUint(u.Uid, 10, 32); err == nil {
+				return uint32(id), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// SessionID returns the audit session id (ses) of the event.
+// ok is false if the field is missing or unset, i.e. for processes that are not part of a login session.
+func (e *AuditEvent) SessionID() (uint32, bool) {
+	return idValue(e.Data["ses"], false)
+}
+
+// LoginUID returns the login uid (auid) of the event, the uid the user logged in with.
+// ok is false if the field is missing or unset (daemons and processes started before login).
+// Interpreted events carry the user name, it is looked up to obtain the uid.
+func (e *AuditEvent) LoginUID() (int, bool) {
+	auid, ok := idValue(e.Data["auid"], true)
+	return int(auid), ok
+}
+
+// SessionKey returns "<auid>:<ses>" identifying the login session of the event, it is the same for
+// all events of one login session and can be used to group them.
+// ok is false if the event is not part of a login session.
+func (e *AuditEvent) SessionKey() (string, bool) {
+	ses, ok := e.SessionID()
+	if !ok {
+		return "", false
+	}
+	auid, ok := e.LoginUID()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%d:%d", auid, ses), true
+}
+
+// EventID identifies an audit event by its timestamp and serial, all records of a multi-record event
+// share it. It is comparable and can key maps, String returns the form the kernel reports
+// (1617181920.345:6789) and ParseEventID parses it back.
+type EventID struct {
+	Sec    uint64 // seconds of the timestamp
+	Msec   uint32 // milliseconds of the timestamp
+	Serial uint64
+}
+
+// ParseEventID parses the "<sec>.<msec>:<serial>" form of an EventID.
+func ParseEventID(s string) (EventID, error) {
+	var id EventID
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return id, fmt.Errorf("ParseEventID: missing serial in %q", s)
+	}
+	sec, msec := s[:i], ""
+	if j := strings.IndexByte(sec, '.'); j >= 0 {
+		sec, msec = sec[:j], sec[j+1:]
+	}
+	var err error
+	if id.Sec, err = strconv.ParseUint(sec, 10, 64); err != nil {
+		return EventID{}, errors.Wrapf(err, "ParseEventID: invalid timestamp in %q", s)
+	}
+	if len(msec) > 0 {
+		if len(msec) > 3 {
+			return EventID{}, fmt.Errorf("ParseEventID: invalid milliseconds in %q", s)
+		}
+		ms, err := strconv.ParseUint(msec, 10, 32)
+		if err != nil {
+			return EventID{}, errors.Wrapf(err, "ParseEventID: invalid milliseconds in %q", s)
+		}
+		// .72 is 720 milliseconds
+		for k := len(msec); k < 3; k++ {
+			ms *= 10
+		}
+		id.Msec = uint32(ms)
+	}
+	if id.Serial, err = strconv.ParseUint(s[i+1:], 10, 64); err != nil {
+		return EventID{}, errors.Wrapf(err, "ParseEventID: invalid serial in %q", s)
+	}
+	return id, nil
+}
+
+func (id EventID) String() string {
+	return fmt.Sprintf("%d.%03d:%d", id.Sec, id.Msec, id.Serial)
+}
+
+// Time returns the timestamp of the event.
+func (id EventID) Time() time.Time {
+	return time.Unix(int64(id.Sec), int64(id.Msec)*int64(time.Millisecond))
+}
+
+// ID returns the EventID of the event from its Timestamp and Serial, the zero EventID if they can't be parsed.
+func (e *AuditEvent) ID() EventID {
+	id, _ := ParseEventID(e.Timestamp + ":" + e.Serial)
+	return id
+}
+
+// permNames are the interpretations of the perm bits, see printPerm
+var permNames = map[string]int{
+	"read":  AUDIT_PERM_READ,
+	"write": AUDIT_PERM_WRITE,
+	"exec":  AUDIT_PERM_EXEC,
+	"attr":  AUDIT_PERM_ATTR,
+}
+
+// InterpretPerm returns the perm field of the event (i.e. of CONFIG_CHANGE records of watch rules) as
+// the permission string of the rules (rw, wa ...), see DecodePerm. The field may be interpreted already.
+func (e *AuditEvent) InterpretPerm() (string, error) {
+	value, ok := e.Data["perm"]
+	if !ok {
+		return "", fmt.Errorf("InterpretPerm: event has no perm field")
+	}
+	if mask, err := strconv.ParseInt(value, 10, 32); err == nil {
+		return DecodePerm(int(mask)), nil
+	}
+	var mask int
+	for _, name := range strings.Split(value, ",") {
+		bit, ok := permNames[name]
+		if !ok {
+			return "", fmt.Errorf("InterpretPerm: invalid perm %q", value)
+		}
+		mask |= bit
+	}
+	return DecodePerm(mask), nil
+}
+
+// successFields are the fields reporting the outcome of an event, in the order they are looked up
+var successFields = []string{"success", "res", "result"}
+
+// Success returns the outcome of the event from its success (SYSCALL), res or result field whatever the
+// notation (yes/no, success/failed, 1/0), interpreted or not.
+// ok is false if the event has none of the fields or its outcome is unset.
+func (e *AuditEvent) Success() (success bool, ok bool) {
+	for _, field := range successFields {
+		value, found := e.Data[field]
+		if !found {
+			continue
+		}
+		switch strings.ToLower(strings.Trim(value, "\"'")) {
+		case "yes", "success", "1", "true":
+			return true, true
+		case "no", "failed", "fail", "0", "false":
+			return false, true
+		}
+		return false, false
+	}
+	return false, false
+}
+
+// Keys returns the keys (-k) of the rule that recorded the event, nil if the event has no key field or
+// the rule has no key. The kernel joins the keys of rules with several keys with \x01 and hex encodes them,
+// the key field is decoded whether the event was interpreted or not.
+func (e *AuditEvent) Keys() []string {
+	key := decodeEncodedValue(e.Data["key"])
+	if len(key) == 0 {
+		return nil
+	}
+	return strings.Split(key, "\x01")
+}
+
+// Operation returns the op field of the event without quotes, e.g. PAM:authentication for user
+// records or add_rule for CONFIG_CHANGE. ok is false if the event has no op field.
+func (e *AuditEvent) Operation() (string, bool) {
+	op, ok := e.Data["op"]
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(op, "\"'"), true
+}
+
+// ttyControlSequences are the names of the keys TTYData renders, multi byte sequences come first
+// so that they are matched before the escape character alone
+var ttyControlSequences = []struct {
+	seq  string
+	name string
+}{
+	{"\x1b[A", "<up>"}, {"\x1b[B", "<down>"}, {"\x1b[C", "<right>"}, {"\x1b[D", "<left>"},
+	{"\x1bOA", "<up>"}, {"\x1bOB", "<down>"}, {"\x1bOC", "<right>"}, {"\x1bOD", "<left>"},
+	{"\x1b[H", "<home>"}, {"\x1b[F", "<end>"}, {"\x1bOH", "<home>"}, {"\x1bOF", "<end>"},
+	{"\x1b[2~", "<insert>"}, {"\x1b[3~", "<delete>"}, {"\x1b[5~", "<pageup>"}, {"\x1b[6~", "<pagedown>"},
+	{"\x1b", "<esc>"}, {"\x7f", "<backspace>"}, {"\b", "<backspace>"}, {"\r", "<ret>"},
+	{"\n", "<nl>"}, {"\t", "<tab>"},
+}
+
+// TTYData returns the keystrokes of a TTY record, which the kernel reports hex encoded in the data field.
+// Printable characters (including UTF-8) are returned as they are, known keys are named in angle
+// brackets (<ret>, <tab>, <backspace>, <up>, <esc>, ...), other control characters are written in caret
+// notation (^C for 0x03) and remaining non-printable bytes as \xNN. "<" and "\" in the input itself are
+// therefore ambiguous, use the data field to get the exact bytes.
+func (e *AuditEvent) TTYData() (string, error) {
+	if e.Type != "TTY" {
+		return "", fmt.Errorf("TTYData failed: event type is %v, expected TTY", e.Type)
+	}
+	data, ok := e.Data["data"]
+	if !ok {
+		return "", fmt.Errorf("TTYData failed: no data field")
+	}
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return "", errors.Wrap(err, "TTYData failed: decoding data")
+	}
+	return formatTTYData(b), nil
+}
+
+// TTYRecord is the input read from a terminal by a process with TTY auditing enabled (see AuditSetTTYStatus),
+// the kernel logs it in TTY records.
+type TTYRecord struct {
+	Process *ProcessInfo // the process reading the terminal, Exe is empty
+	Major   int          // device number of the terminal
+	Minor   int
+	Data    []byte // the exact input, TTYData renders it
+}
+
+// TTYRecord returns the process, terminal and input of a TTY record.
+func (e *AuditEvent) TTYRecord() (*TTYRecord, error) {
+	if e.Type != "TTY" {
+		return nil, fmt.Errorf("TTYRecord failed: event type is %v, expected TTY", e.Type)
+	}
+	p, err := e.ProcessInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "TTYRecord failed")
+	}
+	major, err := strconv.Atoi(e.Data["major"])
+	if err != nil {
+		return nil, errors.Wrap(err, "TTYRecord failed: parsing major")
+	}
+	minor, err := strconv.Atoi(e.Data["minor"])
+	if err != nil {
+		return nil, errors.Wrap(err, "TTYRecord failed: parsing minor")
+	}
+	data, err := hex.DecodeString(e.Data["data"])
+	if err != nil {
+		return nil, errors.Wrap(err, "TTYRecord failed: decoding data")
+	}
+	return &TTYRecord{Process: p, Major: major, Minor: minor, Data: data}, nil
+}
+
+// formatTTYData renders the keystrokes b as described in TTYData
+func formatTTYData(b []byte) string {
+	var out strings.Builder
+next:
+	for len(b) > 0 {
+		for _, c := range ttyControlSequences {
+			if bytes.HasPrefix(b, []byte(c.seq)) {
+				out.WriteString(c.name)
+				b = b[len(c.seq):]
+				continue next
+			}
+		}
+		r, size := utf8.DecodeRune(b)
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			fmt.Fprintf(&out, "\\x%02x", b[0])
+		case r < 0x20:
+			out.WriteByte('^')
+			out.WriteByte(byte(r) + '@')
+		case unicode.IsPrint(r):
+			out.WriteRune(r)
+		default:
+			for _, c := range b[:size] {
+				fmt.Fprintf(&out, "\\x%02x", c)
+			}
+		}
+		b = b[size:]
+	}
+	return out.String()
+}
+
+// Argv returns the arguments of an EXECVE record in order. The kernel hex encodes arguments containing
+// spaces, quotes or non-printable characters and splits long arguments into chunks (a1_len=... a1[0]=...
+// a1[1]=...), both are decoded and joined whether the record was interpreted or not (like the names of
+// Paths, encoded arguments are detected by their upper case digits). Arguments of long command lines
+// continue in further EXECVE records of the event, use (*EventGroup).Argv for these.
+func (e *AuditEvent) Argv() ([]string, error) {
+	if e.Type != "EXECVE" {
+		return nil, fmt.Errorf("Argv failed: event type is %v, expected EXECVE", e.Type)
+	}
+	argv, err := execveArgv([]map[string]string{e.Data})
+	if err != nil {
+		return nil, errors.Wrap(err, "Argv failed")
+	}
+	return argv, nil
+}
+
+// execveArgv assembles the arguments of the fields of the EXECVE records of an event, the first
+// record holds argc
+func execveArgv(records []map[string]string) ([]string, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no EXECVE record")
+	}
+	argc, err := strconv.Atoi(records[0]["argc"])
+	if err != nil || argc < 0 {
+		return nil, fmt.Errorf("invalid argc %q", records[0]["argc"])
+	}
+	lookup := func(field string) (string, bool) {
+		for _, data := range records {
+			if v, ok := data[field]; ok {
+				return v, true
+			}
+		}
+		return "", false
+	}
+	argv := make([]string, 0, argc)
+	for i := 0; i < argc; i++ {
+		name := "a" + strconv.Itoa(i)
+		if v, ok := lookup(name); ok {
+			argv = append(argv, decodeEncodedValue(v))
+			continue
+		}
+		length, ok := lookup(name + "_len")
+		if !ok {
+			return nil, fmt.Errorf("argument %d of %d missing", i, argc)
+		}
+		var arg strings.Builder
+		for chunk := 0; ; chunk++ {
+			v, ok := lookup(name + "[" + strconv.Itoa(chunk) + "]")
+			if !ok {
+				break
+			}
+			arg.WriteString(v)
+		}
+		// the length is the one of the argument, the chunks are hex encoded unless the event was interpreted
+		value := arg.String()
+		if n, err := strconv.Atoi(length); err == nil && len(value) == 2*n {
+			if b, err := hex.DecodeString(value); err == nil {
+				value = string(b)
+			}
+		}
+		argv = append(argv, value)
+	}
+	return argv, nil
+}
+
+// Proctitle returns the arguments of the command line of a PROCTITLE record, which the kernel reports hex
+// encoded with NUL separated arguments (or quoted if it is a single argument without special characters)
+// and truncated to 128 bytes. Interpreted records hold the arguments separated by spaces, which are split
+// on the spaces, arguments containing spaces are only told apart exactly in records which are not
+// interpreted or with SetKeepRawProctitle.
+func (e *AuditEvent) Proctitle() ([]string, error) {
+	if e.Type != "PROCTITLE" {
+		return nil, fmt.Errorf("Proctitle failed: event type is %v, expected PROCTITLE", e.Type)
+	}
+	value, ok := e.Data["proctitle"]
+	if !ok {
+		return nil, fmt.Errorf("Proctitle failed: no proctitle field")
+	}
+	switch {
+	case strings.HasPrefix(value, `"`):
+		return []string{strings.Trim(value, `"`)}, nil
+	case len(value) >= 2 && len(value)%2 == 0 && strings.Trim(value, "0123456789ABCDEF") == "":
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "Proctitle failed: decoding proctitle")
+		}
+		return strings.Split(strings.TrimRight(string(b), "\x00"), "\x00"), nil
+	}
+	return strings.Split(value, " "), nil
+}
@@ -0,0 +1,452 @@
+package libaudit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAsConfigChange(t *testing.T) {
+	var tests = []struct {
+		msg      string
+		expected ConfigChange
+	}{
+		{
+			`audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`,
+			ConfigChange{Op: "add_rule", Key: "passwd", List: "4", AUID: "1000", Session: "2", Result: "1"},
+		},
+		{
+			`audit(1464163771.720:21): op=set audit_backlog_limit=8192 old=320 auid=4294967295 ses=4294967295 res=1`,
+			ConfigChange{Op: "set", Setting: "audit_backlog_limit", New: "8192", Old: "320", AUID: "4294967295", Session: "4294967295", Result: "1"},
+		},
+	}
+	for _, tt := range tests {
+		x, err := ParseAuditEvent(tt.msg, AUDIT_CONFIG_CHANGE, false)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		c, err := x.AsConfigChange()
+		if err != nil {
+			t.Fatalf("AsConfigChange failed %v", err)
+		}
+		if *c != tt.expected {
+			t.Errorf("AsConfigChange: expected %+v, found %+v", tt.expected, *c)
+		}
+	}
+
+	x, err := ParseAuditEvent(`audit(1464163771.720:22): pid=1 auid=1000`, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.AsConfigChange(); err == nil {
+		t.Errorf("AsConfigChange: expected error for %v event", x.Type)
+	}
+}
+
+func TestSessionID(t *testing.T) {
+	var tests = []struct {
+		msg        string
+		interpret  bool
+		auid       int
+		ses        uint32
+		key        string
+		inSession  bool
+		hasLoginID bool
+	}{
+		{`audit(1464163771.720:20): pid=1 auid=1000 ses=2 res=1`, false, 1000, 2, "1000:2", true, true},
+		{`audit(1464163771.720:21): pid=1 auid=0 ses=12 res=1`, true, 0, 12, "0:12", true, true},
+		{`audit(1464163771.720:22): pid=1 auid=4294967295 ses=4294967295 res=1`, false, 0, 0, "", false, false},
+		{`audit(1464163771.720:23): pid=1 auid=4294967295 ses=4294967295 res=1`, true, 0, 0, "", false, false},
+		{`audit(1464163771.720:24): pid=1 res=1`, false, 0, 0, "", false, false},
+	}
+	for _, tt := range tests {
+		x, err := ParseAuditEvent(tt.msg, AUDIT_USER_LOGIN, tt.interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		if auid, ok := x.LoginUID(); auid != tt.auid || ok != tt.hasLoginID {
+			t.Errorf("LoginUID: %v: expected %d %v, found %d %v", tt.msg, tt.auid, tt.hasLoginID, auid, ok)
+		}
+		if ses, ok := x.SessionID(); ses != tt.ses || ok != tt.inSession {
+			t.Errorf("SessionID: %v: expected %d %v, found %d %v", tt.msg, tt.ses, tt.inSession, ses, ok)
+		}
+		if key, ok := x.SessionKey(); key != tt.key || ok != tt.inSession {
+			t.Errorf("SessionKey: %v: expected %q %v, found %q %v", tt.msg, tt.key, tt.inSession, key, ok)
+		}
+	}
+}
+
+func TestEventID(t *testing.T) {
+	syscallEvent, err := ParseAuditEvent(`audit(1464163771.720:020): arch=c000003e syscall=2 success=yes exit=3`, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	cwdEvent, err := ParseAuditEvent(`audit(1464163771.720:20): cwd="/"`, AUDIT_CWD, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	id := syscallEvent.ID()
+	if id != cwdEvent.ID() || id != (EventID{Sec: 1464163771, Msec: 720, Serial: 20}) {
+		t.Errorf("ID: unexpected ids %v %v", id, cwdEvent.ID())
+	}
+	if id.String() != "1464163771.720:20" || id.Time().UnixNano() != 1464163771720*int64(time.Millisecond) {
+		t.Errorf("EventID: unexpected string %q or time %v", id.String(), id.Time())
+	}
+	if parsed, err := ParseEventID(id.String()); err != nil || parsed != id {
+		t.Errorf("ParseEventID: expected %v, found %v %v", id, parsed, err)
+	}
+	if parsed, err := ParseEventID("1464163771.72:5"); err != nil || parsed.Msec != 720 {
+		t.Errorf("ParseEventID: expected 720 milliseconds, found %v %v", parsed, err)
+	}
+	for _, s := range []string{"", "1464163771.720", "x.720:20", "1464163771.7200:20", "1464163771.720:"} {
+		if _, err := ParseEventID(s); err == nil {
+			t.Errorf("ParseEventID: expected error for %q", s)
+		}
+	}
+	if (&AuditEvent{}).ID() != (EventID{}) {
+		t.Errorf("ID: expected the zero EventID without timestamp")
+	}
+}
+
+func TestInterpretPerm(t *testing.T) {
+	msg := `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1 perm=10`
+	for _, interpret := range []bool{false, true} {
+		x, err := ParseAuditEvent(msg, AUDIT_CONFIG_CHANGE, interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		if perm, err := x.InterpretPerm(); err != nil || perm != "wa" {
+			t.Errorf("InterpretPerm (interpret %v): expected wa, found %q %v", interpret, perm, err)
+		}
+	}
+	if _, err := (&AuditEvent{Data: map[string]string{}}).InterpretPerm(); err == nil {
+		t.Errorf("InterpretPerm: expected error without perm field")
+	}
+}
+
+func TestSuccessOperation(t *testing.T) {
+	var tests = []struct {
+		msg       string
+		msgType   auditConstant
+		success   bool
+		hasResult bool
+		op        string
+	}{
+		{`audit(1464163771.720:20): arch=c000003e syscall=2 success=yes exit=3 a0=1 items=1 pid=2`, AUDIT_SYSCALL, true, true, ""},
+		{`audit(1464163771.720:21): arch=c000003e syscall=2 success=no exit=-13 a0=1 items=1 pid=2`, AUDIT_SYSCALL, false, true, ""},
+		{`audit(1464163771.720:22): pid=1234 uid=0 auid=1000 ses=3 msg='op=PAM:authentication grantors=? acct="bob" exe="/usr/bin/sudo" hostname=? addr=? terminal=/dev/pts/0 res=failed'`, AUDIT_USER_AUTH, false, true, "PAM:authentication"},
+		{`audit(1464163771.720:23): pid=1234 uid=0 auid=1000 ses=3 msg='op=PAM:authentication grantors=pam_unix acct="bob" exe="/usr/bin/sudo" hostname=? addr=? terminal=/dev/pts/0 res=success'`, AUDIT_USER_AUTH, true, true, "PAM:authentication"},
+		{`audit(1464163771.720:24): auid=1000 ses=2 op="add_rule" key="passwd" list=4 res=1`, AUDIT_CONFIG_CHANGE, true, true, "add_rule"},
+		{`audit(1464163771.720:25): auid=1000 ses=2 op=remove_rule key="passwd" list=4 res=0`, AUDIT_CONFIG_CHANGE, false, true, "remove_rule"},
+		{`audit(1464163771.720:26): item=0 name="/etc/passwd" inode=1 dev=08:01 mode=0100644`, AUDIT_PATH, false, false, ""},
+	}
+	for _, tt := range tests {
+		for _, interpret := range []bool{false, true} {
+			x, err := ParseAuditEvent(tt.msg, tt.msgType, interpret)
+			if err != nil {
+				t.Fatalf("parse failed %v", err)
+			}
+			if success, ok := x.Success(); success != tt.success || ok != tt.hasResult {
+				t.Errorf("Success: %v (interpret %v): expected %v %v, found %v %v", tt.msg, interpret, tt.success, tt.hasResult, success, ok)
+			}
+			if op, ok := x.Operation(); op != tt.op || ok != (len(tt.op) > 0) {
+				t.Errorf("Operation: %v: expected %q, found %q %v", tt.msg, tt.op, op, ok)
+			}
+		}
+	}
+}
+
+func TestTTYData(t *testing.T) {
+	msg := `audit(1464163771.720:20): tty pid=2345 uid=0 auid=1000 ses=3 major=136 minor=0 comm="bash" data=6C73202D6C0D1B5B4109037FC3A9FF`
+	for _, interpret := range []bool{false, true} {
+		x, err := ParseAuditEvent(msg, AUDIT_TTY, interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		data, err := x.TTYData()
+		if err != nil {
+			t.Fatalf("TTYData failed %v", err)
+		}
+		if expected := `ls -l<ret><up><tab>^C<backspace>é\xff`; data != expected {
+			t.Errorf("TTYData: expected %q, found %q", expected, data)
+		}
+	}
+	x, err := ParseAuditEvent(`audit(1464163771.720:21): pid=2345 uid=0 data=zz`, AUDIT_TTY, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.TTYData(); err == nil {
+		t.Errorf("TTYData: expected error for invalid data")
+	}
+	x.Type = "SYSCALL"
+	if _, err := x.TTYData(); err == nil {
+		t.Errorf("TTYData: expected error for SYSCALL event")
+	}
+}
+
+func TestTTYRecord(t *testing.T) {
+	msg := `audit(1464163771.720:20): tty pid=2345 uid=0 auid=1000 ses=3 major=136 minor=1 comm="bash" data=6C730D`
+	for _, interpret := range []bool{false, true} {
+		x, err := ParseAuditEvent(msg, AUDIT_TTY, interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		r, err := x.TTYRecord()
+		if err != nil {
+			t.Fatalf("TTYRecord failed %v", err)
+		}
+		p := r.Process
+		if p.PID != 2345 || p.UID != 0 || p.AUID != 1000 || p.Session != 3 || p.Comm != "bash" ||
+			r.Major != 136 || r.Minor != 1 || string(r.Data) != "ls\r" {
+			t.Errorf("TTYRecord: unexpected record %+v of process %+v", r, p)
+		}
+	}
+	x, err := ParseAuditEvent(`audit(1464163771.720:21): tty pid=2345 uid=0 auid=1000 ses=3 comm="bash" data=6C73`, AUDIT_TTY, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.TTYRecord(); err == nil {
+		t.Errorf("TTYRecord: expected error without device number")
+	}
+}
+
+func TestIntegrityAnomalyEvents(t *testing.T) {
+	if AUDIT_INTEGRITY_DATA.typeName() != "INTEGRITY_DATA" || AUDIT_ANOM_LOGIN_FAILURES.typeName() != "ANOM_LOGIN_FAILURES" ||
+		AUDIT_RESP_ANOMALY.typeName() != "RESP_ANOMALY" || !AUDIT_INTEGRITY_POLICY_RULE.IsKnown() || !AUDIT_ANOM_CREAT.IsKnown() {
+		t.Errorf("typeName: unexpected names of integrity and anomaly types")
+	}
+	msg := testEventMessage(AUDIT_INTEGRITY_DATA, `audit(1464163771.720:20): pid=2345 uid=0 auid=1000 ses=3 op=appraise_data cause=invalid-hash comm="bash" name="/usr/bin/tool" dev="sda1" ino=1234 res=0`)
+	x, err := NewAuditEvent(msg)
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed %v", err)
+	}
+	i, err := x.AsIntegrityEvent()
+	if err != nil {
+		t.Fatalf("AsIntegrityEvent failed %v", err)
+	}
+	if i.Type != "INTEGRITY_DATA" || i.Op != "appraise_data" || i.Cause != "invalid-hash" || i.File != "/usr/bin/tool" ||
+		i.Device != "sda1" || i.Inode != "1234" || i.Comm != "bash" || i.PID != "2345" {
+		t.Errorf("AsIntegrityEvent: unexpected result %+v", i)
+	}
+	if _, err := x.AsAnomalyEvent(); err == nil {
+		t.Errorf("AsAnomalyEvent: expected error for %v", x.Type)
+	}
+
+	msg = testEventMessage(AUDIT_ANOM_ABEND, `audit(1464163771.720:21): auid=1000 uid=1000 gid=1000 ses=3 pid=4567 comm="crash" exe="/usr/bin/crash" sig=11 res=1`)
+	x, err = NewAuditEvent(msg)
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed %v", err)
+	}
+	a, err := x.AsAnomalyEvent()
+	if err != nil {
+		t.Fatalf("AsAnomalyEvent failed %v", err)
+	}
+	if a.Type != "ANOM_ABEND" || a.Comm != "crash" || a.Exe != "/usr/bin/crash" || a.PID != "4567" || a.Signal == "" {
+		t.Errorf("AsAnomalyEvent: unexpected result %+v", a)
+	}
+	if _, err := x.AsIntegrityEvent(); err == nil {
+		t.Errorf("AsIntegrityEvent: expected error for %v", x.Type)
+	}
+}
+
+func TestProcessInfo(t *testing.T) {
+	msg := `audit(1464163771.720:20): arch=c000003e syscall=59 success=yes exit=0 ppid=2345 pid=2346 auid=4294967295 uid=0 gid=0 euid=0 ses=4294967295 tty=pts0 comm="ls" exe=2F62696E2F6C7320646972`
+	for _, interpret := range []bool{false, true} {
+		x, err := ParseAuditEvent(msg, AUDIT_SYSCALL, interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		p, err := x.ProcessInfo()
+		if err != nil {
+			t.Fatalf("ProcessInfo failed %v", err)
+		}
+		expected := ProcessInfo{PID: 2346, PPID: 2345, Comm: "ls", Exe: "/bin/ls dir", TTY: "pts0", UID: 0, GID: 0, AUID: -1, Session: -1}
+		if *p != expected {
+			t.Errorf("ProcessInfo (interpret %v): expected %+v, found %+v", interpret, expected, *p)
+		}
+	}
+
+	x, err := ParseAuditEvent(`audit(1464163771.720:21): pid=1234 uid=0 auid=1000 ses=3 msg='op=PAM:session_open acct="bob" exe="/usr/sbin/sshd" hostname=10.0.0.1 addr=10.0.0.1 terminal=ssh res=success'`, AUDIT_USER_START, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	p, err := x.ProcessInfo()
+	if err != nil || p.PID != 1234 || p.PPID != -1 || p.Exe != "/usr/sbin/sshd" || p.TTY != "" || p.AUID != 1000 || p.Session != 3 || p.GID != -1 {
+		t.Errorf("ProcessInfo: unexpected result %+v %v", p, err)
+	}
+
+	x, err = ParseAuditEvent(`audit(1464163771.720:22): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`, AUDIT_CONFIG_CHANGE, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.ProcessInfo(); err == nil {
+		t.Errorf("ProcessInfo: expected error for CONFIG_CHANGE")
+	}
+}
+
+func TestNormalizeSentinels(t *testing.T) {
+	msgs := []struct {
+		msg     string
+		msgType auditConstant
+		removed []string
+	}{
+		{`audit(1464163771.720:20): arch=c000003e syscall=59 success=yes exit=0 ppid=1 pid=2346 auid=4294967295 uid=0 ses=4294967295 tty=(none) comm="cron" exe="/usr/sbin/cron" key=(null)`,
+			AUDIT_SYSCALL, []string{"auid", "ses", "tty", "key"}},
+		{`audit(1464163771.720:21): pid=1234 uid=0 auid=1000 ses=3 msg='op=PAM:authentication grantors=? acct="bob" exe="/usr/bin/sudo" hostname=? addr=? terminal=/dev/pts/0 res=failed'`,
+			AUDIT_USER_AUTH, []string{"grantors", "hostname", "addr"}},
+	}
+	for _, interpret := range []bool{false, true} {
+		for _, m := range msgs {
+			x, err := ParseAuditEvent(m.msg, m.msgType, interpret)
+			if err != nil {
+				t.Fatalf("parse failed %v", err)
+			}
+			fields := len(x.Data)
+			x.NormalizeSentinels()
+			for _, f := range m.removed {
+				if v, ok := x.Data[f]; ok {
+					t.Errorf("NormalizeSentinels (interpret %v): expected %v to be removed, found %q", interpret, f, v)
+				}
+			}
+			if len(x.Data) != fields-len(m.removed) {
+				t.Errorf("NormalizeSentinels (interpret %v): expected only %v to be removed, found %v", interpret, m.removed, x.Data)
+			}
+			if _, ok := x.LoginUID(); ok != (m.msgType == AUDIT_USER_AUTH) {
+				t.Errorf("LoginUID (interpret %v): unexpected ok %v", interpret, ok)
+			}
+		}
+	}
+
+	SetNormalizeSentinels(true)
+	defer SetNormalizeSentinels(false)
+	x, err := NewAuditEvent(testEventMessage(AUDIT_SYSCALL, msgs[0].msg))
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed %v", err)
+	}
+	if _, ok := x.Data["auid"]; ok || x.Data["comm"] != "cron" {
+		t.Errorf("SetNormalizeSentinels: expected the event to be normalized, found %v", x.Data)
+	}
+}
+
+func TestAsLoginEvent(t *testing.T) {
+	var tests = []struct {
+		msg      string
+		msgType  auditConstant
+		expected LoginEvent
+	}{
+		{`audit(1464163771.720:20): pid=1234 uid=0 auid=1000 ses=3 msg='op=login id=1000 exe="/usr/sbin/sshd" hostname=10.0.0.5 addr=10.0.0.5 terminal=/dev/pts/0 res=success'`,
+			AUDIT_USER_LOGIN, LoginEvent{Type: "USER_LOGIN", Op: "login", Hostname: "10.0.0.5", Addr: "10.0.0.5",
+				Terminal: "/dev/pts/0", Exe: "/usr/sbin/sshd", PID: "1234", AUID: "1000", Session: "3", Success: true, Result: "success"}},
+		{`audit(1464163771.720:21): pid=1234 uid=0 auid=1000 ses=3 msg='op=PAM:session_close grantors=pam_unix acct="bob" exe="/usr/sbin/sshd" hostname=? addr=? terminal=(null) res=failed'`,
+			AUDIT_USER_END, LoginEvent{Type: "USER_END", Op: "PAM:session_close", Account: "bob", Exe: "/usr/sbin/sshd",
+				PID: "1234", AUID: "1000", Session: "3", Result: "failed"}},
+		{`audit(1464163771.720:22): pid=1234 uid=0 auid=1000 ses=3 msg='op=PAM:setcred acct=626F62 exe="/usr/bin/sudo" hostname=? addr=? terminal=/dev/pts/1 res=success'`,
+			AUDIT_CRED_ACQ, LoginEvent{Type: "CRED_ACQ", Op: "PAM:setcred", Account: "bob", Exe: "/usr/bin/sudo",
+				Terminal: "/dev/pts/1", PID: "1234", AUID: "1000", Session: "3", Success: true, Result: "success"}},
+	}
+	for _, tt := range tests {
+		x, err := ParseAuditEvent(tt.msg, tt.msgType, false)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		l, err := x.AsLoginEvent()
+		if err != nil {
+			t.Fatalf("AsLoginEvent failed %v", err)
+		}
+		tt.expected.UID = "0"
+		if *l != tt.expected {
+			t.Errorf("AsLoginEvent: expected %+v, found %+v", tt.expected, *l)
+		}
+	}
+	x, err := ParseAuditEvent(`audit(1464163771.720:23): arch=c000003e syscall=2 success=yes exit=3`, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.AsLoginEvent(); err == nil {
+		t.Errorf("AsLoginEvent: expected error for SYSCALL event")
+	}
+}
+
+func TestArgv(t *testing.T) {
+	for _, interpret := range []bool{false, true} {
+		// hex encoded and split arguments
+		x, err := ParseAuditEvent(`audit(1464163771.720:020): argc=4 a0="echo" a1=68656C6C6F20776F726C64 a2_len=10 a2[0]=6C6F6E67 a2[1]=5F617267 a2[2]=3231 a3="x"`, AUDIT_EXECVE, interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		argv, err := x.Argv()
+		if err != nil {
+			t.Fatalf("Argv failed %v", err)
+		}
+		if expected := []string{"echo", "hello world", "long_arg21", "x"}; !reflect.DeepEqual(argv, expected) {
+			t.Errorf("Argv (interpret %v): expected %q, found %q", interpret, expected, argv)
+		}
+	}
+
+	// the command line continues in the next record
+	first, err := ParseAuditEvent(`audit(1464163771.720:021): argc=3 a0="ls" a1="-l"`, AUDIT_EXECVE, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := first.Argv(); err == nil {
+		t.Errorf("Argv: expected error for a missing argument")
+	}
+	second, err := ParseAuditEvent(`audit(1464163771.720:021): a2="/tmp"`, AUDIT_EXECVE, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	g, err := NewEventGroup(first, second)
+	if err != nil {
+		t.Fatalf("NewEventGroup failed %v", err)
+	}
+	if argv, err := g.Argv(); err != nil || !reflect.DeepEqual(argv, []string{"ls", "-l", "/tmp"}) {
+		t.Errorf("EventGroup.Argv: unexpected result %q %v", argv, err)
+	}
+
+	x, err := ParseAuditEvent(`audit(1464163771.720:022): arch=c000003e syscall=59 success=yes exit=0`, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.Argv(); err == nil {
+		t.Errorf("Argv: expected error for SYSCALL event")
+	}
+}
+
+func TestProctitle(t *testing.T) {
+	// ls -l "/tmp dir"
+	msg := `audit(1464163771.720:020): proctitle=6C73002D6C002F746D7020646972`
+	tests := []struct {
+		interpret, keepRaw bool
+		field              string
+		argv               []string
+	}{
+		{false, false, "6C73002D6C002F746D7020646972", []string{"ls", "-l", "/tmp dir"}},
+		{true, false, "ls -l /tmp dir", []string{"ls", "-l", "/tmp", "dir"}},
+		{true, true, "6C73002D6C002F746D7020646972", []string{"ls", "-l", "/tmp dir"}},
+	}
+	defer SetKeepRawProctitle(false)
+	for _, tt := range tests {
+		SetKeepRawProctitle(tt.keepRaw)
+		x, err := ParseAuditEvent(msg, AUDIT_PROCTITLE, tt.interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		argv, err := x.Proctitle()
+		if err != nil {
+			t.Fatalf("Proctitle failed %v", err)
+		}
+		if x.Data["proctitle"] != tt.field || !reflect.DeepEqual(argv, tt.argv) {
+			t.Errorf("Proctitle (interpret %v, keep raw %v): expected %q %q, found %q %q", tt.interpret, tt.keepRaw, tt.field, tt.argv, x.Data["proctitle"], argv)
+		}
+	}
+	SetKeepRawProctitle(false)
+	for _, interpret := range []bool{false, true} {
+		x, err := ParseAuditEvent(`audit(1464163771.720:021): proctitle="bash"`, AUDIT_PROCTITLE, interpret)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		if argv, err := x.Proctitle(); err != nil || !reflect.DeepEqual(argv, []string{"bash"}) {
+			t.Errorf("Proctitle (interpret %v): unexpected result %q %v", interpret, argv, err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package libaudit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// ErrFeatureUnsupported is returned when the running kernel does not know about the
+// requested audit feature (or about audit features at all, kernels older than 3.13).
+var ErrFeatureUnsupported = errors.New("audit feature not supported by kernel")
+
+// auditFeatures is the c compatible struct of audit_features (audit.h).
+// It is used for querying and changing the audit features of the kernel.
+type auditFeatures struct {
+	Vers     uint32 /* AUDIT_FEATURE_VERSION */
+	Mask     uint32 /* which bits we are dealing with */
+	Features uint32 /* which features to enable/disable */
+	Lock     uint32 /* which features to lock */
+}
+
+// auditFeatureToMask returns the bitmask used for feature in auditFeatures.
+func auditFeatureToMask(feature uint32) uint32 {
+	return 1 << (feature & 31)
+}
+
+// auditGetFeatures asks the kernel for the known, enabled and locked audit features.
+// It is used as a probe for feature support, kernels without AUDIT_GET_FEATURE (which
+// refuse it with EINVAL) result in ErrFeatureUnsupported.
+func auditGetFeatures(s Netlink) (*auditFeatures, error) {
+	var features auditFeatures
+
+	wb := newNetlinkAuditRequest(uint16(AUDIT_GET_FEATURE), syscall.AF_NETLINK, 0)
+	// no ack on success, the reply is enough and a stray ack would confuse the next auditGetReply
+	wb.Header.Flags = syscall.NLM_F_REQUEST
+	if err := s.Send(wb); err != nil {
+		return nil, errors.Wrap(err, "auditGetFeatures failed")
+	}
+	data, err := auditGetReplyData(s, AUDIT_GET_FEATURE, wb.Header.Seq)
+	if cause := errors.Cause(err); cause == syscall.EINVAL || cause == errNoReply {
+		return nil, errors.Wrap(ErrFeatureUnsupported, err.Error())
+	} else if err != nil {
+		return nil, errors.Wrap(err, "auditGetFeatures failed")
+	}
+	err = binary.Read(bytes.NewBuffer(data), nativeEndian(), &features)
+	if err != nil {
+		return nil, errors.Wrap(err, "auditGetFeatures: binary read into auditFeatures failed")
+	}
+	return &features, nil
+}
+
+// AuditSetFeature enables or disables an audit feature (AUDIT_FEATURE_LOGINUID_IMMUTABLE or
+// AUDIT_FEATURE_ONLY_UNSET_LOGINUID) in the kernel. If lock is true the feature is locked
+// afterwards and cannot be changed again until reboot.
+// Kernel support for the feature is checked first, ErrFeatureUnsupported is returned if missing.
+func AuditSetFeature(s Netlink, feature uint32, enabled bool, lock bool) error {
+	if feature > AUDIT_LAST_FEATURE {
+		return errors.Wrap(ErrFeatureUnsupported, fmt.Sprintf("AuditSetFeature: unknown feature %d", feature))
+	}
+	current, err := auditGetFeatures(s)
+	if err != nil {
+		return errors.Wrap(err, "AuditSetFeature failed")
+	}
+	mask := auditFeatureToMask(feature)
+	if current.Mask&mask == 0 {
+		return errors.Wrap(ErrFeatureUnsupported, fmt.Sprintf("AuditSetFeature: feature %d", feature))
+	}
+	if current.Lock&mask != 0 && (current.Features&mask != 0) != enabled {
+		return fmt.Errorf("AuditSetFeature: feature %d is locked", feature)
+	}
+
+	features := auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: mask}
+	if enabled {
+		features.Features = mask
+	}
+	if lock {
+		features.Lock = mask
+	}
+	buff := new(bytes.Buffer)
+	err = binary.Write(buff, nativeEndian(), features)
+	if err != nil {
+		return errors.Wrap(err, "AuditSetFeature: binary write from auditFeatures failed")
+	}
+
+	wb := newNetlinkAuditRequest(uint16(AUDIT_SET_FEATURE), syscall.AF_NETLINK, int(unsafe.Sizeof(features)))
+	wb.Data = append(wb.Data, buff.Bytes()[:]...)
+	if err := s.Send(wb); err != nil {
+		return errors.Wrap(err, "AuditSetFeature failed")
+	}
+
+	err = auditGetReply(s, syscall.Getpagesize(), 0, wb.Header.Seq)
+	if err != nil {
+		return errors.Wrap(err, "AuditSetFeature failed")
+	}
+	return nil
+}
+
+// AuditFeatureState is the state of an audit feature of the kernel, see AuditGetFeature.
+type AuditFeatureState struct {
+	Enabled bool
+	Locked  bool // the feature can't be changed until reboot
+}
+
+// AuditGetFeature returns the state of an audit feature (AUDIT_FEATURE_LOGINUID_IMMUTABLE or
+// AUDIT_FEATURE_ONLY_UNSET_LOGINUID), ErrFeatureUnsupported is returned if the kernel doesn't know it.
+func AuditGetFeature(s Netlink, feature uint32) (*AuditFeatureState, error) {
+	if feature > AUDIT_LAST_FEATURE {
+		return nil, errors.Wrap(ErrFeatureUnsupported, fmt.Sprintf("AuditGetFeature: unknown feature %d", feature))
+	}
+	current, err := auditGetFeatures(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "AuditGetFeature failed")
+	}
+	mask := auditFeatureToMask(feature)
+	if current.Mask&mask == 0 {
+		return nil, errors.Wrap(ErrFeatureUnsupported, fmt.Sprintf("AuditGetFeature: feature %d", feature))
+	}
+	return &AuditFeatureState{Enabled: current.Features&mask != 0, Locked: current.Lock&mask != 0}, nil
+}
+
+// AuditGetFeatureBitmap returns the capabilities of the kernel (AUDIT_FEATURE_BITMAP_*) reported
+// in the audit status, i.e. to check for AUDIT_FEATURE_BITMAP_EXECUTABLE_PATH before loading rules
+// with exe fields. Kernels before 3.14 report no capabilities.
+func AuditGetFeatureBitmap(s Netlink) (uint32, error) {
+	status, _, err := auditGetStatus(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "AuditGetFeatureBitmap failed")
+	}
+	return status.Version, nil
+}
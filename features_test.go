@@ -0,0 +1,126 @@
+package libaudit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// testFeaturesConn emulates the kernel side of AUDIT_GET_FEATURE/AUDIT_SET_FEATURE
+func testFeaturesConn(current auditFeatures, supported bool) *testReplyNetlinkConn {
+	return &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			switch auditConstant(request.Header.Type) {
+			case AUDIT_GET_FEATURE:
+				if !supported {
+					return []NetlinkMessage{testAckMessage(request, int32(syscall.EINVAL))}
+				}
+				buff := new(bytes.Buffer)
+				binary.Write(buff, nativeEndian(), current)
+				return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET_FEATURE), buff.Bytes())}
+			}
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+}
+
+func TestAuditSetFeature(t *testing.T) {
+	known := auditFeatureToMask(AUDIT_FEATURE_ONLY_UNSET_LOGINUID) | auditFeatureToMask(AUDIT_FEATURE_LOGINUID_IMMUTABLE)
+	s := testFeaturesConn(auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: known}, true)
+	if err := AuditSetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE, true, true); err != nil {
+		t.Fatalf("AuditSetFeature failed: %v", err)
+	}
+	if len(s.sent) != 2 || s.sent[1].Header.Type != uint16(AUDIT_SET_FEATURE) {
+		t.Fatalf("AuditSetFeature: expected AUDIT_SET_FEATURE request, found %v", s.sent)
+	}
+	var sent auditFeatures
+	if err := binary.Read(bytes.NewReader(s.sent[1].Data), nativeEndian(), &sent); err != nil {
+		t.Fatalf("AuditSetFeature: unreadable request: %v", err)
+	}
+	expected := auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: 2, Features: 2, Lock: 2}
+	if sent != expected {
+		t.Errorf("AuditSetFeature: expected %+v, found %+v", expected, sent)
+	}
+
+	s = testFeaturesConn(auditFeatures{}, false)
+	err := AuditSetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE, true, false)
+	if errors.Cause(err) != ErrFeatureUnsupported {
+		t.Errorf("AuditSetFeature: expected ErrFeatureUnsupported on old kernel, found %v", err)
+	}
+	if len(s.sent) != 1 {
+		t.Errorf("AuditSetFeature: no AUDIT_SET_FEATURE expected on old kernel")
+	}
+
+	s = testFeaturesConn(auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: known, Lock: 2}, true)
+	if err := AuditSetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE, true, false); err == nil {
+		t.Errorf("AuditSetFeature: expected error for locked feature")
+	}
+}
+
+func TestAuditGetFeature(t *testing.T) {
+	known := auditFeatureToMask(AUDIT_FEATURE_ONLY_UNSET_LOGINUID) | auditFeatureToMask(AUDIT_FEATURE_LOGINUID_IMMUTABLE)
+	s := testFeaturesConn(auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: known, Features: 2, Lock: 2}, true)
+	for feature, expected := range map[uint32]AuditFeatureState{
+		AUDIT_FEATURE_ONLY_UNSET_LOGINUID: {},
+		AUDIT_FEATURE_LOGINUID_IMMUTABLE:  {Enabled: true, Locked: true},
+	} {
+		state, err := AuditGetFeature(s, feature)
+		if err != nil || *state != expected {
+			t.Errorf("AuditGetFeature(%d): expected %+v, found %+v (%v)", feature, expected, state, err)
+		}
+	}
+	if _, err := AuditGetFeature(s, AUDIT_LAST_FEATURE+1); errors.Cause(err) != ErrFeatureUnsupported {
+		t.Errorf("AuditGetFeature: expected ErrFeatureUnsupported for unknown feature, found %v", err)
+	}
+
+	s = testFeaturesConn(auditFeatures{Vers: AUDIT_FEATURE_VERSION, Mask: auditFeatureToMask(AUDIT_FEATURE_ONLY_UNSET_LOGINUID)}, true)
+	if _, err := AuditGetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE); errors.Cause(err) != ErrFeatureUnsupported {
+		t.Errorf("AuditGetFeature: expected ErrFeatureUnsupported for feature unknown to the kernel, found %v", err)
+	}
+	s = testFeaturesConn(auditFeatures{}, false)
+	if _, err := AuditGetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE); errors.Cause(err) != ErrFeatureUnsupported {
+		t.Errorf("AuditGetFeature: expected ErrFeatureUnsupported on old kernel, found %v", err)
+	}
+}
+
+func TestAuditGetFeatureBitmap(t *testing.T) {
+	bitmap := uint32(AUDIT_FEATURE_BITMAP_BACKLOG_LIMIT | AUDIT_FEATURE_BITMAP_EXECUTABLE_PATH)
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET), testStatusData(auditStatus{Version: bitmap}))}
+		},
+	}
+	found, err := AuditGetFeatureBitmap(s)
+	if err != nil || found != bitmap {
+		t.Errorf("AuditGetFeatureBitmap: expected %#x, found %#x (%v)", bitmap, found, err)
+	}
+}
+
+func TestAuditGetFeaturesErrors(t *testing.T) {
+	errno := int32(syscall.EPERM)
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, errno)}
+		},
+	}
+	// only kernels refusing AUDIT_GET_FEATURE lack the features
+	if _, err := AuditGetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE); errors.Cause(err) != ErrPermission {
+		t.Errorf("AuditGetFeature: expected ErrPermission for EPERM, found %v", err)
+	}
+	errno = int32(syscall.ENOMEM)
+	if _, err := AuditGetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE); err == nil || errors.Cause(err) == ErrFeatureUnsupported {
+		t.Errorf("AuditGetFeature: expected an error other than ErrFeatureUnsupported for ENOMEM, found %v", err)
+	}
+
+	s = &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET_FEATURE), []byte{1, 0})}
+		},
+	}
+	if _, err := AuditGetFeature(s, AUDIT_FEATURE_LOGINUID_IMMUTABLE); err == nil || errors.Cause(err) == ErrFeatureUnsupported {
+		t.Errorf("AuditGetFeature: expected an error other than ErrFeatureUnsupported for a short reply, found %v", err)
+	}
+}
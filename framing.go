@@ -0,0 +1,36 @@
+package libaudit
+
+// Low-level netlink framing helpers.
+// They are meant for consumers of raw netlink buffers (ReceiveNoParse) that do their own parsing.
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// NlmsgAlign rounds the length of a netlink message up to the netlink alignment (NLMSG_ALIGNTO),
+// messages in a buffer start at aligned offsets.
+func NlmsgAlign(length int) int {
+	return nlmAlignOf(length)
+}
+
+// ParseNetlinkMessages splits a buffer received from a netlink socket into its messages.
+// The Data of the messages refers to b, it is not copied. The last message doesn't need to be padded, a
+// trailing part of b that is too short for a netlink header is ignored. A message whose length doesn't
+// fit into the buffer (i.e. truncated by a too small receive buffer) is an error, the messages before it
+// are returned along with the error.
+// Unlike ParseAuditNetlinkMessage the header length is taken as is, older kernels sent audit records with
+// a length missing the header size which ParseAuditNetlinkMessage works around.
+func ParseNetlinkMessages(b []byte) ([]NetlinkMessage, error) {
+	var msgs []NetlinkMessage
+	for offset := 0; len(b)-offset >= syscall.NLMSG_HDRLEN; {
+		h := (*syscall.NlMsghdr)(unsafe.Pointer(&b[offset]))
+		if int(h.Len) < syscall.NLMSG_HDRLEN || int(h.Len) > len(b)-offset {
+			return msgs, fmt.Errorf("ParseNetlinkMessages: message at offset %d has length %d, %d bytes left", offset, h.Len, len(b)-offset)
+		}
+		msgs = append(msgs, NetlinkMessage{Header: *h, Data: b[offset+syscall.NLMSG_HDRLEN : offset+int(h.Len)]})
+		offset += NlmsgAlign(int(h.Len))
+	}
+	return msgs, nil
+}
@@ -0,0 +1,59 @@
+package libaudit
+
+import (
+	"bytes"
+	"syscall"
+	"testing"
+)
+
+func TestNlmsgAlign(t *testing.T) {
+	for length, expected := range map[int]int{0: 0, 1: 4, 4: 4, 17: 20, 20: 20, 23: 24} {
+		if aligned := NlmsgAlign(length); aligned != expected {
+			t.Errorf("NlmsgAlign: expected %d for %d, found %d", expected, length, aligned)
+		}
+	}
+}
+
+func TestParseNetlinkMessages(t *testing.T) {
+	var (
+		first  = testReplyMessage(NetlinkMessage{}, uint16(AUDIT_GET), []byte("abcde"))
+		second = testReplyMessage(NetlinkMessage{}, uint16(AUDIT_CONFIG_CHANGE), []byte("op=add_rule"))
+		b      []byte
+	)
+	// the first message is padded, the last one isn't
+	b = append(b, first.ToWireFormat(nil)...)
+	b = append(b, make([]byte, NlmsgAlign(len(b))-len(b))...)
+	b = append(b, second.ToWireFormat(nil)...)
+
+	msgs, err := ParseNetlinkMessages(b)
+	if err != nil {
+		t.Fatalf("ParseNetlinkMessages failed %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("ParseNetlinkMessages: expected 2 messages, found %d", len(msgs))
+	}
+	for i, expected := range []NetlinkMessage{first, second} {
+		if msgs[i].Header != expected.Header || !bytes.Equal(msgs[i].Data, expected.Data) {
+			t.Errorf("ParseNetlinkMessages: expected %+v, found %+v", expected, msgs[i])
+		}
+	}
+
+	// trailing bytes too short for a header are ignored
+	if msgs, err := ParseNetlinkMessages(append(b, 0, 0, 0)); err != nil || len(msgs) != 2 {
+		t.Errorf("ParseNetlinkMessages: expected 2 messages, found %d %v", len(msgs), err)
+	}
+	// a truncated message is an error, the messages before it are returned
+	msgs, err = ParseNetlinkMessages(b[:len(b)-3])
+	if err == nil || len(msgs) != 1 || msgs[0].Header.Type != uint16(AUDIT_GET) {
+		t.Errorf("ParseNetlinkMessages: expected the first message and an error, found %v %v", msgs, err)
+	}
+	// so is a length shorter than the header
+	short := make([]byte, syscall.NLMSG_HDRLEN)
+	short[0] = 4
+	if _, err := ParseNetlinkMessages(short); err == nil {
+		t.Errorf("ParseNetlinkMessages: expected error for a length shorter than the header")
+	}
+	if msgs, err := ParseNetlinkMessages(nil); err != nil || len(msgs) != 0 {
+		t.Errorf("ParseNetlinkMessages: expected no messages, found %v %v", msgs, err)
+	}
+}
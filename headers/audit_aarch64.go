@@ -0,0 +1,666 @@
+package headers
+
+// SysMapAarch64 is a mapping between aarch64 syscall names and their integer values
+// The list is taken from the asm-generic unistd.h of the kernel used by arm64
+func SysMapAarch64(syscall string) (name int) {
+	switch syscall {
+	case "io_setup":
+		return 0
+	case "io_destroy":
+		return 1
+	case "io_submit":
+		return 2
+	case "io_cancel":
+		return 3
+	case "io_getevents":
+		return 4
+	case "setxattr":
+		return 5
+	case "lsetxattr":
+		return 6
+	case "fsetxattr":
+		return 7
+	case "getxattr":
+		return 8
+	case "lgetxattr":
+		return 9
+	case "fgetxattr":
+		return 10
+	case "listxattr":
+		return 11
+	case "llistxattr":
+		return 12
+	case "flistxattr":
+		return 13
+	case "removexattr":
+		return 14
+	case "lremovexattr":
+		return 15
+	case "fremovexattr":
+		return 16
+	case "getcwd":
+		return 17
+	case "lookup_dcookie":
+		return 18
+	case "eventfd2":
+		return 19
+	case "epoll_create1":
+		return 20
+	case "epoll_ctl":
+		return 21
+	case "epoll_pwait":
+		return 22
+	case "dup":
+		return 23
+	case "dup3":
+		return 24
+	case "fcntl":
+		return 25
+	case "inotify_init1":
+		return 26
+	case "inotify_add_watch":
+		return 27
+	case "inotify_rm_watch":
+		return 28
+	case "ioctl":
+		return 29
+	case "ioprio_set":
+		return 30
+	case "ioprio_get":
+		return 31
+	case "flock":
+		return 32
+	case "mknodat":
+		return 33
+	case "mkdirat":
+		return 34
+	case "unlinkat":
+		return 35
+	case "symlinkat":
+		return 36
+	case "linkat":
+		return 37
+	case "renameat":
+		return 38
+	case "umount2":
+		return 39
+	case "mount":
+		return 40
+	case "pivot_root":
+		return 41
+	case "nfsservctl":
+		return 42
+	case "statfs":
+		return 43
+	case "fstatfs":
+		return 44
+	case "truncate":
+		return 45
+	case "ftruncate":
+		return 46
+	case "fallocate":
+		return 47
+	case "faccessat":
+		return 48
+	case "chdir":
+		return 49
+	case "fchdir":
+		return 50
+	case "chroot":
+		return 51
+	case "fchmod":
+		return 52
+	case "fchmodat":
+		return 53
+	case "fchownat":
+		return 54
+	case "fchown":
+		return 55
+	case "openat":
+		return 56
+	case "close":
+		return 57
+	case "vhangup":
+		return 58
+	case "pipe2":
+		return 59
+	case "quotactl":
+		return 60
+	case "getdents64":
+		return 61
+	case "lseek":
+		return 62
+	case "read":
+		return 63
+	case "write":
+		return 64
+	case "readv":
+		return 65
+	case "writev":
+		return 66
+	case "pread64":
+		return 67
+	case "pwrite64":
+		return 68
+	case "preadv":
+		return 69
+	case "pwritev":
+		return 70
+	case "sendfile":
+		return 71
+	case "pselect6":
+		return 72
+	case "ppoll":
+		return 73
+	case "signalfd4":
+		return 74
+	case "vmsplice":
+		return 75
+	case "splice":
+		return 76
+	case "tee":
+		return 77
+	case "readlinkat":
+		return 78
+	case "newfstatat":
+		return 79
+	case "fstat":
+		return 80
+	case "sync":
+		return 81
+	case "fsync":
+		return 82
+	case "fdatasync":
+		return 83
+	case "sync_file_range":
+		return 84
+	case "timerfd_create":
+		return 85
+	case "timerfd_settime":
+		return 86
+	case "timerfd_gettime":
+		return 87
+	case "utimensat":
+		return 88
+	case "acct":
+		return 89
+	case "capget":
+		return 90
+	case "capset":
+		return 91
+	case "personality":
+		return 92
+	case "exit":
+		return 93
+	case "exit_group":
+		return 94
+	case "waitid":
+		return 95
+	case "set_tid_address":
+		return 96
+	case "unshare":
+		return 97
+	case "futex":
+		return 98
+	case "set_robust_list":
+		return 99
+	case "get_robust_list":
+		return 100
+	case "nanosleep":
+		return 101
+	case "getitimer":
+		return 102
+	case "setitimer":
+		return 103
+	case "kexec_load":
+		return 104
+	case "init_module":
+		return 105
+	case "delete_module":
+		return 106
+	case "timer_create":
+		return 107
+	case "timer_gettime":
+		return 108
+	case "timer_getoverrun":
+		return 109
+	case "timer_settime":
+		return 110
+	case "timer_delete":
+		return 111
+	case "clock_settime":
+		return 112
+	case "clock_gettime":
+		return 113
+	case "clock_getres":
+		return 114
+	case "clock_nanosleep":
+		return 115
+	case "syslog":
+		return 116
+	case "ptrace":
+		return 117
+	case "sched_setparam":
+		return 118
+	case "sched_setscheduler":
+		return 119
+	case "sched_getscheduler":
+		return 120
+	case "sched_getparam":
+		return 121
+	case "sched_setaffinity":
+		return 122
+	case "sched_getaffinity":
+		return 123
+	case "sched_yield":
+		return 124
+	case "sched_get_priority_max":
+		return 125
+	case "sched_get_priority_min":
+		return 126
+	case "sched_rr_get_interval":
+		return 127
+	case "restart_syscall":
+		return 128
+	case "kill":
+		return 129
+	case "tkill":
+		return 130
+	case "tgkill":
+		return 131
+	case "sigaltstack":
+		return 132
+	case "rt_sigsuspend":
+		return 133
+	case "rt_sigaction":
+		return 134
+	case "rt_sigprocmask":
+		return 135
+	case "rt_sigpending":
+		return 136
+	case "rt_sigtimedwait":
+		return 137
+	case "rt_sigqueueinfo":
+		return 138
+	case "rt_sigreturn":
+		return 139
+	case "setpriority":
+		return 140
+	case "getpriority":
+		return 141
+	case "reboot":
+		return 142
+	case "setregid":
+		return 143
+	case "setgid":
+		return 144
+	case "setreuid":
+		return 145
+	case "setuid":
+		return 146
+	case "setresuid":
+		return 147
+	case "getresuid":
+		return 148
+	case "setresgid":
+		return 149
+	case "getresgid":
+		return 150
+	case "setfsuid":
+		return 151
+	case "setfsgid":
+		return 152
+	case "times":
+		return 153
+	case "setpgid":
+		return 154
+	case "getpgid":
+		return 155
+	case "getsid":
+		return 156
+	case "setsid":
+		return 157
+	case "getgroups":
+		return 158
+	case "setgroups":
+		return 159
+	case "uname":
+		return 160
+	case "sethostname":
+		return 161
+	case "setdomainname":
+		return 162
+	case "getrlimit":
+		return 163
+	case "setrlimit":
+		return 164
+	case "getrusage":
+		return 165
+	case "umask":
+		return 166
+	case "prctl":
+		return 167
+	case "getcpu":
+		return 168
+	case "gettimeofday":
+		return 169
+	case "settimeofday":
+		return 170
+	case "adjtimex":
+		return 171
+	case "getpid":
+		return 172
+	case "getppid":
+		return 173
+	case "getuid":
+		return 174
+	case "geteuid":
+		return 175
+	case "getgid":
+		return 176
+	case "getegid":
+		return 177
+	case "gettid":
+		return 178
+	case "sysinfo":
+		return 179
+	case "mq_open":
+		return 180
+	case "mq_unlink":
+		return 181
+	case "mq_timedsend":
+		return 182
+	case "mq_timedreceive":
+		return 183
+	case "mq_notify":
+		return 184
+	case "mq_getsetattr":
+		return 185
+	case "msgget":
+		return 186
+	case "msgctl":
+		return 187
+	case "msgrcv":
+		return 188
+	case "msgsnd":
+		return 189
+	case "semget":
+		return 190
+	case "semctl":
+		return 191
+	case "semtimedop":
+		return 192
+	case "semop":
+		return 193
+	case "shmget":
+		return 194
+	case "shmctl":
+		return 195
+	case "shmat":
+		return 196
+	case "shmdt":
+		return 197
+	case "socket":
+		return 198
+	case "socketpair":
+		return 199
+	case "bind":
+		return 200
+	case "listen":
+		return 201
+	case "accept":
+		return 202
+	case "connect":
+		return 203
+	case "getsockname":
+		return 204
+	case "getpeername":
+		return 205
+	case "sendto":
+		return 206
+	case "recvfrom":
+		return 207
+	case "setsockopt":
+		return 208
+	case "getsockopt":
+		return 209
+	case "shutdown":
+		return 210
+	case "sendmsg":
+		return 211
+	case "recvmsg":
+		return 212
+	case "readahead":
+		return 213
+	case "brk":
+		return 214
+	case "munmap":
+		return 215
+	case "mremap":
+		return 216
+	case "add_key":
+		return 217
+	case "request_key":
+		return 218
+	case "keyctl":
+		return 219
+	case "clone":
+		return 220
+	case "execve":
+		return 221
+	case "mmap":
+		return 222
+	case "fadvise64":
+		return 223
+	case "swapon":
+		return 224
+	case "swapoff":
+		return 225
+	case "mprotect":
+		return 226
+	case "msync":
+		return 227
+	case "mlock":
+		return 228
+	case "munlock":
+		return 229
+	case "mlockall":
+		return 230
+	case "munlockall":
+		return 231
+	case "mincore":
+		return 232
+	case "madvise":
+		return 233
+	case "remap_file_pages":
+		return 234
+	case "mbind":
+		return 235
+	case "get_mempolicy":
+		return 236
+	case "set_mempolicy":
+		return 237
+	case "migrate_pages":
+		return 238
+	case "move_pages":
+		return 239
+	case "rt_tgsigqueueinfo":
+		return 240
+	case "perf_event_open":
+		return 241
+	case "accept4":
+		return 242
+	case "recvmmsg":
+		return 243
+	case "arch_specific_syscall":
+		return 244
+	case "wait4":
+		return 260
+	case "prlimit64":
+		return 261
+	case "fanotify_init":
+		return 262
+	case "fanotify_mark":
+		return 263
+	case "name_to_handle_at":
+		return 264
+	case "open_by_handle_at":
+		return 265
+	case "clock_adjtime":
+		return 266
+	case "syncfs":
+		return 267
+	case "setns":
+		return 268
+	case "sendmmsg":
+		return 269
+	case "process_vm_readv":
+		return 270
+	case "process_vm_writev":
+		return 271
+	case "kcmp":
+		return 272
+	case "finit_module":
+		return 273
+	case "sched_setattr":
+		return 274
+	case "sched_getattr":
+		return 275
+	case "renameat2":
+		return 276
+	case "seccomp":
+		return 277
+	case "getrandom":
+		return 278
+	case "memfd_create":
+		return 279
+	case "bpf":
+		return 280
+	case "execveat":
+		return 281
+	case "userfaultfd":
+		return 282
+	case "membarrier":
+		return 283
+	case "mlock2":
+		return 284
+	case "copy_file_range":
+		return 285
+	case "preadv2":
+		return 286
+	case "pwritev2":
+		return 287
+	case "pkey_mprotect":
+		return 288
+	case "pkey_alloc":
+		return 289
+	case "pkey_free":
+		return 290
+	case "statx":
+		return 291
+	case "io_pgetevents":
+		return 292
+	case "rseq":
+		return 293
+	case "kexec_file_load":
+		return 294
+	case "pidfd_send_signal":
+		return 424
+	case "io_uring_setup":
+		return 425
+	case "io_uring_enter":
+		return 426
+	case "io_uring_register":
+		return 427
+	case "open_tree":
+		return 428
+	case "move_mount":
+		return 429
+	case "fsopen":
+		return 430
+	case "fsconfig":
+		return 431
+	case "fsmount":
+		return 432
+	case "fspick":
+		return 433
+	case "pidfd_open":
+		return 434
+	case "clone3":
+		return 435
+	case "close_range":
+		return 436
+	case "openat2":
+		return 437
+	case "pidfd_getfd":
+		return 438
+	case "faccessat2":
+		return 439
+	case "process_madvise":
+		return 440
+	case "epoll_pwait2":
+		return 441
+	case "mount_setattr":
+		return 442
+	case "quotactl_fd":
+		return 443
+	case "landlock_create_ruleset":
+		return 444
+	case "landlock_add_rule":
+		return 445
+	case "landlock_restrict_self":
+		return 446
+	case "memfd_secret":
+		return 447
+	case "process_mrelease":
+		return 448
+	case "futex_waitv":
+		return 449
+	case "set_mempolicy_home_node":
+		return 450
+	case "cachestat":
+		return 451
+	case "fchmodat2":
+		return 452
+	case "map_shadow_stack":
+		return 453
+	case "futex_wake":
+		return 454
+	case "futex_wait":
+		return 455
+	case "futex_requeue":
+		return 456
+	case "statmount":
+		return 457
+	case "listmount":
+		return 458
+	case "lsm_get_self_attr":
+		return 459
+	case "lsm_set_self_attr":
+		return 460
+	case "lsm_list_modules":
+		return 461
+	case "mseal":
+		return 462
+	case "setxattrat":
+		return 463
+	case "getxattrat":
+		return 464
+	case "listxattrat":
+		return 465
+	case "removexattrat":
+		return 466
+	case "open_tree_attr":
+		return 467
+	case "file_getattr":
+		return 468
+	case "file_setattr":
+		return 469
+	case "listns":
+		return 470
+	case "rseq_slice_yield":
+		return 471
+	default:
+		return -1
+	}
+}
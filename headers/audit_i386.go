@@ -0,0 +1,890 @@
+package headers
+
+// SysMapI386 is a mapping between i386 syscall names and their integer values
+// The list is taken from the i386 unistd_32.h of the kernel
+func SysMapI386(syscall string) (name int) {
+	switch syscall {
+	case "restart_syscall":
+		return 0
+	case "exit":
+		return 1
+	case "fork":
+		return 2
+	case "read":
+		return 3
+	case "write":
+		return 4
+	case "open":
+		return 5
+	case "close":
+		return 6
+	case "waitpid":
+		return 7
+	case "creat":
+		return 8
+	case "link":
+		return 9
+	case "unlink":
+		return 10
+	case "execve":
+		return 11
+	case "chdir":
+		return 12
+	case "time":
+		return 13
+	case "mknod":
+		return 14
+	case "chmod":
+		return 15
+	case "lchown":
+		return 16
+	case "break":
+		return 17
+	case "oldstat":
+		return 18
+	case "lseek":
+		return 19
+	case "getpid":
+		return 20
+	case "mount":
+		return 21
+	case "umount":
+		return 22
+	case "setuid":
+		return 23
+	case "getuid":
+		return 24
+	case "stime":
+		return 25
+	case "ptrace":
+		return 26
+	case "alarm":
+		return 27
+	case "oldfstat":
+		return 28
+	case "pause":
+		return 29
+	case "utime":
+		return 30
+	case "stty":
+		return 31
+	case "gtty":
+		return 32
+	case "access":
+		return 33
+	case "nice":
+		return 34
+	case "ftime":
+		return 35
+	case "sync":
+		return 36
+	case "kill":
+		return 37
+	case "rename":
+		return 38
+	case "mkdir":
+		return 39
+	case "rmdir":
+		return 40
+	case "dup":
+		return 41
+	case "pipe":
+		return 42
+	case "times":
+		return 43
+	case "prof":
+		return 44
+	case "brk":
+		return 45
+	case "setgid":
+		return 46
+	case "getgid":
+		return 47
+	case "signal":
+		return 48
+	case "geteuid":
+		return 49
+	case "getegid":
+		return 50
+	case "acct":
+		return 51
+	case "umount2":
+		return 52
+	case "lock":
+		return 53
+	case "ioctl":
+		return 54
+	case "fcntl":
+		return 55
+	case "mpx":
+		return 56
+	case "setpgid":
+		return 57
+	case "ulimit":
+		return 58
+	case "oldolduname":
+		return 59
+	case "umask":
+		return 60
+	case "chroot":
+		return 61
+	case "ustat":
+		return 62
+	case "dup2":
+		return 63
+	case "getppid":
+		return 64
+	case "getpgrp":
+		return 65
+	case "setsid":
+		return 66
+	case "sigaction":
+		return 67
+	case "sgetmask":
+		return 68
+	case "ssetmask":
+		return 69
+	case "setreuid":
+		return 70
+	case "setregid":
+		return 71
+	case "sigsuspend":
+		return 72
+	case "sigpending":
+		return 73
+	case "sethostname":
+		return 74
+	case "setrlimit":
+		return 75
+	case "getrlimit":
+		return 76
+	case "getrusage":
+		return 77
+	case "gettimeofday":
+		return 78
+	case "settimeofday":
+		return 79
+	case "getgroups":
+		return 80
+	case "setgroups":
+		return 81
+	case "select":
+		return 82
+	case "symlink":
+		return 83
+	case "oldlstat":
+		return 84
+	case "readlink":
+		return 85
+	case "uselib":
+		return 86
+	case "swapon":
+		return 87
+	case "reboot":
+		return 88
+	case "readdir":
+		return 89
+	case "mmap":
+		return 90
+	case "munmap":
+		return 91
+	case "truncate":
+		return 92
+	case "ftruncate":
+		return 93
+	case "fchmod":
+		return 94
+	case "fchown":
+		return 95
+	case "getpriority":
+		return 96
+	case "setpriority":
+		return 97
+	case "profil":
+		return 98
+	case "statfs":
+		return 99
+	case "fstatfs":
+		return 100
+	case "ioperm":
+		return 101
+	case "socketcall":
+		return 102
+	case "syslog":
+		return 103
+	case "setitimer":
+		return 104
+	case "getitimer":
+		return 105
+	case "stat":
+		return 106
+	case "lstat":
+		return 107
+	case "fstat":
+		return 108
+	case "olduname":
+		return 109
+	case "iopl":
+		return 110
+	case "vhangup":
+		return 111
+	case "idle":
+		return 112
+	case "vm86old":
+		return 113
+	case "wait4":
+		return 114
+	case "swapoff":
+		return 115
+	case "sysinfo":
+		return 116
+	case "ipc":
+		return 117
+	case "fsync":
+		return 118
+	case "sigreturn":
+		return 119
+	case "clone":
+		return 120
+	case "setdomainname":
+		return 121
+	case "uname":
+		return 122
+	case "modify_ldt":
+		return 123
+	case "adjtimex":
+		return 124
+	case "mprotect":
+		return 125
+	case "sigprocmask":
+		return 126
+	case "create_module":
+		return 127
+	case "init_module":
+		return 128
+	case "delete_module":
+		return 129
+	case "get_kernel_syms":
+		return 130
+	case "quotactl":
+		return 131
+	case "getpgid":
+		return 132
+	case "fchdir":
+		return 133
+	case "bdflush":
+		return 134
+	case "sysfs":
+		return 135
+	case "personality":
+		return 136
+	case "afs_syscall":
+		return 137
+	case "setfsuid":
+		return 138
+	case "setfsgid":
+		return 139
+	case "_llseek":
+		return 140
+	case "getdents":
+		return 141
+	case "_newselect":
+		return 142
+	case "flock":
+		return 143
+	case "msync":
+		return 144
+	case "readv":
+		return 145
+	case "writev":
+		return 146
+	case "getsid":
+		return 147
+	case "fdatasync":
+		return 148
+	case "_sysctl":
+		return 149
+	case "mlock":
+		return 150
+	case "munlock":
+		return 151
+	case "mlockall":
+		return 152
+	case "munlockall":
+		return 153
+	case "sched_setparam":
+		return 154
+	case "sched_getparam":
+		return 155
+	case "sched_setscheduler":
+		return 156
+	case "sched_getscheduler":
+		return 157
+	case "sched_yield":
+		return 158
+	case "sched_get_priority_max":
+		return 159
+	case "sched_get_priority_min":
+		return 160
+	case "sched_rr_get_interval":
+		return 161
+	case "nanosleep":
+		return 162
+	case "mremap":
+		return 163
+	case "setresuid":
+		return 164
+	case "getresuid":
+		return 165
+	case "vm86":
+		return 166
+	case "query_module":
+		return 167
+	case "poll":
+		return 168
+	case "nfsservctl":
+		return 169
+	case "setresgid":
+		return 170
+	case "getresgid":
+		return 171
+	case "prctl":
+		return 172
+	case "rt_sigreturn":
+		return 173
+	case "rt_sigaction":
+		return 174
+	case "rt_sigprocmask":
+		return 175
+	case "rt_sigpending":
+		return 176
+	case "rt_sigtimedwait":
+		return 177
+	case "rt_sigqueueinfo":
+		return 178
+	case "rt_sigsuspend":
+		return 179
+	case "pread64":
+		return 180
+	case "pwrite64":
+		return 181
+	case "chown":
+		return 182
+	case "getcwd":
+		return 183
+	case "capget":
+		return 184
+	case "capset":
+		return 185
+	case "sigaltstack":
+		return 186
+	case "sendfile":
+		return 187
+	case "getpmsg":
+		return 188
+	case "putpmsg":
+		return 189
+	case "vfork":
+		return 190
+	case "ugetrlimit":
+		return 191
+	case "mmap2":
+		return 192
+	case "truncate64":
+		return 193
+	case "ftruncate64":
+		return 194
+	case "stat64":
+		return 195
+	case "lstat64":
+		return 196
+	case "fstat64":
+		return 197
+	case "lchown32":
+		return 198
+	case "getuid32":
+		return 199
+	case "getgid32":
+		return 200
+	case "geteuid32":
+		return 201
+	case "getegid32":
+		return 202
+	case "setreuid32":
+		return 203
+	case "setregid32":
+		return 204
+	case "getgroups32":
+		return 205
+	case "setgroups32":
+		return 206
+	case "fchown32":
+		return 207
+	case "setresuid32":
+		return 208
+	case "getresuid32":
+		return 209
+	case "setresgid32":
+		return 210
+	case "getresgid32":
+		return 211
+	case "chown32":
+		return 212
+	case "setuid32":
+		return 213
+	case "setgid32":
+		return 214
+	case "setfsuid32":
+		return 215
+	case "setfsgid32":
+		return 216
+	case "pivot_root":
+		return 217
+	case "mincore":
+		return 218
+	case "madvise":
+		return 219
+	case "getdents64":
+		return 220
+	case "fcntl64":
+		return 221
+	case "gettid":
+		return 224
+	case "readahead":
+		return 225
+	case "setxattr":
+		return 226
+	case "lsetxattr":
+		return 227
+	case "fsetxattr":
+		return 228
+	case "getxattr":
+		return 229
+	case "lgetxattr":
+		return 230
+	case "fgetxattr":
+		return 231
+	case "listxattr":
+		return 232
+	case "llistxattr":
+		return 233
+	case "flistxattr":
+		return 234
+	case "removexattr":
+		return 235
+	case "lremovexattr":
+		return 236
+	case "fremovexattr":
+		return 237
+	case "tkill":
+		return 238
+	case "sendfile64":
+		return 239
+	case "futex":
+		return 240
+	case "sched_setaffinity":
+		return 241
+	case "sched_getaffinity":
+		return 242
+	case "set_thread_area":
+		return 243
+	case "get_thread_area":
+		return 244
+	case "io_setup":
+		return 245
+	case "io_destroy":
+		return 246
+	case "io_getevents":
+		return 247
+	case "io_submit":
+		return 248
+	case "io_cancel":
+		return 249
+	case "fadvise64":
+		return 250
+	case "exit_group":
+		return 252
+	case "lookup_dcookie":
+		return 253
+	case "epoll_create":
+		return 254
+	case "epoll_ctl":
+		return 255
+	case "epoll_wait":
+		return 256
+	case "remap_file_pages":
+		return 257
+	case "set_tid_address":
+		return 258
+	case "timer_create":
+		return 259
+	case "timer_settime":
+		return 260
+	case "timer_gettime":
+		return 261
+	case "timer_getoverrun":
+		return 262
+	case "timer_delete":
+		return 263
+	case "clock_settime":
+		return 264
+	case "clock_gettime":
+		return 265
+	case "clock_getres":
+		return 266
+	case "clock_nanosleep":
+		return 267
+	case "statfs64":
+		return 268
+	case "fstatfs64":
+		return 269
+	case "tgkill":
+		return 270
+	case "utimes":
+		return 271
+	case "fadvise64_64":
+		return 272
+	case "vserver":
+		return 273
+	case "mbind":
+		return 274
+	case "get_mempolicy":
+		return 275
+	case "set_mempolicy":
+		return 276
+	case "mq_open":
+		return 277
+	case "mq_unlink":
+		return 278
+	case "mq_timedsend":
+		return 279
+	case "mq_timedreceive":
+		return 280
+	case "mq_notify":
+		return 281
+	case "mq_getsetattr":
+		return 282
+	case "kexec_load":
+		return 283
+	case "waitid":
+		return 284
+	case "add_key":
+		return 286
+	case "request_key":
+		return 287
+	case "keyctl":
+		return 288
+	case "ioprio_set":
+		return 289
+	case "ioprio_get":
+		return 290
+	case "inotify_init":
+		return 291
+	case "inotify_add_watch":
+		return 292
+	case "inotify_rm_watch":
+		return 293
+	case "migrate_pages":
+		return 294
+	case "openat":
+		return 295
+	case "mkdirat":
+		return 296
+	case "mknodat":
+		return 297
+	case "fchownat":
+		return 298
+	case "futimesat":
+		return 299
+	case "fstatat64":
+		return 300
+	case "unlinkat":
+		return 301
+	case "renameat":
+		return 302
+	case "linkat":
+		return 303
+	case "symlinkat":
+		return 304
+	case "readlinkat":
+		return 305
+	case "fchmodat":
+		return 306
+	case "faccessat":
+		return 307
+	case "pselect6":
+		return 308
+	case "ppoll":
+		return 309
+	case "unshare":
+		return 310
+	case "set_robust_list":
+		return 311
+	case "get_robust_list":
+		return 312
+	case "splice":
+		return 313
+	case "sync_file_range":
+		return 314
+	case "tee":
+		return 315
+	case "vmsplice":
+		return 316
+	case "move_pages":
+		return 317
+	case "getcpu":
+		return 318
+	case "epoll_pwait":
+		return 319
+	case "utimensat":
+		return 320
+	case "signalfd":
+		return 321
+	case "timerfd_create":
+		return 322
+	case "eventfd":
+		return 323
+	case "fallocate":
+		return 324
+	case "timerfd_settime":
+		return 325
+	case "timerfd_gettime":
+		return 326
+	case "signalfd4":
+		return 327
+	case "eventfd2":
+		return 328
+	case "epoll_create1":
+		return 329
+	case "dup3":
+		return 330
+	case "pipe2":
+		return 331
+	case "inotify_init1":
+		return 332
+	case "preadv":
+		return 333
+	case "pwritev":
+		return 334
+	case "rt_tgsigqueueinfo":
+		return 335
+	case "perf_event_open":
+		return 336
+	case "recvmmsg":
+		return 337
+	case "fanotify_init":
+		return 338
+	case "fanotify_mark":
+		return 339
+	case "prlimit64":
+		return 340
+	case "name_to_handle_at":
+		return 341
+	case "open_by_handle_at":
+		return 342
+	case "clock_adjtime":
+		return 343
+	case "syncfs":
+		return 344
+	case "sendmmsg":
+		return 345
+	case "setns":
+		return 346
+	case "process_vm_readv":
+		return 347
+	case "process_vm_writev":
+		return 348
+	case "kcmp":
+		return 349
+	case "finit_module":
+		return 350
+	case "sched_setattr":
+		return 351
+	case "sched_getattr":
+		return 352
+	case "renameat2":
+		return 353
+	case "seccomp":
+		return 354
+	case "getrandom":
+		return 355
+	case "memfd_create":
+		return 356
+	case "bpf":
+		return 357
+	case "execveat":
+		return 358
+	case "socket":
+		return 359
+	case "socketpair":
+		return 360
+	case "bind":
+		return 361
+	case "connect":
+		return 362
+	case "listen":
+		return 363
+	case "accept4":
+		return 364
+	case "getsockopt":
+		return 365
+	case "setsockopt":
+		return 366
+	case "getsockname":
+		return 367
+	case "getpeername":
+		return 368
+	case "sendto":
+		return 369
+	case "sendmsg":
+		return 370
+	case "recvfrom":
+		return 371
+	case "recvmsg":
+		return 372
+	case "shutdown":
+		return 373
+	case "userfaultfd":
+		return 374
+	case "membarrier":
+		return 375
+	case "mlock2":
+		return 376
+	case "copy_file_range":
+		return 377
+	case "preadv2":
+		return 378
+	case "pwritev2":
+		return 379
+	case "pkey_mprotect":
+		return 380
+	case "pkey_alloc":
+		return 381
+	case "pkey_free":
+		return 382
+	case "statx":
+		return 383
+	case "arch_prctl":
+		return 384
+	case "io_pgetevents":
+		return 385
+	case "rseq":
+		return 386
+	case "semget":
+		return 393
+	case "semctl":
+		return 394
+	case "shmget":
+		return 395
+	case "shmctl":
+		return 396
+	case "shmat":
+		return 397
+	case "shmdt":
+		return 398
+	case "msgget":
+		return 399
+	case "msgsnd":
+		return 400
+	case "msgrcv":
+		return 401
+	case "msgctl":
+		return 402
+	case "clock_gettime64":
+		return 403
+	case "clock_settime64":
+		return 404
+	case "clock_adjtime64":
+		return 405
+	case "clock_getres_time64":
+		return 406
+	case "clock_nanosleep_time64":
+		return 407
+	case "timer_gettime64":
+		return 408
+	case "timer_settime64":
+		return 409
+	case "timerfd_gettime64":
+		return 410
+	case "timerfd_settime64":
+		return 411
+	case "utimensat_time64":
+		return 412
+	case "pselect6_time64":
+		return 413
+	case "ppoll_time64":
+		return 414
+	case "io_pgetevents_time64":
+		return 416
+	case "recvmmsg_time64":
+		return 417
+	case "mq_timedsend_time64":
+		return 418
+	case "mq_timedreceive_time64":
+		return 419
+	case "semtimedop_time64":
+		return 420
+	case "rt_sigtimedwait_time64":
+		return 421
+	case "futex_time64":
+		return 422
+	case "sched_rr_get_interval_time64":
+		return 423
+	case "pidfd_send_signal":
+		return 424
+	case "io_uring_setup":
+		return 425
+	case "io_uring_enter":
+		return 426
+	case "io_uring_register":
+		return 427
+	case "open_tree":
+		return 428
+	case "move_mount":
+		return 429
+	case "fsopen":
+		return 430
+	case "fsconfig":
+		return 431
+	case "fsmount":
+		return 432
+	case "fspick":
+		return 433
+	case "pidfd_open":
+		return 434
+	case "clone3":
+		return 435
+	case "close_range":
+		return 436
+	case "openat2":
+		return 437
+	case "pidfd_getfd":
+		return 438
+	case "faccessat2":
+		return 439
+	case "process_madvise":
+		return 440
+	case "epoll_pwait2":
+		return 441
+	case "mount_setattr":
+		return 442
+	case "quotactl_fd":
+		return 443
+	case "landlock_create_ruleset":
+		return 444
+	case "landlock_add_rule":
+		return 445
+	case "landlock_restrict_self":
+		return 446
+	case "memfd_secret":
+		return 447
+	case "process_mrelease":
+		return 448
+	case "futex_waitv":
+		return 449
+	case "set_mempolicy_home_node":
+		return 450
+	default:
+		return -1
+	}
+}
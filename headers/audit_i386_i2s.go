@@ -0,0 +1,890 @@
+package headers
+
+// ReverseSysMapI386 is a mapping between i386 syscall integer values and their names
+// The list is taken from the i386 unistd_32.h of the kernel
+func ReverseSysMapI386(syscall string) (name string) {
+	switch syscall {
+	case "0":
+		return "restart_syscall"
+	case "1":
+		return "exit"
+	case "2":
+		return "fork"
+	case "3":
+		return "read"
+	case "4":
+		return "write"
+	case "5":
+		return "open"
+	case "6":
+		return "close"
+	case "7":
+		return "waitpid"
+	case "8":
+		return "creat"
+	case "9":
+		return "link"
+	case "10":
+		return "unlink"
+	case "11":
+		return "execve"
+	case "12":
+		return "chdir"
+	case "13":
+		return "time"
+	case "14":
+		return "mknod"
+	case "15":
+		return "chmod"
+	case "16":
+		return "lchown"
+	case "17":
+		return "break"
+	case "18":
+		return "oldstat"
+	case "19":
+		return "lseek"
+	case "20":
+		return "getpid"
+	case "21":
+		return "mount"
+	case "22":
+		return "umount"
+	case "23":
+		return "setuid"
+	case "24":
+		return "getuid"
+	case "25":
+		return "stime"
+	case "26":
+		return "ptrace"
+	case "27":
+		return "alarm"
+	case "28":
+		return "oldfstat"
+	case "29":
+		return "pause"
+	case "30":
+		return "utime"
+	case "31":
+		return "stty"
+	case "32":
+		return "gtty"
+	case "33":
+		return "access"
+	case "34":
+		return "nice"
+	case "35":
+		return "ftime"
+	case "36":
+		return "sync"
+	case "37":
+		return "kill"
+	case "38":
+		return "rename"
+	case "39":
+		return "mkdir"
+	case "40":
+		return "rmdir"
+	case "41":
+		return "dup"
+	case "42":
+		return "pipe"
+	case "43":
+		return "times"
+	case "44":
+		return "prof"
+	case "45":
+		return "brk"
+	case "46":
+		return "setgid"
+	case "47":
+		return "getgid"
+	case "48":
+		return "signal"
+	case "49":
+		return "geteuid"
+	case "50":
+		return "getegid"
+	case "51":
+		return "acct"
+	case "52":
+		return "umount2"
+	case "53":
+		return "lock"
+	case "54":
+		return "ioctl"
+	case "55":
+		return "fcntl"
+	case "56":
+		return "mpx"
+	case "57":
+		return "setpgid"
+	case "58":
+		return "ulimit"
+	case "59":
+		return "oldolduname"
+	case "60":
+		return "umask"
+	case "61":
+		return "chroot"
+	case "62":
+		return "ustat"
+	case "63":
+		return "dup2"
+	case "64":
+		return "getppid"
+	case "65":
+		return "getpgrp"
+	case "66":
+		return "setsid"
+	case "67":
+		return "sigaction"
+	case "68":
+		return "sgetmask"
+	case "69":
+		return "ssetmask"
+	case "70":
+		return "setreuid"
+	case "71":
+		return "setregid"
+	case "72":
+		return "sigsuspend"
+	case "73":
+		return "sigpending"
+	case "74":
+		return "sethostname"
+	case "75":
+		return "setrlimit"
+	case "76":
+		return "getrlimit"
+	case "77":
+		return "getrusage"
+	case "78":
+		return "gettimeofday"
+	case "79":
+		return "settimeofday"
+	case "80":
+		return "getgroups"
+	case "81":
+		return "setgroups"
+	case "82":
+		return "select"
+	case "83":
+		return "symlink"
+	case "84":
+		return "oldlstat"
+	case "85":
+		return "readlink"
+	case "86":
+		return "uselib"
+	case "87":
+		return "swapon"
+	case "88":
+		return "reboot"
+	case "89":
+		return "readdir"
+	case "90":
+		return "mmap"
+	case "91":
+		return "munmap"
+	case "92":
+		return "truncate"
+	case "93":
+		return "ftruncate"
+	case "94":
+		return "fchmod"
+	case "95":
+		return "fchown"
+	case "96":
+		return "getpriority"
+	case "97":
+		return "setpriority"
+	case "98":
+		return "profil"
+	case "99":
+		return "statfs"
+	case "100":
+		return "fstatfs"
+	case "101":
+		return "ioperm"
+	case "102":
+		return "socketcall"
+	case "103":
+		return "syslog"
+	case "104":
+		return "setitimer"
+	case "105":
+		return "getitimer"
+	case "106":
+		return "stat"
+	case "107":
+		return "lstat"
+	case "108":
+		return "fstat"
+	case "109":
+		return "olduname"
+	case "110":
+		return "iopl"
+	case "111":
+		return "vhangup"
+	case "112":
+		return "idle"
+	case "113":
+		return "vm86old"
+	case "114":
+		return "wait4"
+	case "115":
+		return "swapoff"
+	case "116":
+		return "sysinfo"
+	case "117":
+		return "ipc"
+	case "118":
+		return "fsync"
+	case "119":
+		return "sigreturn"
+	case "120":
+		return "clone"
+	case "121":
+		return "setdomainname"
+	case "122":
+		return "uname"
+	case "123":
+		return "modify_ldt"
+	case "124":
+		return "adjtimex"
+	case "125":
+		return "mprotect"
+	case "126":
+		return "sigprocmask"
+	case "127":
+		return "create_module"
+	case "128":
+		return "init_module"
+	case "129":
+		return "delete_module"
+	case "130":
+		return "get_kernel_syms"
+	case "131":
+		return "quotactl"
+	case "132":
+		return "getpgid"
+	case "133":
+		return "fchdir"
+	case "134":
+		return "bdflush"
+	case "135":
+		return "sysfs"
+	case "136":
+		return "personality"
+	case "137":
+		return "afs_syscall"
+	case "138":
+		return "setfsuid"
+	case "139":
+		return "setfsgid"
+	case "140":
+		return "_llseek"
+	case "141":
+		return "getdents"
+	case "142":
+		return "_newselect"
+	case "143":
+		return "flock"
+	case "144":
+		return "msync"
+	case "145":
+		return "readv"
+	case "146":
+		return "writev"
+	case "147":
+		return "getsid"
+	case "148":
+		return "fdatasync"
+	case "149":
+		return "_sysctl"
+	case "150":
+		return "mlock"
+	case "151":
+		return "munlock"
+	case "152":
+		return "mlockall"
+	case "153":
+		return "munlockall"
+	case "154":
+		return "sched_setparam"
+	case "155":
+		return "sched_getparam"
+	case "156":
+		return "sched_setscheduler"
+	case "157":
+		return "sched_getscheduler"
+	case "158":
+		return "sched_yield"
+	case "159":
+		return "sched_get_priority_max"
+	case "160":
+		return "sched_get_priority_min"
+	case "161":
+		return "sched_rr_get_interval"
+	case "162":
+		return "nanosleep"
+	case "163":
+		return "mremap"
+	case "164":
+		return "setresuid"
+	case "165":
+		return "getresuid"
+	case "166":
+		return "vm86"
+	case "167":
+		return "query_module"
+	case "168":
+		return "poll"
+	case "169":
+		return "nfsservctl"
+	case "170":
+		return "setresgid"
+	case "171":
+		return "getresgid"
+	case "172":
+		return "prctl"
+	case "173":
+		return "rt_sigreturn"
+	case "174":
+		return "rt_sigaction"
+	case "175":
+		return "rt_sigprocmask"
+	case "176":
+		return "rt_sigpending"
+	case "177":
+		return "rt_sigtimedwait"
+	case "178":
+		return "rt_sigqueueinfo"
+	case "179":
+		return "rt_sigsuspend"
+	case "180":
+		return "pread64"
+	case "181":
+		return "pwrite64"
+	case "182":
+		return "chown"
+	case "183":
+		return "getcwd"
+	case "184":
+		return "capget"
+	case "185":
+		return "capset"
+	case "186":
+		return "sigaltstack"
+	case "187":
+		return "sendfile"
+	case "188":
+		return "getpmsg"
+	case "189":
+		return "putpmsg"
+	case "190":
+		return "vfork"
+	case "191":
+		return "ugetrlimit"
+	case "192":
+		return "mmap2"
+	case "193":
+		return "truncate64"
+	case "194":
+		return "ftruncate64"
+	case "195":
+		return "stat64"
+	case "196":
+		return "lstat64"
+	case "197":
+		return "fstat64"
+	case "198":
+		return "lchown32"
+	case "199":
+		return "getuid32"
+	case "200":
+		return "getgid32"
+	case "201":
+		return "geteuid32"
+	case "202":
+		return "getegid32"
+	case "203":
+		return "setreuid32"
+	case "204":
+		return "setregid32"
+	case "205":
+		return "getgroups32"
+	case "206":
+		return "setgroups32"
+	case "207":
+		return "fchown32"
+	case "208":
+		return "setresuid32"
+	case "209":
+		return "getresuid32"
+	case "210":
+		return "setresgid32"
+	case "211":
+		return "getresgid32"
+	case "212":
+		return "chown32"
+	case "213":
+		return "setuid32"
+	case "214":
+		return "setgid32"
+	case "215":
+		return "setfsuid32"
+	case "216":
+		return "setfsgid32"
+	case "217":
+		return "pivot_root"
+	case "218":
+		return "mincore"
+	case "219":
+		return "madvise"
+	case "220":
+		return "getdents64"
+	case "221":
+		return "fcntl64"
+	case "224":
+		return "gettid"
+	case "225":
+		return "readahead"
+	case "226":
+		return "setxattr"
+	case "227":
+		return "lsetxattr"
+	case "228":
+		return "fsetxattr"
+	case "229":
+		return "getxattr"
+	case "230":
+		return "lgetxattr"
+	case "231":
+		return "fgetxattr"
+	case "232":
+		return "listxattr"
+	case "233":
+		return "llistxattr"
+	case "234":
+		return "flistxattr"
+	case "235":
+		return "removexattr"
+	case "236":
+		return "lremovexattr"
+	case "237":
+		return "fremovexattr"
+	case "238":
+		return "tkill"
+	case "239":
+		return "sendfile64"
+	case "240":
+		return "futex"
+	case "241":
+		return "sched_setaffinity"
+	case "242":
+		return "sched_getaffinity"
+	case "243":
+		return "set_thread_area"
+	case "244":
+		return "get_thread_area"
+	case "245":
+		return "io_setup"
+	case "246":
+		return "io_destroy"
+	case "247":
+		return "io_getevents"
+	case "248":
+		return "io_submit"
+	case "249":
+		return "io_cancel"
+	case "250":
+		return "fadvise64"
+	case "252":
+		return "exit_group"
+	case "253":
+		return "lookup_dcookie"
+	case "254":
+		return "epoll_create"
+	case "255":
+		return "epoll_ctl"
+	case "256":
+		return "epoll_wait"
+	case "257":
+		return "remap_file_pages"
+	case "258":
+		return "set_tid_address"
+	case "259":
+		return "timer_create"
+	case "260":
+		return "timer_settime"
+	case "261":
+		return "timer_gettime"
+	case "262":
+		return "timer_getoverrun"
+	case "263":
+		return "timer_delete"
+	case "264":
+		return "clock_settime"
+	case "265":
+		return "clock_gettime"
+	case "266":
+		return "clock_getres"
+	case "267":
+		return "clock_nanosleep"
+	case "268":
+		return "statfs64"
+	case "269":
+		return "fstatfs64"
+	case "270":
+		return "tgkill"
+	case "271":
+		return "utimes"
+	case "272":
+		return "fadvise64_64"
+	case "273":
+		return "vserver"
+	case "274":
+		return "mbind"
+	case "275":
+		return "get_mempolicy"
+	case "276":
+		return "set_mempolicy"
+	case "277":
+		return "mq_open"
+	case "278":
+		return "mq_unlink"
+	case "279":
+		return "mq_timedsend"
+	case "280":
+		return "mq_timedreceive"
+	case "281":
+		return "mq_notify"
+	case "282":
+		return "mq_getsetattr"
+	case "283":
+		return "kexec_load"
+	case "284":
+		return "waitid"
+	case "286":
+		return "add_key"
+	case "287":
+		return "request_key"
+	case "288":
+		return "keyctl"
+	case "289":
+		return "ioprio_set"
+	case "290":
+		return "ioprio_get"
+	case "291":
+		return "inotify_init"
+	case "292":
+		return "inotify_add_watch"
+	case "293":
+		return "inotify_rm_watch"
+	case "294":
+		return "migrate_pages"
+	case "295":
+		return "openat"
+	case "296":
+		return "mkdirat"
+	case "297":
+		return "mknodat"
+	case "298":
+		return "fchownat"
+	case "299":
+		return "futimesat"
+	case "300":
+		return "fstatat64"
+	case "301":
+		return "unlinkat"
+	case "302":
+		return "renameat"
+	case "303":
+		return "linkat"
+	case "304":
+		return "symlinkat"
+	case "305":
+		return "readlinkat"
+	case "306":
+		return "fchmodat"
+	case "307":
+		return "faccessat"
+	case "308":
+		return "pselect6"
+	case "309":
+		return "ppoll"
+	case "310":
+		return "unshare"
+	case "311":
+		return "set_robust_list"
+	case "312":
+		return "get_robust_list"
+	case "313":
+		return "splice"
+	case "314":
+		return "sync_file_range"
+	case "315":
+		return "tee"
+	case "316":
+		return "vmsplice"
+	case "317":
+		return "move_pages"
+	case "318":
+		return "getcpu"
+	case "319":
+		return "epoll_pwait"
+	case "320":
+		return "utimensat"
+	case "321":
+		return "signalfd"
+	case "322":
+		return "timerfd_create"
+	case "323":
+		return "eventfd"
+	case "324":
+		return "fallocate"
+	case "325":
+		return "timerfd_settime"
+	case "326":
+		return "timerfd_gettime"
+	case "327":
+		return "signalfd4"
+	case "328":
+		return "eventfd2"
+	case "329":
+		return "epoll_create1"
+	case "330":
+		return "dup3"
+	case "331":
+		return "pipe2"
+	case "332":
+		return "inotify_init1"
+	case "333":
+		return "preadv"
+	case "334":
+		return "pwritev"
+	case "335":
+		return "rt_tgsigqueueinfo"
+	case "336":
+		return "perf_event_open"
+	case "337":
+		return "recvmmsg"
+	case "338":
+		return "fanotify_init"
+	case "339":
+		return "fanotify_mark"
+	case "340":
+		return "prlimit64"
+	case "341":
+		return "name_to_handle_at"
+	case "342":
+		return "open_by_handle_at"
+	case "343":
+		return "clock_adjtime"
+	case "344":
+		return "syncfs"
+	case "345":
+		return "sendmmsg"
+	case "346":
+		return "setns"
+	case "347":
+		return "process_vm_readv"
+	case "348":
+		return "process_vm_writev"
+	case "349":
+		return "kcmp"
+	case "350":
+		return "finit_module"
+	case "351":
+		return "sched_setattr"
+	case "352":
+		return "sched_getattr"
+	case "353":
+		return "renameat2"
+	case "354":
+		return "seccomp"
+	case "355":
+		return "getrandom"
+	case "356":
+		return "memfd_create"
+	case "357":
+		return "bpf"
+	case "358":
+		return "execveat"
+	case "359":
+		return "socket"
+	case "360":
+		return "socketpair"
+	case "361":
+		return "bind"
+	case "362":
+		return "connect"
+	case "363":
+		return "listen"
+	case "364":
+		return "accept4"
+	case "365":
+		return "getsockopt"
+	case "366":
+		return "setsockopt"
+	case "367":
+		return "getsockname"
+	case "368":
+		return "getpeername"
+	case "369":
+		return "sendto"
+	case "370":
+		return "sendmsg"
+	case "371":
+		return "recvfrom"
+	case "372":
+		return "recvmsg"
+	case "373":
+		return "shutdown"
+	case "374":
+		return "userfaultfd"
+	case "375":
+		return "membarrier"
+	case "376":
+		return "mlock2"
+	case "377":
+		return "copy_file_range"
+	case "378":
+		return "preadv2"
+	case "379":
+		return "pwritev2"
+	case "380":
+		return "pkey_mprotect"
+	case "381":
+		return "pkey_alloc"
+	case "382":
+		return "pkey_free"
+	case "383":
+		return "statx"
+	case "384":
+		return "arch_prctl"
+	case "385":
+		return "io_pgetevents"
+	case "386":
+		return "rseq"
+	case "393":
+		return "semget"
+	case "394":
+		return "semctl"
+	case "395":
+		return "shmget"
+	case "396":
+		return "shmctl"
+	case "397":
+		return "shmat"
+	case "398":
+		return "shmdt"
+	case "399":
+		return "msgget"
+	case "400":
+		return "msgsnd"
+	case "401":
+		return "msgrcv"
+	case "402":
+		return "msgctl"
+	case "403":
+		return "clock_gettime64"
+	case "404":
+		return "clock_settime64"
+	case "405":
+		return "clock_adjtime64"
+	case "406":
+		return "clock_getres_time64"
+	case "407":
+		return "clock_nanosleep_time64"
+	case "408":
+		return "timer_gettime64"
+	case "409":
+		return "timer_settime64"
+	case "410":
+		return "timerfd_gettime64"
+	case "411":
+		return "timerfd_settime64"
+	case "412":
+		return "utimensat_time64"
+	case "413":
+		return "pselect6_time64"
+	case "414":
+		return "ppoll_time64"
+	case "416":
+		return "io_pgetevents_time64"
+	case "417":
+		return "recvmmsg_time64"
+	case "418":
+		return "mq_timedsend_time64"
+	case "419":
+		return "mq_timedreceive_time64"
+	case "420":
+		return "semtimedop_time64"
+	case "421":
+		return "rt_sigtimedwait_time64"
+	case "422":
+		return "futex_time64"
+	case "423":
+		return "sched_rr_get_interval_time64"
+	case "424":
+		return "pidfd_send_signal"
+	case "425":
+		return "io_uring_setup"
+	case "426":
+		return "io_uring_enter"
+	case "427":
+		return "io_uring_register"
+	case "428":
+		return "open_tree"
+	case "429":
+		return "move_mount"
+	case "430":
+		return "fsopen"
+	case "431":
+		return "fsconfig"
+	case "432":
+		return "fsmount"
+	case "433":
+		return "fspick"
+	case "434":
+		return "pidfd_open"
+	case "435":
+		return "clone3"
+	case "436":
+		return "close_range"
+	case "437":
+		return "openat2"
+	case "438":
+		return "pidfd_getfd"
+	case "439":
+		return "faccessat2"
+	case "440":
+		return "process_madvise"
+	case "441":
+		return "epoll_pwait2"
+	case "442":
+		return "mount_setattr"
+	case "443":
+		return "quotactl_fd"
+	case "444":
+		return "landlock_create_ruleset"
+	case "445":
+		return "landlock_add_rule"
+	case "446":
+		return "landlock_restrict_self"
+	case "447":
+		return "memfd_secret"
+	case "448":
+		return "process_mrelease"
+	case "449":
+		return "futex_waitv"
+	case "450":
+		return "set_mempolicy_home_node"
+	default:
+		return "Unsupported"
+	}
+}
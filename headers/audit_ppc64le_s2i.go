@@ -0,0 +1,859 @@
+package headers
+
+// SysMapPpc64le is a mapping between ppc64le syscall names and their integer values
+// The list is taken from the powerpc unistd.h of the kernel (64 bit)
+// Unlike audit_x64.go the file name has the _s2i suffix, a _ppc64le suffix would restrict the file to GOARCH=ppc64le builds
+func SysMapPpc64le(syscall string) (name int) {
+	switch syscall {
+	case "restart_syscall":
+		return 0
+	case "exit":
+		return 1
+	case "fork":
+		return 2
+	case "read":
+		return 3
+	case "write":
+		return 4
+	case "open":
+		return 5
+	case "close":
+		return 6
+	case "waitpid":
+		return 7
+	case "creat":
+		return 8
+	case "link":
+		return 9
+	case "unlink":
+		return 10
+	case "execve":
+		return 11
+	case "chdir":
+		return 12
+	case "time":
+		return 13
+	case "mknod":
+		return 14
+	case "chmod":
+		return 15
+	case "lchown":
+		return 16
+	case "break":
+		return 17
+	case "oldstat":
+		return 18
+	case "lseek":
+		return 19
+	case "getpid":
+		return 20
+	case "mount":
+		return 21
+	case "umount":
+		return 22
+	case "setuid":
+		return 23
+	case "getuid":
+		return 24
+	case "stime":
+		return 25
+	case "ptrace":
+		return 26
+	case "alarm":
+		return 27
+	case "oldfstat":
+		return 28
+	case "pause":
+		return 29
+	case "utime":
+		return 30
+	case "stty":
+		return 31
+	case "gtty":
+		return 32
+	case "access":
+		return 33
+	case "nice":
+		return 34
+	case "ftime":
+		return 35
+	case "sync":
+		return 36
+	case "kill":
+		return 37
+	case "rename":
+		return 38
+	case "mkdir":
+		return 39
+	case "rmdir":
+		return 40
+	case "dup":
+		return 41
+	case "pipe":
+		return 42
+	case "times":
+		return 43
+	case "prof":
+		return 44
+	case "brk":
+		return 45
+	case "setgid":
+		return 46
+	case "getgid":
+		return 47
+	case "signal":
+		return 48
+	case "geteuid":
+		return 49
+	case "getegid":
+		return 50
+	case "acct":
+		return 51
+	case "umount2":
+		return 52
+	case "lock":
+		return 53
+	case "ioctl":
+		return 54
+	case "fcntl":
+		return 55
+	case "mpx":
+		return 56
+	case "setpgid":
+		return 57
+	case "ulimit":
+		return 58
+	case "oldolduname":
+		return 59
+	case "umask":
+		return 60
+	case "chroot":
+		return 61
+	case "ustat":
+		return 62
+	case "dup2":
+		return 63
+	case "getppid":
+		return 64
+	case "getpgrp":
+		return 65
+	case "setsid":
+		return 66
+	case "sigaction":
+		return 67
+	case "sgetmask":
+		return 68
+	case "ssetmask":
+		return 69
+	case "setreuid":
+		return 70
+	case "setregid":
+		return 71
+	case "sigsuspend":
+		return 72
+	case "sigpending":
+		return 73
+	case "sethostname":
+		return 74
+	case "setrlimit":
+		return 75
+	case "getrlimit":
+		return 76
+	case "getrusage":
+		return 77
+	case "gettimeofday":
+		return 78
+	case "settimeofday":
+		return 79
+	case "getgroups":
+		return 80
+	case "setgroups":
+		return 81
+	case "select":
+		return 82
+	case "symlink":
+		return 83
+	case "oldlstat":
+		return 84
+	case "readlink":
+		return 85
+	case "uselib":
+		return 86
+	case "swapon":
+		return 87
+	case "reboot":
+		return 88
+	case "readdir":
+		return 89
+	case "mmap":
+		return 90
+	case "munmap":
+		return 91
+	case "truncate":
+		return 92
+	case "ftruncate":
+		return 93
+	case "fchmod":
+		return 94
+	case "fchown":
+		return 95
+	case "getpriority":
+		return 96
+	case "setpriority":
+		return 97
+	case "profil":
+		return 98
+	case "statfs":
+		return 99
+	case "fstatfs":
+		return 100
+	case "ioperm":
+		return 101
+	case "socketcall":
+		return 102
+	case "syslog":
+		return 103
+	case "setitimer":
+		return 104
+	case "getitimer":
+		return 105
+	case "stat":
+		return 106
+	case "lstat":
+		return 107
+	case "fstat":
+		return 108
+	case "olduname":
+		return 109
+	case "iopl":
+		return 110
+	case "vhangup":
+		return 111
+	case "idle":
+		return 112
+	case "vm86":
+		return 113
+	case "wait4":
+		return 114
+	case "swapoff":
+		return 115
+	case "sysinfo":
+		return 116
+	case "ipc":
+		return 117
+	case "fsync":
+		return 118
+	case "sigreturn":
+		return 119
+	case "clone":
+		return 120
+	case "setdomainname":
+		return 121
+	case "uname":
+		return 122
+	case "modify_ldt":
+		return 123
+	case "adjtimex":
+		return 124
+	case "mprotect":
+		return 125
+	case "sigprocmask":
+		return 126
+	case "create_module":
+		return 127
+	case "init_module":
+		return 128
+	case "delete_module":
+		return 129
+	case "get_kernel_syms":
+		return 130
+	case "quotactl":
+		return 131
+	case "getpgid":
+		return 132
+	case "fchdir":
+		return 133
+	case "bdflush":
+		return 134
+	case "sysfs":
+		return 135
+	case "personality":
+		return 136
+	case "afs_syscall":
+		return 137
+	case "setfsuid":
+		return 138
+	case "setfsgid":
+		return 139
+	case "_llseek":
+		return 140
+	case "getdents":
+		return 141
+	case "_newselect":
+		return 142
+	case "flock":
+		return 143
+	case "msync":
+		return 144
+	case "readv":
+		return 145
+	case "writev":
+		return 146
+	case "getsid":
+		return 147
+	case "fdatasync":
+		return 148
+	case "_sysctl":
+		return 149
+	case "mlock":
+		return 150
+	case "munlock":
+		return 151
+	case "mlockall":
+		return 152
+	case "munlockall":
+		return 153
+	case "sched_setparam":
+		return 154
+	case "sched_getparam":
+		return 155
+	case "sched_setscheduler":
+		return 156
+	case "sched_getscheduler":
+		return 157
+	case "sched_yield":
+		return 158
+	case "sched_get_priority_max":
+		return 159
+	case "sched_get_priority_min":
+		return 160
+	case "sched_rr_get_interval":
+		return 161
+	case "nanosleep":
+		return 162
+	case "mremap":
+		return 163
+	case "setresuid":
+		return 164
+	case "getresuid":
+		return 165
+	case "query_module":
+		return 166
+	case "poll":
+		return 167
+	case "nfsservctl":
+		return 168
+	case "setresgid":
+		return 169
+	case "getresgid":
+		return 170
+	case "prctl":
+		return 171
+	case "rt_sigreturn":
+		return 172
+	case "rt_sigaction":
+		return 173
+	case "rt_sigprocmask":
+		return 174
+	case "rt_sigpending":
+		return 175
+	case "rt_sigtimedwait":
+		return 176
+	case "rt_sigqueueinfo":
+		return 177
+	case "rt_sigsuspend":
+		return 178
+	case "pread64":
+		return 179
+	case "pwrite64":
+		return 180
+	case "chown":
+		return 181
+	case "getcwd":
+		return 182
+	case "capget":
+		return 183
+	case "capset":
+		return 184
+	case "sigaltstack":
+		return 185
+	case "sendfile":
+		return 186
+	case "getpmsg":
+		return 187
+	case "putpmsg":
+		return 188
+	case "vfork":
+		return 189
+	case "ugetrlimit":
+		return 190
+	case "readahead":
+		return 191
+	case "pciconfig_read":
+		return 198
+	case "pciconfig_write":
+		return 199
+	case "pciconfig_iobase":
+		return 200
+	case "multiplexer":
+		return 201
+	case "getdents64":
+		return 202
+	case "pivot_root":
+		return 203
+	case "madvise":
+		return 205
+	case "mincore":
+		return 206
+	case "gettid":
+		return 207
+	case "tkill":
+		return 208
+	case "setxattr":
+		return 209
+	case "lsetxattr":
+		return 210
+	case "fsetxattr":
+		return 211
+	case "getxattr":
+		return 212
+	case "lgetxattr":
+		return 213
+	case "fgetxattr":
+		return 214
+	case "listxattr":
+		return 215
+	case "llistxattr":
+		return 216
+	case "flistxattr":
+		return 217
+	case "removexattr":
+		return 218
+	case "lremovexattr":
+		return 219
+	case "fremovexattr":
+		return 220
+	case "futex":
+		return 221
+	case "sched_setaffinity":
+		return 222
+	case "sched_getaffinity":
+		return 223
+	case "tuxcall":
+		return 225
+	case "io_setup":
+		return 227
+	case "io_destroy":
+		return 228
+	case "io_getevents":
+		return 229
+	case "io_submit":
+		return 230
+	case "io_cancel":
+		return 231
+	case "set_tid_address":
+		return 232
+	case "fadvise64":
+		return 233
+	case "exit_group":
+		return 234
+	case "lookup_dcookie":
+		return 235
+	case "epoll_create":
+		return 236
+	case "epoll_ctl":
+		return 237
+	case "epoll_wait":
+		return 238
+	case "remap_file_pages":
+		return 239
+	case "timer_create":
+		return 240
+	case "timer_settime":
+		return 241
+	case "timer_gettime":
+		return 242
+	case "timer_getoverrun":
+		return 243
+	case "timer_delete":
+		return 244
+	case "clock_settime":
+		return 245
+	case "clock_gettime":
+		return 246
+	case "clock_getres":
+		return 247
+	case "clock_nanosleep":
+		return 248
+	case "swapcontext":
+		return 249
+	case "tgkill":
+		return 250
+	case "utimes":
+		return 251
+	case "statfs64":
+		return 252
+	case "fstatfs64":
+		return 253
+	case "rtas":
+		return 255
+	case "sys_debug_setcontext":
+		return 256
+	case "migrate_pages":
+		return 258
+	case "mbind":
+		return 259
+	case "get_mempolicy":
+		return 260
+	case "set_mempolicy":
+		return 261
+	case "mq_open":
+		return 262
+	case "mq_unlink":
+		return 263
+	case "mq_timedsend":
+		return 264
+	case "mq_timedreceive":
+		return 265
+	case "mq_notify":
+		return 266
+	case "mq_getsetattr":
+		return 267
+	case "kexec_load":
+		return 268
+	case "add_key":
+		return 269
+	case "request_key":
+		return 270
+	case "keyctl":
+		return 271
+	case "waitid":
+		return 272
+	case "ioprio_set":
+		return 273
+	case "ioprio_get":
+		return 274
+	case "inotify_init":
+		return 275
+	case "inotify_add_watch":
+		return 276
+	case "inotify_rm_watch":
+		return 277
+	case "spu_run":
+		return 278
+	case "spu_create":
+		return 279
+	case "pselect6":
+		return 280
+	case "ppoll":
+		return 281
+	case "unshare":
+		return 282
+	case "splice":
+		return 283
+	case "tee":
+		return 284
+	case "vmsplice":
+		return 285
+	case "openat":
+		return 286
+	case "mkdirat":
+		return 287
+	case "mknodat":
+		return 288
+	case "fchownat":
+		return 289
+	case "futimesat":
+		return 290
+	case "newfstatat":
+		return 291
+	case "unlinkat":
+		return 292
+	case "renameat":
+		return 293
+	case "linkat":
+		return 294
+	case "symlinkat":
+		return 295
+	case "readlinkat":
+		return 296
+	case "fchmodat":
+		return 297
+	case "faccessat":
+		return 298
+	case "get_robust_list":
+		return 299
+	case "set_robust_list":
+		return 300
+	case "move_pages":
+		return 301
+	case "getcpu":
+		return 302
+	case "epoll_pwait":
+		return 303
+	case "utimensat":
+		return 304
+	case "signalfd":
+		return 305
+	case "timerfd_create":
+		return 306
+	case "eventfd":
+		return 307
+	case "sync_file_range2":
+		return 308
+	case "fallocate":
+		return 309
+	case "subpage_prot":
+		return 310
+	case "timerfd_settime":
+		return 311
+	case "timerfd_gettime":
+		return 312
+	case "signalfd4":
+		return 313
+	case "eventfd2":
+		return 314
+	case "epoll_create1":
+		return 315
+	case "dup3":
+		return 316
+	case "pipe2":
+		return 317
+	case "inotify_init1":
+		return 318
+	case "perf_event_open":
+		return 319
+	case "preadv":
+		return 320
+	case "pwritev":
+		return 321
+	case "rt_tgsigqueueinfo":
+		return 322
+	case "fanotify_init":
+		return 323
+	case "fanotify_mark":
+		return 324
+	case "prlimit64":
+		return 325
+	case "socket":
+		return 326
+	case "bind":
+		return 327
+	case "connect":
+		return 328
+	case "listen":
+		return 329
+	case "accept":
+		return 330
+	case "getsockname":
+		return 331
+	case "getpeername":
+		return 332
+	case "socketpair":
+		return 333
+	case "send":
+		return 334
+	case "sendto":
+		return 335
+	case "recv":
+		return 336
+	case "recvfrom":
+		return 337
+	case "shutdown":
+		return 338
+	case "setsockopt":
+		return 339
+	case "getsockopt":
+		return 340
+	case "sendmsg":
+		return 341
+	case "recvmsg":
+		return 342
+	case "recvmmsg":
+		return 343
+	case "accept4":
+		return 344
+	case "name_to_handle_at":
+		return 345
+	case "open_by_handle_at":
+		return 346
+	case "clock_adjtime":
+		return 347
+	case "syncfs":
+		return 348
+	case "sendmmsg":
+		return 349
+	case "setns":
+		return 350
+	case "process_vm_readv":
+		return 351
+	case "process_vm_writev":
+		return 352
+	case "finit_module":
+		return 353
+	case "kcmp":
+		return 354
+	case "sched_setattr":
+		return 355
+	case "sched_getattr":
+		return 356
+	case "renameat2":
+		return 357
+	case "seccomp":
+		return 358
+	case "getrandom":
+		return 359
+	case "memfd_create":
+		return 360
+	case "bpf":
+		return 361
+	case "execveat":
+		return 362
+	case "switch_endian":
+		return 363
+	case "userfaultfd":
+		return 364
+	case "membarrier":
+		return 365
+	case "mlock2":
+		return 378
+	case "copy_file_range":
+		return 379
+	case "preadv2":
+		return 380
+	case "pwritev2":
+		return 381
+	case "kexec_file_load":
+		return 382
+	case "statx":
+		return 383
+	case "pkey_alloc":
+		return 384
+	case "pkey_free":
+		return 385
+	case "pkey_mprotect":
+		return 386
+	case "rseq":
+		return 387
+	case "io_pgetevents":
+		return 388
+	case "semtimedop":
+		return 392
+	case "semget":
+		return 393
+	case "semctl":
+		return 394
+	case "shmget":
+		return 395
+	case "shmctl":
+		return 396
+	case "shmat":
+		return 397
+	case "shmdt":
+		return 398
+	case "msgget":
+		return 399
+	case "msgsnd":
+		return 400
+	case "msgrcv":
+		return 401
+	case "msgctl":
+		return 402
+	case "pidfd_send_signal":
+		return 424
+	case "io_uring_setup":
+		return 425
+	case "io_uring_enter":
+		return 426
+	case "io_uring_register":
+		return 427
+	case "open_tree":
+		return 428
+	case "move_mount":
+		return 429
+	case "fsopen":
+		return 430
+	case "fsconfig":
+		return 431
+	case "fsmount":
+		return 432
+	case "fspick":
+		return 433
+	case "pidfd_open":
+		return 434
+	case "clone3":
+		return 435
+	case "close_range":
+		return 436
+	case "openat2":
+		return 437
+	case "pidfd_getfd":
+		return 438
+	case "faccessat2":
+		return 439
+	case "process_madvise":
+		return 440
+	case "epoll_pwait2":
+		return 441
+	case "mount_setattr":
+		return 442
+	case "quotactl_fd":
+		return 443
+	case "landlock_create_ruleset":
+		return 444
+	case "landlock_add_rule":
+		return 445
+	case "landlock_restrict_self":
+		return 446
+	case "process_mrelease":
+		return 448
+	case "futex_waitv":
+		return 449
+	case "set_mempolicy_home_node":
+		return 450
+	case "cachestat":
+		return 451
+	case "fchmodat2":
+		return 452
+	case "map_shadow_stack":
+		return 453
+	case "futex_wake":
+		return 454
+	case "futex_wait":
+		return 455
+	case "futex_requeue":
+		return 456
+	case "statmount":
+		return 457
+	case "listmount":
+		return 458
+	case "lsm_get_self_attr":
+		return 459
+	case "lsm_set_self_attr":
+		return 460
+	case "lsm_list_modules":
+		return 461
+	case "mseal":
+		return 462
+	case "setxattrat":
+		return 463
+	case "getxattrat":
+		return 464
+	case "listxattrat":
+		return 465
+	case "removexattrat":
+		return 466
+	case "open_tree_attr":
+		return 467
+	case "file_getattr":
+		return 468
+	case "file_setattr":
+		return 469
+	case "listns":
+		return 470
+	case "rseq_slice_yield":
+		return 471
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,790 @@
+package headers
+
+// ReverseSysMapS390x is a mapping between s390x syscall integer values and their names
+// The list is taken from the s390 unistd.h of the kernel (64 bit)
+func ReverseSysMapS390x(syscall string) (name string) {
+	switch syscall {
+	case "1":
+		return "exit"
+	case "2":
+		return "fork"
+	case "3":
+		return "read"
+	case "4":
+		return "write"
+	case "5":
+		return "open"
+	case "6":
+		return "close"
+	case "7":
+		return "restart_syscall"
+	case "8":
+		return "creat"
+	case "9":
+		return "link"
+	case "10":
+		return "unlink"
+	case "11":
+		return "execve"
+	case "12":
+		return "chdir"
+	case "14":
+		return "mknod"
+	case "15":
+		return "chmod"
+	case "19":
+		return "lseek"
+	case "20":
+		return "getpid"
+	case "21":
+		return "mount"
+	case "22":
+		return "umount"
+	case "26":
+		return "ptrace"
+	case "27":
+		return "alarm"
+	case "29":
+		return "pause"
+	case "30":
+		return "utime"
+	case "33":
+		return "access"
+	case "34":
+		return "nice"
+	case "36":
+		return "sync"
+	case "37":
+		return "kill"
+	case "38":
+		return "rename"
+	case "39":
+		return "mkdir"
+	case "40":
+		return "rmdir"
+	case "41":
+		return "dup"
+	case "42":
+		return "pipe"
+	case "43":
+		return "times"
+	case "45":
+		return "brk"
+	case "48":
+		return "signal"
+	case "51":
+		return "acct"
+	case "52":
+		return "umount2"
+	case "54":
+		return "ioctl"
+	case "55":
+		return "fcntl"
+	case "57":
+		return "setpgid"
+	case "60":
+		return "umask"
+	case "61":
+		return "chroot"
+	case "62":
+		return "ustat"
+	case "63":
+		return "dup2"
+	case "64":
+		return "getppid"
+	case "65":
+		return "getpgrp"
+	case "66":
+		return "setsid"
+	case "67":
+		return "sigaction"
+	case "72":
+		return "sigsuspend"
+	case "73":
+		return "sigpending"
+	case "74":
+		return "sethostname"
+	case "75":
+		return "setrlimit"
+	case "77":
+		return "getrusage"
+	case "78":
+		return "gettimeofday"
+	case "79":
+		return "settimeofday"
+	case "83":
+		return "symlink"
+	case "85":
+		return "readlink"
+	case "86":
+		return "uselib"
+	case "87":
+		return "swapon"
+	case "88":
+		return "reboot"
+	case "89":
+		return "readdir"
+	case "90":
+		return "mmap"
+	case "91":
+		return "munmap"
+	case "92":
+		return "truncate"
+	case "93":
+		return "ftruncate"
+	case "94":
+		return "fchmod"
+	case "96":
+		return "getpriority"
+	case "97":
+		return "setpriority"
+	case "99":
+		return "statfs"
+	case "100":
+		return "fstatfs"
+	case "102":
+		return "socketcall"
+	case "103":
+		return "syslog"
+	case "104":
+		return "setitimer"
+	case "105":
+		return "getitimer"
+	case "106":
+		return "stat"
+	case "107":
+		return "lstat"
+	case "108":
+		return "fstat"
+	case "110":
+		return "lookup_dcookie"
+	case "111":
+		return "vhangup"
+	case "112":
+		return "idle"
+	case "114":
+		return "wait4"
+	case "115":
+		return "swapoff"
+	case "116":
+		return "sysinfo"
+	case "117":
+		return "ipc"
+	case "118":
+		return "fsync"
+	case "119":
+		return "sigreturn"
+	case "120":
+		return "clone"
+	case "121":
+		return "setdomainname"
+	case "122":
+		return "uname"
+	case "124":
+		return "adjtimex"
+	case "125":
+		return "mprotect"
+	case "126":
+		return "sigprocmask"
+	case "127":
+		return "create_module"
+	case "128":
+		return "init_module"
+	case "129":
+		return "delete_module"
+	case "130":
+		return "get_kernel_syms"
+	case "131":
+		return "quotactl"
+	case "132":
+		return "getpgid"
+	case "133":
+		return "fchdir"
+	case "134":
+		return "bdflush"
+	case "135":
+		return "sysfs"
+	case "136":
+		return "personality"
+	case "137":
+		return "afs_syscall"
+	case "141":
+		return "getdents"
+	case "142":
+		return "select"
+	case "143":
+		return "flock"
+	case "144":
+		return "msync"
+	case "145":
+		return "readv"
+	case "146":
+		return "writev"
+	case "147":
+		return "getsid"
+	case "148":
+		return "fdatasync"
+	case "149":
+		return "_sysctl"
+	case "150":
+		return "mlock"
+	case "151":
+		return "munlock"
+	case "152":
+		return "mlockall"
+	case "153":
+		return "munlockall"
+	case "154":
+		return "sched_setparam"
+	case "155":
+		return "sched_getparam"
+	case "156":
+		return "sched_setscheduler"
+	case "157":
+		return "sched_getscheduler"
+	case "158":
+		return "sched_yield"
+	case "159":
+		return "sched_get_priority_max"
+	case "160":
+		return "sched_get_priority_min"
+	case "161":
+		return "sched_rr_get_interval"
+	case "162":
+		return "nanosleep"
+	case "163":
+		return "mremap"
+	case "167":
+		return "query_module"
+	case "168":
+		return "poll"
+	case "169":
+		return "nfsservctl"
+	case "172":
+		return "prctl"
+	case "173":
+		return "rt_sigreturn"
+	case "174":
+		return "rt_sigaction"
+	case "175":
+		return "rt_sigprocmask"
+	case "176":
+		return "rt_sigpending"
+	case "177":
+		return "rt_sigtimedwait"
+	case "178":
+		return "rt_sigqueueinfo"
+	case "179":
+		return "rt_sigsuspend"
+	case "180":
+		return "pread64"
+	case "181":
+		return "pwrite64"
+	case "183":
+		return "getcwd"
+	case "184":
+		return "capget"
+	case "185":
+		return "capset"
+	case "186":
+		return "sigaltstack"
+	case "187":
+		return "sendfile"
+	case "188":
+		return "getpmsg"
+	case "189":
+		return "putpmsg"
+	case "190":
+		return "vfork"
+	case "191":
+		return "getrlimit"
+	case "198":
+		return "lchown"
+	case "199":
+		return "getuid"
+	case "200":
+		return "getgid"
+	case "201":
+		return "geteuid"
+	case "202":
+		return "getegid"
+	case "203":
+		return "setreuid"
+	case "204":
+		return "setregid"
+	case "205":
+		return "getgroups"
+	case "206":
+		return "setgroups"
+	case "207":
+		return "fchown"
+	case "208":
+		return "setresuid"
+	case "209":
+		return "getresuid"
+	case "210":
+		return "setresgid"
+	case "211":
+		return "getresgid"
+	case "212":
+		return "chown"
+	case "213":
+		return "setuid"
+	case "214":
+		return "setgid"
+	case "215":
+		return "setfsuid"
+	case "216":
+		return "setfsgid"
+	case "217":
+		return "pivot_root"
+	case "218":
+		return "mincore"
+	case "219":
+		return "madvise"
+	case "220":
+		return "getdents64"
+	case "222":
+		return "readahead"
+	case "224":
+		return "setxattr"
+	case "225":
+		return "lsetxattr"
+	case "226":
+		return "fsetxattr"
+	case "227":
+		return "getxattr"
+	case "228":
+		return "lgetxattr"
+	case "229":
+		return "fgetxattr"
+	case "230":
+		return "listxattr"
+	case "231":
+		return "llistxattr"
+	case "232":
+		return "flistxattr"
+	case "233":
+		return "removexattr"
+	case "234":
+		return "lremovexattr"
+	case "235":
+		return "fremovexattr"
+	case "236":
+		return "gettid"
+	case "237":
+		return "tkill"
+	case "238":
+		return "futex"
+	case "239":
+		return "sched_setaffinity"
+	case "240":
+		return "sched_getaffinity"
+	case "241":
+		return "tgkill"
+	case "243":
+		return "io_setup"
+	case "244":
+		return "io_destroy"
+	case "245":
+		return "io_getevents"
+	case "246":
+		return "io_submit"
+	case "247":
+		return "io_cancel"
+	case "248":
+		return "exit_group"
+	case "249":
+		return "epoll_create"
+	case "250":
+		return "epoll_ctl"
+	case "251":
+		return "epoll_wait"
+	case "252":
+		return "set_tid_address"
+	case "253":
+		return "fadvise64"
+	case "254":
+		return "timer_create"
+	case "255":
+		return "timer_settime"
+	case "256":
+		return "timer_gettime"
+	case "257":
+		return "timer_getoverrun"
+	case "258":
+		return "timer_delete"
+	case "259":
+		return "clock_settime"
+	case "260":
+		return "clock_gettime"
+	case "261":
+		return "clock_getres"
+	case "262":
+		return "clock_nanosleep"
+	case "265":
+		return "statfs64"
+	case "266":
+		return "fstatfs64"
+	case "267":
+		return "remap_file_pages"
+	case "268":
+		return "mbind"
+	case "269":
+		return "get_mempolicy"
+	case "270":
+		return "set_mempolicy"
+	case "271":
+		return "mq_open"
+	case "272":
+		return "mq_unlink"
+	case "273":
+		return "mq_timedsend"
+	case "274":
+		return "mq_timedreceive"
+	case "275":
+		return "mq_notify"
+	case "276":
+		return "mq_getsetattr"
+	case "277":
+		return "kexec_load"
+	case "278":
+		return "add_key"
+	case "279":
+		return "request_key"
+	case "280":
+		return "keyctl"
+	case "281":
+		return "waitid"
+	case "282":
+		return "ioprio_set"
+	case "283":
+		return "ioprio_get"
+	case "284":
+		return "inotify_init"
+	case "285":
+		return "inotify_add_watch"
+	case "286":
+		return "inotify_rm_watch"
+	case "287":
+		return "migrate_pages"
+	case "288":
+		return "openat"
+	case "289":
+		return "mkdirat"
+	case "290":
+		return "mknodat"
+	case "291":
+		return "fchownat"
+	case "292":
+		return "futimesat"
+	case "293":
+		return "newfstatat"
+	case "294":
+		return "unlinkat"
+	case "295":
+		return "renameat"
+	case "296":
+		return "linkat"
+	case "297":
+		return "symlinkat"
+	case "298":
+		return "readlinkat"
+	case "299":
+		return "fchmodat"
+	case "300":
+		return "faccessat"
+	case "301":
+		return "pselect6"
+	case "302":
+		return "ppoll"
+	case "303":
+		return "unshare"
+	case "304":
+		return "set_robust_list"
+	case "305":
+		return "get_robust_list"
+	case "306":
+		return "splice"
+	case "307":
+		return "sync_file_range"
+	case "308":
+		return "tee"
+	case "309":
+		return "vmsplice"
+	case "310":
+		return "move_pages"
+	case "311":
+		return "getcpu"
+	case "312":
+		return "epoll_pwait"
+	case "313":
+		return "utimes"
+	case "314":
+		return "fallocate"
+	case "315":
+		return "utimensat"
+	case "316":
+		return "signalfd"
+	case "317":
+		return "timerfd"
+	case "318":
+		return "eventfd"
+	case "319":
+		return "timerfd_create"
+	case "320":
+		return "timerfd_settime"
+	case "321":
+		return "timerfd_gettime"
+	case "322":
+		return "signalfd4"
+	case "323":
+		return "eventfd2"
+	case "324":
+		return "inotify_init1"
+	case "325":
+		return "pipe2"
+	case "326":
+		return "dup3"
+	case "327":
+		return "epoll_create1"
+	case "328":
+		return "preadv"
+	case "329":
+		return "pwritev"
+	case "330":
+		return "rt_tgsigqueueinfo"
+	case "331":
+		return "perf_event_open"
+	case "332":
+		return "fanotify_init"
+	case "333":
+		return "fanotify_mark"
+	case "334":
+		return "prlimit64"
+	case "335":
+		return "name_to_handle_at"
+	case "336":
+		return "open_by_handle_at"
+	case "337":
+		return "clock_adjtime"
+	case "338":
+		return "syncfs"
+	case "339":
+		return "setns"
+	case "340":
+		return "process_vm_readv"
+	case "341":
+		return "process_vm_writev"
+	case "342":
+		return "s390_runtime_instr"
+	case "343":
+		return "kcmp"
+	case "344":
+		return "finit_module"
+	case "345":
+		return "sched_setattr"
+	case "346":
+		return "sched_getattr"
+	case "347":
+		return "renameat2"
+	case "348":
+		return "seccomp"
+	case "349":
+		return "getrandom"
+	case "350":
+		return "memfd_create"
+	case "351":
+		return "bpf"
+	case "352":
+		return "s390_pci_mmio_write"
+	case "353":
+		return "s390_pci_mmio_read"
+	case "354":
+		return "execveat"
+	case "355":
+		return "userfaultfd"
+	case "356":
+		return "membarrier"
+	case "357":
+		return "recvmmsg"
+	case "358":
+		return "sendmmsg"
+	case "359":
+		return "socket"
+	case "360":
+		return "socketpair"
+	case "361":
+		return "bind"
+	case "362":
+		return "connect"
+	case "363":
+		return "listen"
+	case "364":
+		return "accept4"
+	case "365":
+		return "getsockopt"
+	case "366":
+		return "setsockopt"
+	case "367":
+		return "getsockname"
+	case "368":
+		return "getpeername"
+	case "369":
+		return "sendto"
+	case "370":
+		return "sendmsg"
+	case "371":
+		return "recvfrom"
+	case "372":
+		return "recvmsg"
+	case "373":
+		return "shutdown"
+	case "374":
+		return "mlock2"
+	case "375":
+		return "copy_file_range"
+	case "376":
+		return "preadv2"
+	case "377":
+		return "pwritev2"
+	case "378":
+		return "s390_guarded_storage"
+	case "379":
+		return "statx"
+	case "380":
+		return "s390_sthyi"
+	case "381":
+		return "kexec_file_load"
+	case "382":
+		return "io_pgetevents"
+	case "383":
+		return "rseq"
+	case "384":
+		return "pkey_mprotect"
+	case "385":
+		return "pkey_alloc"
+	case "386":
+		return "pkey_free"
+	case "392":
+		return "semtimedop"
+	case "393":
+		return "semget"
+	case "394":
+		return "semctl"
+	case "395":
+		return "shmget"
+	case "396":
+		return "shmctl"
+	case "397":
+		return "shmat"
+	case "398":
+		return "shmdt"
+	case "399":
+		return "msgget"
+	case "400":
+		return "msgsnd"
+	case "401":
+		return "msgrcv"
+	case "402":
+		return "msgctl"
+	case "424":
+		return "pidfd_send_signal"
+	case "425":
+		return "io_uring_setup"
+	case "426":
+		return "io_uring_enter"
+	case "427":
+		return "io_uring_register"
+	case "428":
+		return "open_tree"
+	case "429":
+		return "move_mount"
+	case "430":
+		return "fsopen"
+	case "431":
+		return "fsconfig"
+	case "432":
+		return "fsmount"
+	case "433":
+		return "fspick"
+	case "434":
+		return "pidfd_open"
+	case "435":
+		return "clone3"
+	case "436":
+		return "close_range"
+	case "437":
+		return "openat2"
+	case "438":
+		return "pidfd_getfd"
+	case "439":
+		return "faccessat2"
+	case "440":
+		return "process_madvise"
+	case "441":
+		return "epoll_pwait2"
+	case "442":
+		return "mount_setattr"
+	case "443":
+		return "quotactl_fd"
+	case "444":
+		return "landlock_create_ruleset"
+	case "445":
+		return "landlock_add_rule"
+	case "446":
+		return "landlock_restrict_self"
+	case "447":
+		return "memfd_secret"
+	case "448":
+		return "process_mrelease"
+	case "449":
+		return "futex_waitv"
+	case "450":
+		return "set_mempolicy_home_node"
+	case "451":
+		return "cachestat"
+	case "452":
+		return "fchmodat2"
+	case "453":
+		return "map_shadow_stack"
+	case "454":
+		return "futex_wake"
+	case "455":
+		return "futex_wait"
+	case "456":
+		return "futex_requeue"
+	case "457":
+		return "statmount"
+	case "458":
+		return "listmount"
+	case "459":
+		return "lsm_get_self_attr"
+	case "460":
+		return "lsm_set_self_attr"
+	case "461":
+		return "lsm_list_modules"
+	case "462":
+		return "mseal"
+	case "463":
+		return "setxattrat"
+	case "464":
+		return "getxattrat"
+	case "465":
+		return "listxattrat"
+	case "466":
+		return "removexattrat"
+	case "467":
+		return "open_tree_attr"
+	case "468":
+		return "file_getattr"
+	case "469":
+		return "file_setattr"
+	case "470":
+		return "listns"
+	case "471":
+		return "rseq_slice_yield"
+	default:
+		return "Unsupported"
+	}
+}
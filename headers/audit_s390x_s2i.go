@@ -0,0 +1,791 @@
+package headers
+
+// SysMapS390x is a mapping between s390x syscall names and their integer values
+// The list is taken from the s390 unistd.h of the kernel (64 bit)
+// Unlike audit_x64.go the file name has the _s2i suffix, a _s390x suffix would restrict the file to GOARCH=s390x builds
+func SysMapS390x(syscall string) (name int) {
+	switch syscall {
+	case "exit":
+		return 1
+	case "fork":
+		return 2
+	case "read":
+		return 3
+	case "write":
+		return 4
+	case "open":
+		return 5
+	case "close":
+		return 6
+	case "restart_syscall":
+		return 7
+	case "creat":
+		return 8
+	case "link":
+		return 9
+	case "unlink":
+		return 10
+	case "execve":
+		return 11
+	case "chdir":
+		return 12
+	case "mknod":
+		return 14
+	case "chmod":
+		return 15
+	case "lseek":
+		return 19
+	case "getpid":
+		return 20
+	case "mount":
+		return 21
+	case "umount":
+		return 22
+	case "ptrace":
+		return 26
+	case "alarm":
+		return 27
+	case "pause":
+		return 29
+	case "utime":
+		return 30
+	case "access":
+		return 33
+	case "nice":
+		return 34
+	case "sync":
+		return 36
+	case "kill":
+		return 37
+	case "rename":
+		return 38
+	case "mkdir":
+		return 39
+	case "rmdir":
+		return 40
+	case "dup":
+		return 41
+	case "pipe":
+		return 42
+	case "times":
+		return 43
+	case "brk":
+		return 45
+	case "signal":
+		return 48
+	case "acct":
+		return 51
+	case "umount2":
+		return 52
+	case "ioctl":
+		return 54
+	case "fcntl":
+		return 55
+	case "setpgid":
+		return 57
+	case "umask":
+		return 60
+	case "chroot":
+		return 61
+	case "ustat":
+		return 62
+	case "dup2":
+		return 63
+	case "getppid":
+		return 64
+	case "getpgrp":
+		return 65
+	case "setsid":
+		return 66
+	case "sigaction":
+		return 67
+	case "sigsuspend":
+		return 72
+	case "sigpending":
+		return 73
+	case "sethostname":
+		return 74
+	case "setrlimit":
+		return 75
+	case "getrusage":
+		return 77
+	case "gettimeofday":
+		return 78
+	case "settimeofday":
+		return 79
+	case "symlink":
+		return 83
+	case "readlink":
+		return 85
+	case "uselib":
+		return 86
+	case "swapon":
+		return 87
+	case "reboot":
+		return 88
+	case "readdir":
+		return 89
+	case "mmap":
+		return 90
+	case "munmap":
+		return 91
+	case "truncate":
+		return 92
+	case "ftruncate":
+		return 93
+	case "fchmod":
+		return 94
+	case "getpriority":
+		return 96
+	case "setpriority":
+		return 97
+	case "statfs":
+		return 99
+	case "fstatfs":
+		return 100
+	case "socketcall":
+		return 102
+	case "syslog":
+		return 103
+	case "setitimer":
+		return 104
+	case "getitimer":
+		return 105
+	case "stat":
+		return 106
+	case "lstat":
+		return 107
+	case "fstat":
+		return 108
+	case "lookup_dcookie":
+		return 110
+	case "vhangup":
+		return 111
+	case "idle":
+		return 112
+	case "wait4":
+		return 114
+	case "swapoff":
+		return 115
+	case "sysinfo":
+		return 116
+	case "ipc":
+		return 117
+	case "fsync":
+		return 118
+	case "sigreturn":
+		return 119
+	case "clone":
+		return 120
+	case "setdomainname":
+		return 121
+	case "uname":
+		return 122
+	case "adjtimex":
+		return 124
+	case "mprotect":
+		return 125
+	case "sigprocmask":
+		return 126
+	case "create_module":
+		return 127
+	case "init_module":
+		return 128
+	case "delete_module":
+		return 129
+	case "get_kernel_syms":
+		return 130
+	case "quotactl":
+		return 131
+	case "getpgid":
+		return 132
+	case "fchdir":
+		return 133
+	case "bdflush":
+		return 134
+	case "sysfs":
+		return 135
+	case "personality":
+		return 136
+	case "afs_syscall":
+		return 137
+	case "getdents":
+		return 141
+	case "select":
+		return 142
+	case "flock":
+		return 143
+	case "msync":
+		return 144
+	case "readv":
+		return 145
+	case "writev":
+		return 146
+	case "getsid":
+		return 147
+	case "fdatasync":
+		return 148
+	case "_sysctl":
+		return 149
+	case "mlock":
+		return 150
+	case "munlock":
+		return 151
+	case "mlockall":
+		return 152
+	case "munlockall":
+		return 153
+	case "sched_setparam":
+		return 154
+	case "sched_getparam":
+		return 155
+	case "sched_setscheduler":
+		return 156
+	case "sched_getscheduler":
+		return 157
+	case "sched_yield":
+		return 158
+	case "sched_get_priority_max":
+		return 159
+	case "sched_get_priority_min":
+		return 160
+	case "sched_rr_get_interval":
+		return 161
+	case "nanosleep":
+		return 162
+	case "mremap":
+		return 163
+	case "query_module":
+		return 167
+	case "poll":
+		return 168
+	case "nfsservctl":
+		return 169
+	case "prctl":
+		return 172
+	case "rt_sigreturn":
+		return 173
+	case "rt_sigaction":
+		return 174
+	case "rt_sigprocmask":
+		return 175
+	case "rt_sigpending":
+		return 176
+	case "rt_sigtimedwait":
+		return 177
+	case "rt_sigqueueinfo":
+		return 178
+	case "rt_sigsuspend":
+		return 179
+	case "pread64":
+		return 180
+	case "pwrite64":
+		return 181
+	case "getcwd":
+		return 183
+	case "capget":
+		return 184
+	case "capset":
+		return 185
+	case "sigaltstack":
+		return 186
+	case "sendfile":
+		return 187
+	case "getpmsg":
+		return 188
+	case "putpmsg":
+		return 189
+	case "vfork":
+		return 190
+	case "getrlimit":
+		return 191
+	case "lchown":
+		return 198
+	case "getuid":
+		return 199
+	case "getgid":
+		return 200
+	case "geteuid":
+		return 201
+	case "getegid":
+		return 202
+	case "setreuid":
+		return 203
+	case "setregid":
+		return 204
+	case "getgroups":
+		return 205
+	case "setgroups":
+		return 206
+	case "fchown":
+		return 207
+	case "setresuid":
+		return 208
+	case "getresuid":
+		return 209
+	case "setresgid":
+		return 210
+	case "getresgid":
+		return 211
+	case "chown":
+		return 212
+	case "setuid":
+		return 213
+	case "setgid":
+		return 214
+	case "setfsuid":
+		return 215
+	case "setfsgid":
+		return 216
+	case "pivot_root":
+		return 217
+	case "mincore":
+		return 218
+	case "madvise":
+		return 219
+	case "getdents64":
+		return 220
+	case "readahead":
+		return 222
+	case "setxattr":
+		return 224
+	case "lsetxattr":
+		return 225
+	case "fsetxattr":
+		return 226
+	case "getxattr":
+		return 227
+	case "lgetxattr":
+		return 228
+	case "fgetxattr":
+		return 229
+	case "listxattr":
+		return 230
+	case "llistxattr":
+		return 231
+	case "flistxattr":
+		return 232
+	case "removexattr":
+		return 233
+	case "lremovexattr":
+		return 234
+	case "fremovexattr":
+		return 235
+	case "gettid":
+		return 236
+	case "tkill":
+		return 237
+	case "futex":
+		return 238
+	case "sched_setaffinity":
+		return 239
+	case "sched_getaffinity":
+		return 240
+	case "tgkill":
+		return 241
+	case "io_setup":
+		return 243
+	case "io_destroy":
+		return 244
+	case "io_getevents":
+		return 245
+	case "io_submit":
+		return 246
+	case "io_cancel":
+		return 247
+	case "exit_group":
+		return 248
+	case "epoll_create":
+		return 249
+	case "epoll_ctl":
+		return 250
+	case "epoll_wait":
+		return 251
+	case "set_tid_address":
+		return 252
+	case "fadvise64":
+		return 253
+	case "timer_create":
+		return 254
+	case "timer_settime":
+		return 255
+	case "timer_gettime":
+		return 256
+	case "timer_getoverrun":
+		return 257
+	case "timer_delete":
+		return 258
+	case "clock_settime":
+		return 259
+	case "clock_gettime":
+		return 260
+	case "clock_getres":
+		return 261
+	case "clock_nanosleep":
+		return 262
+	case "statfs64":
+		return 265
+	case "fstatfs64":
+		return 266
+	case "remap_file_pages":
+		return 267
+	case "mbind":
+		return 268
+	case "get_mempolicy":
+		return 269
+	case "set_mempolicy":
+		return 270
+	case "mq_open":
+		return 271
+	case "mq_unlink":
+		return 272
+	case "mq_timedsend":
+		return 273
+	case "mq_timedreceive":
+		return 274
+	case "mq_notify":
+		return 275
+	case "mq_getsetattr":
+		return 276
+	case "kexec_load":
+		return 277
+	case "add_key":
+		return 278
+	case "request_key":
+		return 279
+	case "keyctl":
+		return 280
+	case "waitid":
+		return 281
+	case "ioprio_set":
+		return 282
+	case "ioprio_get":
+		return 283
+	case "inotify_init":
+		return 284
+	case "inotify_add_watch":
+		return 285
+	case "inotify_rm_watch":
+		return 286
+	case "migrate_pages":
+		return 287
+	case "openat":
+		return 288
+	case "mkdirat":
+		return 289
+	case "mknodat":
+		return 290
+	case "fchownat":
+		return 291
+	case "futimesat":
+		return 292
+	case "newfstatat":
+		return 293
+	case "unlinkat":
+		return 294
+	case "renameat":
+		return 295
+	case "linkat":
+		return 296
+	case "symlinkat":
+		return 297
+	case "readlinkat":
+		return 298
+	case "fchmodat":
+		return 299
+	case "faccessat":
+		return 300
+	case "pselect6":
+		return 301
+	case "ppoll":
+		return 302
+	case "unshare":
+		return 303
+	case "set_robust_list":
+		return 304
+	case "get_robust_list":
+		return 305
+	case "splice":
+		return 306
+	case "sync_file_range":
+		return 307
+	case "tee":
+		return 308
+	case "vmsplice":
+		return 309
+	case "move_pages":
+		return 310
+	case "getcpu":
+		return 311
+	case "epoll_pwait":
+		return 312
+	case "utimes":
+		return 313
+	case "fallocate":
+		return 314
+	case "utimensat":
+		return 315
+	case "signalfd":
+		return 316
+	case "timerfd":
+		return 317
+	case "eventfd":
+		return 318
+	case "timerfd_create":
+		return 319
+	case "timerfd_settime":
+		return 320
+	case "timerfd_gettime":
+		return 321
+	case "signalfd4":
+		return 322
+	case "eventfd2":
+		return 323
+	case "inotify_init1":
+		return 324
+	case "pipe2":
+		return 325
+	case "dup3":
+		return 326
+	case "epoll_create1":
+		return 327
+	case "preadv":
+		return 328
+	case "pwritev":
+		return 329
+	case "rt_tgsigqueueinfo":
+		return 330
+	case "perf_event_open":
+		return 331
+	case "fanotify_init":
+		return 332
+	case "fanotify_mark":
+		return 333
+	case "prlimit64":
+		return 334
+	case "name_to_handle_at":
+		return 335
+	case "open_by_handle_at":
+		return 336
+	case "clock_adjtime":
+		return 337
+	case "syncfs":
+		return 338
+	case "setns":
+		return 339
+	case "process_vm_readv":
+		return 340
+	case "process_vm_writev":
+		return 341
+	case "s390_runtime_instr":
+		return 342
+	case "kcmp":
+		return 343
+	case "finit_module":
+		return 344
+	case "sched_setattr":
+		return 345
+	case "sched_getattr":
+		return 346
+	case "renameat2":
+		return 347
+	case "seccomp":
+		return 348
+	case "getrandom":
+		return 349
+	case "memfd_create":
+		return 350
+	case "bpf":
+		return 351
+	case "s390_pci_mmio_write":
+		return 352
+	case "s390_pci_mmio_read":
+		return 353
+	case "execveat":
+		return 354
+	case "userfaultfd":
+		return 355
+	case "membarrier":
+		return 356
+	case "recvmmsg":
+		return 357
+	case "sendmmsg":
+		return 358
+	case "socket":
+		return 359
+	case "socketpair":
+		return 360
+	case "bind":
+		return 361
+	case "connect":
+		return 362
+	case "listen":
+		return 363
+	case "accept4":
+		return 364
+	case "getsockopt":
+		return 365
+	case "setsockopt":
+		return 366
+	case "getsockname":
+		return 367
+	case "getpeername":
+		return 368
+	case "sendto":
+		return 369
+	case "sendmsg":
+		return 370
+	case "recvfrom":
+		return 371
+	case "recvmsg":
+		return 372
+	case "shutdown":
+		return 373
+	case "mlock2":
+		return 374
+	case "copy_file_range":
+		return 375
+	case "preadv2":
+		return 376
+	case "pwritev2":
+		return 377
+	case "s390_guarded_storage":
+		return 378
+	case "statx":
+		return 379
+	case "s390_sthyi":
+		return 380
+	case "kexec_file_load":
+		return 381
+	case "io_pgetevents":
+		return 382
+	case "rseq":
+		return 383
+	case "pkey_mprotect":
+		return 384
+	case "pkey_alloc":
+		return 385
+	case "pkey_free":
+		return 386
+	case "semtimedop":
+		return 392
+	case "semget":
+		return 393
+	case "semctl":
+		return 394
+	case "shmget":
+		return 395
+	case "shmctl":
+		return 396
+	case "shmat":
+		return 397
+	case "shmdt":
+		return 398
+	case "msgget":
+		return 399
+	case "msgsnd":
+		return 400
+	case "msgrcv":
+		return 401
+	case "msgctl":
+		return 402
+	case "pidfd_send_signal":
+		return 424
+	case "io_uring_setup":
+		return 425
+	case "io_uring_enter":
+		return 426
+	case "io_uring_register":
+		return 427
+	case "open_tree":
+		return 428
+	case "move_mount":
+		return 429
+	case "fsopen":
+		return 430
+	case "fsconfig":
+		return 431
+	case "fsmount":
+		return 432
+	case "fspick":
+		return 433
+	case "pidfd_open":
+		return 434
+	case "clone3":
+		return 435
+	case "close_range":
+		return 436
+	case "openat2":
+		return 437
+	case "pidfd_getfd":
+		return 438
+	case "faccessat2":
+		return 439
+	case "process_madvise":
+		return 440
+	case "epoll_pwait2":
+		return 441
+	case "mount_setattr":
+		return 442
+	case "quotactl_fd":
+		return 443
+	case "landlock_create_ruleset":
+		return 444
+	case "landlock_add_rule":
+		return 445
+	case "landlock_restrict_self":
+		return 446
+	case "memfd_secret":
+		return 447
+	case "process_mrelease":
+		return 448
+	case "futex_waitv":
+		return 449
+	case "set_mempolicy_home_node":
+		return 450
+	case "cachestat":
+		return 451
+	case "fchmodat2":
+		return 452
+	case "map_shadow_stack":
+		return 453
+	case "futex_wake":
+		return 454
+	case "futex_wait":
+		return 455
+	case "futex_requeue":
+		return 456
+	case "statmount":
+		return 457
+	case "listmount":
+		return 458
+	case "lsm_get_self_attr":
+		return 459
+	case "lsm_set_self_attr":
+		return 460
+	case "lsm_list_modules":
+		return 461
+	case "mseal":
+		return 462
+	case "setxattrat":
+		return 463
+	case "getxattrat":
+		return 464
+	case "listxattrat":
+		return 465
+	case "removexattrat":
+		return 466
+	case "open_tree_attr":
+		return 467
+	case "file_getattr":
+		return 468
+	case "file_setattr":
+		return 469
+	case "listns":
+		return 470
+	case "rseq_slice_yield":
+		return 471
+	default:
+		return -1
+	}
+}
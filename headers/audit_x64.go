@@ -1,10 +1,7 @@
 package headers
 
 // SysMapX64 is a mapping between x64 syscall names and their integer values
-// The list is taken from x86_64_table.h of auditd library
-/*
-var SysMapX64 = map[string]int{
-*/
+// The list is taken from the x86_64 unistd_64.h of the kernel
 func SysMapX64(syscall string) (name int) {
 	switch syscall {
 	case "read":
@@ -373,7 +370,7 @@ func SysMapX64(syscall string) (name int) {
 		return 181
 	case "putpmsg":
 		return 182
-	case "afs_":
+	case "afs_syscall":
 		return 183
 	case "tuxcall":
 		return 184
@@ -445,7 +442,7 @@ func SysMapX64(syscall string) (name int) {
 		return 217
 	case "set_tid_address":
 		return 218
-	case "restart_":
+	case "restart_syscall":
 		return 219
 	case "semtimedop":
 		return 220
@@ -661,6 +658,122 @@ func SysMapX64(syscall string) (name int) {
 		return 325
 	case "copy_file_range":
 		return 326
+	case "preadv2":
+		return 327
+	case "pwritev2":
+		return 328
+	case "pkey_mprotect":
+		return 329
+	case "pkey_alloc":
+		return 330
+	case "pkey_free":
+		return 331
+	case "statx":
+		return 332
+	case "io_pgetevents":
+		return 333
+	case "rseq":
+		return 334
+	case "uretprobe":
+		return 335
+	case "uprobe":
+		return 336
+	case "pidfd_send_signal":
+		return 424
+	case "io_uring_setup":
+		return 425
+	case "io_uring_enter":
+		return 426
+	case "io_uring_register":
+		return 427
+	case "open_tree":
+		return 428
+	case "move_mount":
+		return 429
+	case "fsopen":
+		return 430
+	case "fsconfig":
+		return 431
+	case "fsmount":
+		return 432
+	case "fspick":
+		return 433
+	case "pidfd_open":
+		return 434
+	case "clone3":
+		return 435
+	case "close_range":
+		return 436
+	case "openat2":
+		return 437
+	case "pidfd_getfd":
+		return 438
+	case "faccessat2":
+		return 439
+	case "process_madvise":
+		return 440
+	case "epoll_pwait2":
+		return 441
+	case "mount_setattr":
+		return 442
+	case "quotactl_fd":
+		return 443
+	case "landlock_create_ruleset":
+		return 444
+	case "landlock_add_rule":
+		return 445
+	case "landlock_restrict_self":
+		return 446
+	case "memfd_secret":
+		return 447
+	case "process_mrelease":
+		return 448
+	case "futex_waitv":
+		return 449
+	case "set_mempolicy_home_node":
+		return 450
+	case "cachestat":
+		return 451
+	case "fchmodat2":
+		return 452
+	case "map_shadow_stack":
+		return 453
+	case "futex_wake":
+		return 454
+	case "futex_wait":
+		return 455
+	case "futex_requeue":
+		return 456
+	case "statmount":
+		return 457
+	case "listmount":
+		return 458
+	case "lsm_get_self_attr":
+		return 459
+	case "lsm_set_self_attr":
+		return 460
+	case "lsm_list_modules":
+		return 461
+	case "mseal":
+		return 462
+	case "setxattrat":
+		return 463
+	case "getxattrat":
+		return 464
+	case "listxattrat":
+		return 465
+	case "removexattrat":
+		return 466
+	case "open_tree_attr":
+		return 467
+	case "file_getattr":
+		return 468
+	case "file_setattr":
+		return 469
+	case "listns":
+		return 470
+	case "rseq_slice_yield":
+		return 471
 	default:
 		return -1
 	}
@@ -1,10 +1,7 @@
 package headers
 
 // ReverseSysMapX64 is a mapping between x64 syscall integer values and their names
-// The list is taken from x86_64_table.h of auditd library
-/*
-var ReverseSysMapX64 = map[string]string{
-*/
+// The list is taken from the x86_64 unistd_64.h of the kernel
 func ReverseSysMapX64(syscall string) (name string) {
 	switch syscall {
 	case "0":
@@ -374,7 +371,7 @@ func ReverseSysMapX64(syscall string) (name string) {
 	case "182":
 		return "putpmsg"
 	case "183":
-		return "afs_"
+		return "afs_syscall"
 	case "184":
 		return "tuxcall"
 	case "185":
@@ -446,7 +443,7 @@ func ReverseSysMapX64(syscall string) (name string) {
 	case "218":
 		return "set_tid_address"
 	case "219":
-		return "restart_"
+		return "restart_syscall"
 	case "220":
 		return "semtimedop"
 	case "221":
@@ -661,6 +658,122 @@ func ReverseSysMapX64(syscall string) (name string) {
 		return "mlock2"
 	case "326":
 		return "copy_file_range"
+	case "327":
+		return "preadv2"
+	case "328":
+		return "pwritev2"
+	case "329":
+		return "pkey_mprotect"
+	case "330":
+		return "pkey_alloc"
+	case "331":
+		return "pkey_free"
+	case "332":
+		return "statx"
+	case "333":
+		return "io_pgetevents"
+	case "334":
+		return "rseq"
+	case "335":
+		return "uretprobe"
+	case "336":
+		return "uprobe"
+	case "424":
+		return "pidfd_send_signal"
+	case "425":
+		return "io_uring_setup"
+	case "426":
+		return "io_uring_enter"
+	case "427":
+		return "io_uring_register"
+	case "428":
+		return "open_tree"
+	case "429":
+		return "move_mount"
+	case "430":
+		return "fsopen"
+	case "431":
+		return "fsconfig"
+	case "432":
+		return "fsmount"
+	case "433":
+		return "fspick"
+	case "434":
+		return "pidfd_open"
+	case "435":
+		return "clone3"
+	case "436":
+		return "close_range"
+	case "437":
+		return "openat2"
+	case "438":
+		return "pidfd_getfd"
+	case "439":
+		return "faccessat2"
+	case "440":
+		return "process_madvise"
+	case "441":
+		return "epoll_pwait2"
+	case "442":
+		return "mount_setattr"
+	case "443":
+		return "quotactl_fd"
+	case "444":
+		return "landlock_create_ruleset"
+	case "445":
+		return "landlock_add_rule"
+	case "446":
+		return "landlock_restrict_self"
+	case "447":
+		return "memfd_secret"
+	case "448":
+		return "process_mrelease"
+	case "449":
+		return "futex_waitv"
+	case "450":
+		return "set_mempolicy_home_node"
+	case "451":
+		return "cachestat"
+	case "452":
+		return "fchmodat2"
+	case "453":
+		return "map_shadow_stack"
+	case "454":
+		return "futex_wake"
+	case "455":
+		return "futex_wait"
+	case "456":
+		return "futex_requeue"
+	case "457":
+		return "statmount"
+	case "458":
+		return "listmount"
+	case "459":
+		return "lsm_get_self_attr"
+	case "460":
+		return "lsm_set_self_attr"
+	case "461":
+		return "lsm_list_modules"
+	case "462":
+		return "mseal"
+	case "463":
+		return "setxattrat"
+	case "464":
+		return "getxattrat"
+	case "465":
+		return "listxattrat"
+	case "466":
+		return "removexattrat"
+	case "467":
+		return "open_tree_attr"
+	case "468":
+		return "file_getattr"
+	case "469":
+		return "file_setattr"
+	case "470":
+		return "listns"
+	case "471":
+		return "rseq_slice_yield"
 	default:
 		return "Unsupported"
 	}
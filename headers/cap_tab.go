@@ -40,4 +40,7 @@ var CapabLookup = map[int]string{
 	35: "wake_alarm",
 	36: "block_suspend",
 	37: "audit_read",
+	38: "perfmon",
+	39: "bpf",
+	40: "checkpoint_restore",
 }
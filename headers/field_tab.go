@@ -26,6 +26,7 @@ var FieldMap = map[string]int{
 	"obj_type":      21,
 	"obj_lev_low":   22,
 	"obj_lev_high":  23,
+	"sessionid":     25,
 	"devmajor":      100,
 	"devminor":      101,
 	"inode":         102,
@@ -38,6 +39,7 @@ var FieldMap = map[string]int{
 	"obj_uid":       109,
 	"obj_gid":       110,
 	"field_compare": 111,
+	"exe":           112,
 	"a0":            200,
 	"a1":            201,
 	"a2":            202,
@@ -0,0 +1,23 @@
+package headers
+
+// Location: include/uapi/asm-generic/fcntl.h
+// the access mode (O_RDONLY, O_WRONLY, O_RDWR) is held in the lowest 2 bits
+var OpenFlagLookup = map[int]string{
+	00000100:  "O_CREAT",
+	00000200:  "O_EXCL",
+	00000400:  "O_NOCTTY",
+	00001000:  "O_TRUNC",
+	00002000:  "O_APPEND",
+	00004000:  "O_NONBLOCK",
+	00010000:  "O_DSYNC",
+	00020000:  "O_ASYNC",
+	00040000:  "O_DIRECT",
+	00100000:  "O_LARGEFILE",
+	00200000:  "O_DIRECTORY",
+	00400000:  "O_NOFOLLOW",
+	01000000:  "O_NOATIME",
+	02000000:  "O_CLOEXEC",
+	04000000:  "O_SYNC",
+	010000000: "O_PATH",
+	020000000: "O_TMPFILE",
+}
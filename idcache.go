@@ -0,0 +1,92 @@
+package libaudit
+
+import (
+	"os/user"
+	"sync"
+	"time"
+)
+
+// resolveIDs controls whether the interpretation resolves ids to names, see SetResolveIDs
+var resolveIDs = newAtomicBool(true)
+
+// SetResolveIDs controls whether interpreting events (ParseAuditEvent, NewAuditEvent and the receivers) resolves
+// the uid fields (uid, auid, euid, ouid ...) to user names and the gid fields to group names like ausearch -i.
+// Ids without name are interpreted as unknown(<id>). It is set by default, without it the ids are kept.
+func SetResolveIDs(resolve bool) {
+	resolveIDs.set(resolve)
+}
+
+// idNameCache caches the names of the interpreted ids so that NSS (/etc/passwd, LDAP ...) isn't queried
+// for every event, names which can't be resolved are cached as well
+type idNameCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]idName
+}
+
+// idName is a cached name, ok is false for ids without name
+type idName struct {
+	name    string
+	ok      bool
+	expires time.Time
+}
+
+var (
+	userNames  = &idNameCache{ttl: time.Minute, entries: make(map[string]idName)}
+	groupNames = &idNameCache{ttl: time.Minute, entries: make(map[string]idName)}
+)
+
+// SetIDNameCacheTTL sets the time the names of resolved ids are cached for (SetResolveIDs), changes of
+// the user and group databases show once the cached names expired. The default is a minute, 0 disables
+// the cache. Setting it drops the cached names.
+func SetIDNameCacheTTL(ttl time.Duration) {
+	for _, c := range []*idNameCache{userNames, groupNames} {
+		c.mu.Lock()
+		c.ttl = ttl
+		c.entries = make(map[string]idName)
+		c.mu.Unlock()
+	}
+}
+
+// lookup returns the name of id, resolving it with resolve unless it is cached
+func (c *idNameCache) lookup(id string, resolve func(string) (string, error)) (string, bool) {
+	now := time.Now()
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	ttl := c.ttl
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.name, e.ok
+	}
+	// not under the lock, NSS lookups can be slow
+	name, err := resolve(id)
+	e = idName{name: name, ok: err == nil, expires: now.Add(ttl)}
+	if ttl > 0 {
+		c.mu.Lock()
+		if len(c.entries) >= maxCachedIDNames {
+			c.entries = make(map[string]idName)
+		}
+		c.entries[id] = e
+		c.mu.Unlock()
+	}
+	return e.name, e.ok
+}
+
+// maxCachedIDNames bounds the size of the id caches, they are dropped when full
+const maxCachedIDNames = 4096
+
+func lookupUserName(uid string) (string, error) {
+	u, err := user.LookupId(uid)
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+func lookupGroupName(gid string) (string, error) {
+	g, err := user.LookupGroupId(gid)
+	if err != nil {
+		return "", err
+	}
+	return g.Name, nil
+}
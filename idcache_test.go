@@ -0,0 +1,64 @@
+package libaudit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIDNameCache(t *testing.T) {
+	var calls int
+	resolve := func(id string) (string, error) {
+		calls++
+		if id == "0" {
+			return "root", nil
+		}
+		return "", fmt.Errorf("unknown id %v", id)
+	}
+	c := &idNameCache{ttl: time.Minute, entries: make(map[string]idName)}
+	for i := 0; i < 3; i++ {
+		if name, ok := c.lookup("0", resolve); !ok || name != "root" {
+			t.Errorf("lookup: expected root, found %q %v", name, ok)
+		}
+		if _, ok := c.lookup("1234", resolve); ok {
+			t.Errorf("lookup: expected no name for 1234")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("lookup: expected 2 lookups, found %v", calls)
+	}
+
+	// expired and disabled caches resolve again
+	c.entries["0"] = idName{name: "root", ok: true, expires: time.Now().Add(-time.Second)}
+	c.lookup("0", resolve)
+	c.ttl, c.entries = 0, make(map[string]idName)
+	c.lookup("0", resolve)
+	c.lookup("0", resolve)
+	if calls != 5 {
+		t.Errorf("lookup: expected 5 lookups, found %v", calls)
+	}
+}
+
+func TestResolveIDs(t *testing.T) {
+	msg := `audit(1464163771.720:020): arch=c000003e syscall=2 success=yes exit=3 ppid=1 pid=2 auid=4294967295 uid=0 gid=0 euid=0`
+	x, err := ParseAuditEvent(msg, AUDIT_SYSCALL, true)
+	if err != nil {
+		t.Fatalf("ParseAuditEvent failed %v", err)
+	}
+	if x.Data["uid"] != "root" || x.Data["gid"] != "root" || x.Data["auid"] != "unknown(4294967295)" {
+		t.Errorf("ParseAuditEvent: unexpected ids uid=%v gid=%v auid=%v", x.Data["uid"], x.Data["gid"], x.Data["auid"])
+	}
+	if p, err := x.ProcessInfo(); err != nil || p.UID != 0 || p.GID != 0 || p.AUID != -1 {
+		t.Errorf("ProcessInfo: unexpected process %+v %v", p, err)
+	}
+
+	SetResolveIDs(false)
+	defer SetResolveIDs(true)
+	x, err = ParseAuditEvent(msg, AUDIT_SYSCALL, true)
+	if err != nil {
+		t.Fatalf("ParseAuditEvent failed %v", err)
+	}
+	if x.Data["uid"] != "0" || x.Data["gid"] != "0" || x.Data["auid"] != "4294967295" || x.Data["syscall"] != "open" {
+		t.Errorf("ParseAuditEvent: unexpected fields %v", x.Data)
+	}
+}
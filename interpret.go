@@ -1,18 +1,14 @@
 package libaudit
 
 import (
-	"bytes"
 	"encoding/hex"
 	"fmt"
-	"net"
-	"os/user"
-	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/lacework/libaudit-go/headers"
-	"github.com/lunixbochs/struc"
 	"github.com/pkg/errors"
 )
 
@@ -106,19 +102,21 @@ func interpretField(fieldName string, fieldValue string, msgType auditConstant,
 			return "", errors.Wrap(err, "UID interpretation failed")
 		}
 	case typeGID:
-		// printGID is currently only a stub
 		result, err = printGID(fieldValue)
 		if err != nil {
 			return "", errors.Wrap(err, "GID interpretation failed")
 		}
 
 	case typeSyscall:
-		result, err = printSyscall(fieldValue)
+		result, err = printSyscall(r)
 		if err != nil {
 			return "", errors.Wrap(err, "syscall interpretation failed")
 		}
 	case typeArch:
-		return printArch()
+		result, err = printArch(fieldValue)
+		if err != nil {
+			return "", errors.Wrap(err, "arch interpretation failed")
+		}
 	case typeExit:
 		result, err = printExit(fieldValue) // peek on exit codes (stderror)
 		if err != nil {
@@ -195,22 +193,19 @@ func interpretField(fieldName string, fieldValue string, msgType auditConstant,
 			return "", errors.Wrap(err, "list interpretation failed")
 		}
 	case typeTTYData:
-		// TODO: add printTTYData (see interpret.c (auparse) for ideas)
-		// result, err = printTTYData(fieldValue)
-		// if err != nil {
-		// 	return "", errors.Wrap(err, "tty interpretation failed")
-		// }
+		// keep the captured bytes hex encoded, they can contain anything (including
+		// separators), (*AuditEvent).TTYData renders them
+		result = fieldValue
 	case typeSession:
 		result, err = printSession(fieldValue)
 		if err != nil {
 			return "", errors.Wrap(err, "session interpretation failed")
 		}
 	case typeCapBitmap:
-		// TODO: add printCapBitMap (see interpret.c (auparse) for ideas)
-		// result, err = printCapBitMap(fieldValue)
-		// if err != nil {
-		// 	return "", errors.Wrap(err, "cap bitmap interpretation failed")
-		// }
+		result, err = printCapBitmap(fieldValue)
+		if err != nil {
+			return "", errors.Wrap(err, "cap bitmap interpretation failed")
+		}
 	case typeNFProto:
 		result, err = printNFProto(fieldValue)
 		if err != nil {
@@ -254,8 +249,7 @@ func interpretField(fieldName string, fieldValue string, msgType auditConstant,
 			return "", errors.Wrap(err, "mmap interpretation failed")
 		}
 	case typeProctile:
-		//printing proctitle is same as printing escaped
-		result, err = printEscaped(fieldValue)
+		result, err = printProctitle(fieldValue)
 		if err != nil {
 			return "", errors.Wrap(err, "proctitle interpretation failed")
 		}
@@ -271,30 +265,45 @@ func interpretField(fieldName string, fieldValue string, msgType auditConstant,
 }
 
 func printUID(fieldValue string) (string, error) {
-
-	name, err := user.LookupId(fieldValue)
-	if err != nil {
+	if !resolveIDs.get() {
+		return fieldValue, nil
+	}
+	name, ok := userNames.lookup(fieldValue, lookupUserName)
+	if !ok {
 		return "unknown(" + fieldValue + ")", nil
 	}
-	return name.Username, nil
+	return name, nil
 }
 
-// No standard function until Go 1.7
 func printGID(fieldValue string) (string, error) {
-	return fieldValue, nil
+	if !resolveIDs.get() {
+		return fieldValue, nil
+	}
+	name, ok := groupNames.lookup(fieldValue, lookupGroupName)
+	if !ok {
+		return "unknown(" + fieldValue + ")", nil
+	}
+	return name, nil
 }
 
-func printSyscall(fieldValue string) (string, error) {
-	//NOTE: considering only x64 machines
-	name, err := AuditSyscallToName(fieldValue)
+func printSyscall(r record) (string, error) {
+	name, err := recordSyscallName(r)
 	if err != nil {
 		return "", errors.Wrap(err, "syscall parsing failed")
 	}
 	return name, nil
 }
 
-func printArch() (string, error) {
-	return runtime.GOARCH, nil
+func printArch(fieldValue string) (string, error) {
+	arch, err := parseArch(fieldValue)
+	if err != nil {
+		return "", err
+	}
+	if name, ok := archNames[arch]; ok {
+		return name, nil
+	}
+	// like auparse, archs without name don't fail the event
+	return fmt.Sprintf("unknown-elf-type(%x)", arch), nil
 }
 
 func printExit(fieldValue string) (string, error) {
@@ -417,134 +426,11 @@ func printModeShortInt(ival int64) (string, error) {
 }
 
 func printSockAddr(fieldValue string) (string, error) {
-	// representations of c struct to unpack bytestream into
-	type sockaddr struct {
-		Sa_family uint16   `struc:"uint16,little"`   // address family, AF_xxx
-		Sa_data   [14]byte `struc:"[14]byte,little"` // 14 bytes of protocol address
-	}
-
-	type sockaddr_un struct {
-		Sun_family uint16    `struc:"uint16,little"`    /* AF_UNIX */
-		Sun_path   [108]byte `struc:"[108]byte,little"` /* pathname */
-	}
-
-	type sockaddr_nl struct {
-		Sun_family uint16 `struc:"uint16,little"` /* AF_NETLINK */
-		Nl_pad     uint16 `struc:"uint16,little"` /* Zero. */
-		Nl_pid     int32  `struc:"int32,little"`  /* Port ID. */
-		Nl_groups  uint32 `struc:"uint32,little"` /* Multicast groups mask. */
-	}
-
-	type sockaddr_ll struct {
-		Sll_family   uint16  `struc:"uint16,little"`  /* Always AF_PACKET */
-		Sll_protocol uint16  `struc:"uint16,little"`  /* Physical-layer protocol */
-		Sll_ifindex  int32   `struc:"int32,little"`   /* Interface number */
-		Sll_hatype   uint16  `struc:"uint16,little"`  /* ARP hardware type */
-		Sll_pkttype  byte    `struc:"byte,little"`    /* Packet type */
-		Sll_halen    byte    `struc:"byte,little"`    /* Length of address */
-		Sll_addr     [8]byte `struc:"[8]byte,little"` /* Physical-layer address */
-	}
-
-	type sockaddr_in struct {
-		Sin_family uint16  `struc:"uint16,little"` // e.g. AF_INET, AF_INET6
-		Sin_port   uint16  `struc:"uint16,big"`    // port in network byte order
-		In_addr    [4]byte `struc:"[4]byte,big"`   // address in network byte order
-		Sin_zero   [8]byte `struc:"[8]byte,little"`
-	}
-
-	type sockaddr_in6 struct {
-		Sin6_family   uint16   `struc:"uint16,little"` // address family, AF_INET6
-		Sin6_port     uint16   `struc:"uint16,big"`    // port in network byte order
-		Sin6_flowinfo uint32   `struc:"uint32,little"` // IPv6 flow information
-		Sin6_addr     [16]byte `struc:"[16]byte,big"`  // IPv6 address
-		Sin6_scope_id uint32   `struc:"uint32,little"` // Scope ID
-	}
-
-	var name string
-	var s sockaddr
-
-	bytestr, err := hex.DecodeString(fieldValue)
-	if err != nil {
-		return fieldValue, errors.Wrap(err, "sockaddr parsing failed")
-	}
-
-	buf := bytes.NewBuffer(bytestr)
-	err = struc.Unpack(buf, &s)
-
+	a, err := decodeSockAddr(fieldValue)
 	if err != nil {
-		return fieldValue, errors.Wrap(err, "sockaddr decoding failed")
+		return fieldValue, err
 	}
-	family := int(s.Sa_family)
-
-	if _, ok := headers.SocketFamLookup[int(family)]; !ok {
-		return ("unknown family (" + strconv.FormatInt(int64(family), 10) + ")"), nil
-	}
-
-	errstring := headers.SocketFamLookup[family] + " (error resolving addr)"
-
-	switch family {
-
-	case syscall.AF_LOCAL:
-		var p sockaddr_un
-		nbuf := bytes.NewBuffer(bytestr)
-
-		err = struc.Unpack(nbuf, &p)
-		if err != nil {
-			return fieldValue, errors.Wrap(err, errstring)
-		}
-		name = headers.SocketFamLookup[family] + " " + string(p.Sun_path[:])
-		return name, nil
-
-	case syscall.AF_INET:
-		var ip4 sockaddr_in
-
-		nbuf := bytes.NewBuffer(bytestr)
-		err = struc.Unpack(nbuf, &ip4)
-		if err != nil {
-			return fieldValue, errors.Wrap(err, errstring)
-		}
-		addrBytes := ip4.In_addr[:]
-		var x net.IP = addrBytes
-		name = headers.SocketFamLookup[family] + " host:" + x.String() + " serv:" + strconv.FormatInt(int64(ip4.Sin_port), 10)
-		return name, nil
-
-	case syscall.AF_INET6:
-		var ip6 sockaddr_in6
-		nbuf := bytes.NewBuffer(bytestr)
-		err = struc.Unpack(nbuf, &ip6)
-		if err != nil {
-			return fieldValue, errors.Wrap(err, errstring)
-		}
-		addrBytes := ip6.Sin6_addr[:]
-		var x net.IP = addrBytes
-		name = headers.SocketFamLookup[family] + " host:" + x.String() + " serv:" + strconv.FormatInt(int64(ip6.Sin6_port), 10)
-		return name, nil
-
-	case syscall.AF_NETLINK:
-		var n sockaddr_nl
-
-		nbuf := bytes.NewBuffer(bytestr)
-		err = struc.Unpack(nbuf, &n)
-		if err != nil {
-			return fieldValue, errors.Wrap(err, errstring)
-		}
-		name = headers.SocketFamLookup[family] + " pid:" + strconv.FormatInt(int64(n.Nl_pid), 10)
-		return name, nil
-
-	case syscall.AF_PACKET:
-		var l sockaddr_ll
-
-		nbuf := bytes.NewBuffer(bytestr)
-		err = struc.Unpack(nbuf, &l)
-		if err != nil {
-			return fieldValue, errors.Wrap(err, errstring)
-		}
-		// TODO: decide on kind of information to return
-		// currently only returning the family name
-		// name = fmt.Sprintf("%s pid:%u", famLookup[family], l.)
-		return headers.SocketFamLookup[family], nil
-	}
-	return headers.SocketFamLookup[family], nil
+	return a.String(), nil
 }
 
 // this is currently just a stub as its only used in RHEL kernels
@@ -572,6 +458,19 @@ func printEscaped(fieldValue string) (string, error) {
 	return newStr, nil
 }
 
+// printProctitle decodes the command line of a proctitle field, the arguments are separated by NUL bytes
+// which are printed as spaces like ausearch does
+func printProctitle(fieldValue string) (string, error) {
+	if keepRawProctitle.get() {
+		return fieldValue, nil
+	}
+	newStr, err := printEscaped(fieldValue)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(strings.TrimRight(newStr, "\x00"), "\x00", " ", -1), nil
+}
+
 func unescape(fieldvalue string) string {
 	if strings.HasPrefix(fieldvalue, "(") {
 		return fieldvalue
@@ -614,6 +513,59 @@ func printCapabilities(fieldValue string, base int) (string, error) {
 	return "unknown capability(" + strconv.FormatInt(int64(ival), 10) + ")", nil
 }
 
+// capabilityNames returns the names of the capabilities set in the hex formatted mask, format converts
+// the names of the capability table (net_admin) to the returned form
+func capabilityNames(hexMask string, format func(string) string) ([]string, error) {
+	mask, err := strconv.ParseUint(hexMask, 16, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "capability mask parsing failed")
+	}
+	names := []string{}
+	for i := 0; i < 64; i++ {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		if cap, ok := headers.CapabLookup[i]; ok {
+			names = append(names, format(cap))
+		} else {
+			names = append(names, "unknown capability("+strconv.Itoa(i)+")")
+		}
+	}
+	return names, nil
+}
+
+// joinCapabilities joins capability names for a field value, the empty set is "none" like auparse prints it
+func joinCapabilities(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ",")
+}
+
+// capName returns the constant name (CAP_NET_ADMIN) of a capability of the capability table
+func capName(cap string) string {
+	return "CAP_" + strings.ToUpper(cap)
+}
+
+// DecodeCapabilities returns the names (CAP_CHOWN, CAP_NET_ADMIN, ...) of the capabilities
+// set in a hex formatted capability mask as found in the cap_fp, cap_fi, cap_pe, cap_pi,
+// cap_pp and cap_pa fields.
+func DecodeCapabilities(hexMask string) ([]string, error) {
+	names, err := capabilityNames(hexMask, capName)
+	if err != nil {
+		return nil, errors.Wrap(err, "DecodeCapabilities failed")
+	}
+	return names, nil
+}
+
+func printCapBitmap(fieldValue string) (string, error) {
+	names, err := capabilityNames(fieldValue, func(cap string) string { return cap })
+	if err != nil {
+		return "", err
+	}
+	return joinCapabilities(names), nil
+}
+
 func printSuccess(fieldValue string) (string, error) {
 
 	ival, err := strconv.ParseInt(fieldValue, 10, 64)
@@ -623,9 +575,9 @@ func printSuccess(fieldValue string) (string, error) {
 		return fieldValue, nil
 	}
 	const (
-		sUnset  = -1
-		sFailed = iota
-		sSuccess
+		sUnset   = -1
+		sFailed  = 0
+		sSuccess = 1
 	)
 
 	switch int(ival) {
@@ -758,15 +710,7 @@ func printCloneFlags(fieldValue string) (string, error) {
 		return "", errors.Wrap(err, "clone flags parsing failed")
 	}
 
-	var name string
-	for key, val := range headers.CloneLookUp {
-		if key&int(ival) > 0 {
-			if len(name) > 0 {
-				name += "|"
-			}
-			name += val
-		}
-	}
+	name := strings.Join(flagNames(ival, headers.CloneLookUp), "|")
 	var cloneSignal = ival & 0xFF
 	if cloneSignal > 0 && cloneSignal < 32 {
 		if len(name) > 0 {
@@ -952,10 +896,18 @@ func printSocketType(fieldValue string) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "socket type parsing failed")
 	}
-	if _, ok := headers.SockTypeLookup[int(ival)]; !ok {
+	// the type may be combined with SOCK_NONBLOCK and SOCK_CLOEXEC
+	name, ok := headers.SockTypeLookup[int(ival&0xf)]
+	if !ok || ival&^(0xf|syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC) != 0 {
 		return "unknown socket type(" + strconv.FormatInt(int64(ival), 10) + ")", nil
 	}
-	return headers.SockTypeLookup[int(ival)], nil
+	if ival&syscall.SOCK_NONBLOCK != 0 {
+		name += "|SOCK_NONBLOCK"
+	}
+	if ival&syscall.SOCK_CLOEXEC != 0 {
+		name += "|SOCK_CLOEXEC"
+	}
+	return name, nil
 }
 
 func printSched(fieldValue string) (string, error) {
@@ -973,16 +925,41 @@ func printSched(fieldValue string) (string, error) {
 	return headers.SchedLookup[int(ival)], nil
 }
 
-// TODO: add interpretation
-// see auparse -> interpret.c -> print_open_flags() for ideas
-// useful for debugging rather than forensics
-// actual policy is to filter either on open or write or both
-// and emit msg that this happened so if its opened in r,rw, etc.
-// all endup looking the same i.e READ or WRITE
+// flagNames returns the names of the flags set in ival ordered by their value
+func flagNames(ival int64, table map[int]string) []string {
+	var (
+		keys  []int
+		names []string
+	)
+	for key := range table {
+		if key&int(ival) != 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Ints(keys)
+	for _, key := range keys {
+		names = append(names, table[key])
+	}
+	return names
+}
+
 // auparse specific table is open-flagtab.h
 func printOpenFlags(fieldValue string) (string, error) {
-	// look at table of values from /usr/include/asm-generic/fcntl.h
-	return fieldValue, nil
+	ival, err := strconv.ParseInt(fieldValue, 16, 64)
+	if err != nil {
+		return "", errors.Wrap(err, "open flags parsing failed")
+	}
+	names := []string{"O_RDONLY"}
+	switch ival & syscall.O_ACCMODE {
+	case syscall.O_WRONLY:
+		names[0] = "O_WRONLY"
+	case syscall.O_RDWR:
+		names[0] = "O_RDWR"
+	case syscall.O_ACCMODE:
+		names[0] = "O_ACCMODE"
+	}
+	names = append(names, flagNames(ival, headers.OpenFlagLookup)...)
+	return strings.Join(names, "|"), nil
 }
 
 // policy is to only log success or denial but not read the actual value
@@ -1301,19 +1278,11 @@ func printProt(fieldValue string, isMmap int) (string, error) {
 		return "PROT_NONE", nil
 	}
 
-	var name string
-	for key, val := range headers.ProtLookUp {
-		if key&int(ival) > 0 {
-			if len(name) > 0 {
-				name += "|"
-			}
-			// skip last key if isMmap == 0
-			if isMmap == 0 && val == "PROT_SEM" {
-				continue
-			}
-			name += val
-		}
+	// PROT_SEM is only valid for mmap
+	if isMmap == 0 {
+		ival &^= 0x08
 	}
+	name := strings.Join(flagNames(ival, headers.ProtLookUp), "|")
 
 	if len(name) == 0 {
 		return "0x" + fieldValue, nil
@@ -1410,18 +1379,11 @@ func printMmap(fieldValue string) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "mmap parsing failed")
 	}
-	var name string
+	names := flagNames(ival, headers.MmapLookUp)
 	if ival&0x0F == 0 {
-		name += "MAP_FILE"
-	}
-	for key, val := range headers.MmapLookUp {
-		if key&int(ival) > 0 {
-			if len(name) > 0 {
-				name += "|"
-			}
-			name += val
-		}
+		names = append([]string{"MAP_FILE"}, names...)
 	}
+	name := strings.Join(names, "|")
 
 	if len(name) == 0 {
 		return "0x" + fieldValue, nil
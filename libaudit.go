@@ -3,8 +3,6 @@ Package libaudit is a client library in pure Go for talking with audit framework
 It provides API for dealing with audit related tasks like setting audit rules, deleting audit rules etc.
 The idea is to provide the same set of API as auditd (linux audit daemon).
 
-NOTE: Currently the library is only applicable for x64 architecture.
-
 Example usage of the library:
 
 	package main
@@ -101,9 +99,15 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"github.com/pkg/errors"
@@ -116,9 +120,10 @@ type NetlinkMessage syscall.NetlinkMessage
 
 // auditStatus is the c compatible struct of audit_status (libaudit.h).
 // It is used for passing information involving status of audit services.
+// Like the netlink header it is exchanged in host byte order (nativeEndian).
 type auditStatus struct {
 	Mask            uint32 /* Bit mask for valid entries */
-	Enabled         uint32 /* 1 = enabled, 0 = disabled */
+	Enabled         uint32 /* 1 = enabled, 0 = disabled, 2 = locked */
 	Failure         uint32 /* Failure-to-log action */
 	Pid             uint32 /* pid of auditd process */
 	RateLimit       uint32 /* messages rate limit (per second) */
@@ -127,6 +132,8 @@ type auditStatus struct {
 	Backlog         uint32 /* messages waiting in queue */
 	Version         uint32 /* audit api version number */
 	BacklogWaitTime uint32 /* message queue wait timeout */
+	// time spent waiting while the backlog was full, since kernel 5.9
+	BacklogWaitTimeActual uint32
 }
 
 //Netlink is used for specifying the netlink connection types
@@ -146,11 +153,133 @@ type Netlink interface {
 // an opened netlink connection
 // It implements the Netlink interface
 type NetlinkConnection struct {
-	fd      int
-	address syscall.SockaddrNetlink
-	rb      []byte
+	fd       int
+	address  syscall.SockaddrNetlink
+	rb       []byte
+	nonblock int32 // 1 in non-blocking mode, see SetNonblock
+	closed   int32
+	// mu is read locked across the calls using fd so that Close doesn't release it while they run
+	mu sync.RWMutex
+	// wake is the read end of a pipe, Close closes the write end wakeW to wake the blocked receives
+	wake, wakeW int
+	// recvTimeout is the receive timeout set by SetsockRecvTO in milliseconds, 0 waits without limit
+	recvTimeout int64
+	// receiving is set while a receive call is in progress, see ErrReceiveBusy
+	receiving int32
+	statsMu   sync.Mutex
+	stats     ReceiveStats
+}
+
+// ReceiveStats holds the counters of the receive calls of a NetlinkConnection, see Stats.
+// Receives filling their buffer (FullBuffers) may have left messages in the socket for the next call,
+// if that is the common case a larger receive buffer saves calls.
+type ReceiveStats struct {
+	Receives     uint64 // successful receive calls
+	Bytes        uint64 // bytes read
+	Messages     uint64 // netlink messages read
+	FullBuffers  uint64 // receive calls that filled their buffer
+	LastBytes    int    // bytes read by the last receive call
+	LastMessages int    // messages read by the last receive call
+	BufferSize   int    // size of the buffer of the last receive call
+	Overflows    uint64 // receive calls that failed with ErrEventsLost
+}
+
+// Stats returns the counters of the receive calls on the connection.
+func (s *NetlinkConnection) Stats() ReceiveStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	return s.stats
+}
+
+// observeReceive updates the stats with a receive call of nr bytes and messages messages into rb
+func (s *NetlinkConnection) observeReceive(rb []byte, nr, messages int) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	s.stats.Receives++
+	s.stats.Bytes += uint64(nr)
+	s.stats.Messages += uint64(messages)
+	if nr >= len(rb) {
+		s.stats.FullBuffers++
+	}
+	s.stats.LastBytes, s.stats.LastMessages, s.stats.BufferSize = nr, messages, len(rb)
+}
+
+// countMessages returns the number of netlink messages in b
+func countMessages(b []byte) int {
+	var n int
+	for len(b) >= syscall.NLMSG_HDRLEN {
+		l := nlmAlignOf(int(nativeEndian().Uint32(b[0:4])))
+		if l < syscall.NLMSG_HDRLEN {
+			break
+		}
+		n++
+		if l > len(b) {
+			break
+		}
+		b = b[l:]
+	}
+	return n
 }
 
+// ErrClosed is returned by Send, Receive and ReceiveNoParse once the connection was closed,
+// the receive loops return when they encounter it.
+var ErrClosed = errors.New("netlink connection closed")
+
+// ErrReceiveBusy is returned by Receive and ReceiveNoParse when another receive call is in progress on the connection,
+// i.e. a command (SetRules, AuditIsEnabled, ...) is run while a receive loop owns the socket. The command would
+// otherwise consume the events or the loop the reply, use a Demux to share the connection instead.
+var ErrReceiveBusy = errors.New("another receive is in progress on the netlink connection")
+
+// ErrPermission is returned (wrapped, see errors.Cause) by the commands the kernel refuses with EPERM or EACCES:
+// the process lacks CAP_AUDIT_CONTROL (CAP_AUDIT_WRITE for user messages) or doesn't run in the initial
+// user namespace.
+var ErrPermission = errors.New("permission denied, audit commands require CAP_AUDIT_CONTROL in the initial user namespace")
+
+// ErrRuleNotFound is returned (wrapped, see errors.Cause) by DeleteRule when no rule matching the rule
+// is loaded in the kernel.
+var ErrRuleNotFound = errors.New("no such audit rule is loaded")
+
+// ErrAuditLocked is returned (wrapped, see errors.Cause) by the commands changing the audit status or
+// rules when the kernel refuses them with EPERM because the configuration is locked (enabled is
+// AUDIT_LOCKED, see AuditSetImmutable). The lock holds until the next reboot.
+var ErrAuditLocked = errors.New("audit configuration is locked, changes require a reboot")
+
+// replyError returns err for the NLMSG_ERROR reply with the (negative) errno e, wrapping ErrPermission
+// for EPERM and EACCES. Other errors wrap the syscall.Errno.
+func replyError(e int32, err error) error {
+	switch syscall.Errno(-e) {
+	case syscall.EPERM, syscall.EACCES:
+		return errors.Wrap(ErrPermission, err.Error())
+	}
+	return errors.Wrap(syscall.Errno(-e), err.Error())
+}
+
+// errNoReply is returned (wrapped) by auditGetReplyData when the kernel ends the reply without a message
+// of the requested type
+var errNoReply = errors.New("no reply")
+
+// changeError is replyError for the commands changing the audit status or rules, EPERM is
+// ErrAuditLocked if the kernel reports the locked state. Processes lacking the permission to change
+// the configuration can't read the status either and get ErrPermission.
+func changeError(s Netlink, e int32, err error) error {
+	if syscall.Errno(-e) == syscall.EPERM {
+		if status, _, serr := auditGetStatus(s); serr == nil && status.Enabled == AUDIT_LOCKED {
+			return errors.Wrap(ErrAuditLocked, err.Error())
+		}
+	}
+	return replyError(e, err)
+}
+
+// ErrWouldBlock is returned by Receive and ReceiveNoParse when no data is available on a
+// non-blocking connection (SetNonblock) or for receive calls with the MSG_DONTWAIT flag.
+var ErrWouldBlock = errors.New("no data available on the netlink socket")
+
+// ErrEventsLost is returned (wrapped, see errors.Cause) by Receive and ReceiveNoParse when the socket receive
+// buffer overflowed (ENOBUFS) and the kernel dropped the messages it couldn't queue. The connection stays
+// usable, the receive loops pass the error to their callback and continue receiving. ReceiveStats counts
+// the overflows, a larger receive buffer (SetSocketReceiveBuffer) avoids them.
+var ErrEventsLost = errors.New("socket receive buffer overflowed, audit events were lost")
+
 func NativeEndian() binary.ByteOrder {
 	return nativeEndian()
 }
@@ -243,6 +372,62 @@ func NewNetlinkConnection() (*NetlinkConnection, error) {
 		return nil, fmt.Errorf("not root user")
 	}
 
+	return newNetlinkConnection(0)
+}
+
+// NewMulticastNetlinkConnection creates a netlink connection bound to the read only multicast group of
+// the audit events (AUDIT_NLGRP_READLOG, since kernel 3.16). The kernel passes the events to the group
+// in addition to the registered audit daemon, so the events can be received (GetAuditEvents ...) without
+// registering the process (AuditSetPID) and alongside a running auditd. Binding requires CAP_AUDIT_READ
+// instead of root, requests (rules, status ...) on the connection still require CAP_AUDIT_CONTROL.
+// Delivery to the group is best effort, events are dropped rather than waited for if the socket buffer
+// is full (see SetSocketReceiveBuffer).
+func NewMulticastNetlinkConnection() (*NetlinkConnection, error) {
+	s, err := newNetlinkConnection(1 << (AUDIT_NLGRP_READLOG - 1))
+	if err != nil {
+		return nil, errors.Wrap(err, "NewMulticastNetlinkConnection failed")
+	}
+	return s, nil
+}
+
+// ConnectionOptions configures the connections of NewNetlinkConnectionWithOptions.
+type ConnectionOptions struct {
+	// Multicast binds the connection to the read only multicast group, see NewMulticastNetlinkConnection
+	Multicast bool
+	// ReceiveBuffer is the size of the socket receive buffer, see SetSocketReceiveBuffer. 0 keeps the
+	// default of the kernel (net.core.rmem_default)
+	ReceiveBuffer int
+}
+
+// NewNetlinkConnectionWithOptions creates a netlink connection like NewNetlinkConnection or
+// NewMulticastNetlinkConnection and configures its socket with opts, so that the receive buffer is
+// sized before the first events arrive. The connection is closed if the options can't be applied.
+// Use SocketReceiveBuffer for the size granted by the kernel.
+func NewNetlinkConnectionWithOptions(opts ConnectionOptions) (*NetlinkConnection, error) {
+	var (
+		s   *NetlinkConnection
+		err error
+	)
+	if opts.Multicast {
+		s, err = NewMulticastNetlinkConnection()
+	} else {
+		s, err = NewNetlinkConnection()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "NewNetlinkConnectionWithOptions failed")
+	}
+	if opts.ReceiveBuffer > 0 {
+		if err := s.SetSocketReceiveBuffer(opts.ReceiveBuffer); err != nil {
+			s.Close()
+			return nil, errors.Wrap(err, "NewNetlinkConnectionWithOptions failed")
+		}
+	}
+	return s, nil
+}
+
+// newNetlinkConnection creates a netlink connection bound to the multicast groups (bitmask of
+// 1 << (AUDIT_NLGRP_* - 1))
+func newNetlinkConnection(groups uint32) (*NetlinkConnection, error) {
 	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_AUDIT)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not obtain socket")
@@ -251,27 +436,289 @@ func NewNetlinkConnection() (*NetlinkConnection, error) {
 		fd: fd,
 	}
 	s.address.Family = syscall.AF_NETLINK
-	s.address.Groups = 0
+	s.address.Groups = groups
 	s.address.Pid = 0 //Kernel space pid is always set to be 0
 
 	if err := syscall.Bind(fd, &s.address); err != nil {
 		syscall.Close(fd)
 		return nil, errors.Wrap(err, "could not bind socket to address")
 	}
+	var wake [2]int
+	if err := syscall.Pipe2(wake[:], syscall.O_CLOEXEC|syscall.O_NONBLOCK); err != nil {
+		syscall.Close(fd)
+		return nil, errors.Wrap(err, "could not create wake pipe")
+	}
+	s.wake, s.wakeW = wake[0], wake[1]
 	s.rb = make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
 
 	return s, nil
 }
 
-// Close is a wrapper for closing netlink socket
-func (s *NetlinkConnection) Close() {
-	syscall.Close(s.fd)
-	s.rb = nil
+// setnsSyscall holds the number of the setns syscall, the syscall package doesn't define it on all archs
+var setnsSyscall = map[string]uintptr{
+	"386":     346,
+	"amd64":   308,
+	"arm":     375,
+	"arm64":   268,
+	"ppc64":   350,
+	"ppc64le": 350,
+	"riscv64": 268,
+	"s390x":   339,
+}
+
+// setns moves the calling thread into the namespace referred to by fd
+func setns(fd uintptr, nstype int) error {
+	nr, ok := setnsSyscall[runtime.GOARCH]
+	if !ok {
+		return fmt.Errorf("setns is not supported on %v", runtime.GOARCH)
+	}
+	if _, _, errno := syscall.RawSyscall(nr, fd, uintptr(nstype), 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// NewNetlinkConnectionInNS creates a netlink connection inside the network namespace nsPath refers to
+// (i.e. /proc/<pid>/ns/net or /var/run/netns/<name>), the audit netlink socket is per network namespace.
+// The socket is created by a dedicated go-routine locked to its OS thread while the thread enters the
+// namespace, the thread is moved back to its original namespace afterwards. If that fails the go-routine
+// exits without unlocking the thread so the runtime terminates it instead of reusing it, the calling
+// go-routine is never affected.
+// A socket stays in the namespace it was created in, the returned connection can be used from any
+// go-routine, only creating it requires the thread affinity handled here.
+func NewNetlinkConnectionInNS(nsPath string) (*NetlinkConnection, error) {
+	ns, err := os.Open(nsPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewNetlinkConnectionInNS: could not open namespace")
+	}
+	defer ns.Close()
+
+	type result struct {
+		s   *NetlinkConnection
+		err error
+	}
+	created := make(chan result, 1)
+	go func() {
+		runtime.LockOSThread()
+		s, restored, err := newNetlinkConnectionInNS(ns)
+		if restored {
+			runtime.UnlockOSThread()
+		}
+		created <- result{s, err}
+	}()
+	r := <-created
+	return r.s, r.err
+}
+
+// newNetlinkConnectionInNS creates the connection of NewNetlinkConnectionInNS on the locked thread of the
+// calling go-routine, restored is false if the thread was left in the namespace ns
+func newNetlinkConnectionInNS(ns *os.File) (s *NetlinkConnection, restored bool, err error) {
+	orig, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", syscall.Gettid()))
+	if err != nil {
+		return nil, true, errors.Wrap(err, "NewNetlinkConnectionInNS: could not open current namespace")
+	}
+	defer orig.Close()
+	if err := setns(ns.Fd(), syscall.CLONE_NEWNET); err != nil {
+		return nil, true, errors.Wrap(err, "NewNetlinkConnectionInNS: setns failed")
+	}
+	s, err = NewNetlinkConnection()
+	if rerr := setns(orig.Fd(), syscall.CLONE_NEWNET); rerr != nil {
+		if s != nil {
+			s.Close()
+		}
+		return nil, false, errors.Wrap(rerr, "NewNetlinkConnectionInNS: could not restore the namespace")
+	}
+	if err != nil {
+		return nil, true, errors.Wrap(err, "NewNetlinkConnectionInNS failed")
+	}
+	return s, true, nil
+}
+
+// Close closes the netlink socket, closing an already closed connection is a no-op.
+// A receive blocked on the socket in another go-routine is woken and returns ErrClosed, the socket is
+// released once the calls in progress on it have returned.
+func (s *NetlinkConnection) Close() error {
+	if !atomic.CompareAndSwapInt32(&s.closed, 0, 1) {
+		return nil
+	}
+	syscall.Close(s.wakeW)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	syscall.Close(s.wake)
+	if err := syscall.Close(s.fd); err != nil {
+		return errors.Wrap(err, "Close failed")
+	}
+	return nil
+}
+
+// isClosed reports whether Close was called
+func (s *NetlinkConnection) isClosed() bool {
+	return atomic.LoadInt32(&s.closed) != 0
+}
+
+// Fd returns the file descriptor of the netlink socket, e.g. to register it with a poller
+func (s *NetlinkConnection) Fd() int {
+	return s.fd
+}
+
+// SetNonblock switches the socket to non-blocking mode. Receive and ReceiveNoParse then return
+// ErrWouldBlock instead of waiting for data, the caller is expected to wait for the socket to become
+// readable (Fd) before receiving again.
+// The receive loops (GetAuditEvents, GetRawAuditEvents, GetAuditMessages ...) wait for the socket to
+// become readable themselves, but the request helpers waiting for a kernel reply (AuditSetPID,
+// SetRules ...) expect a blocking connection, don't use them on a connection in non-blocking mode,
+// switch back or use a separate connection instead.
+func (s *NetlinkConnection) SetNonblock(nonblocking bool) error {
+	if err := syscall.SetNonblock(s.fd, nonblocking); err != nil {
+		return errors.Wrap(err, "SetNonblock failed")
+	}
+	var nonblock int32
+	if nonblocking {
+		nonblock = 1
+	}
+	atomic.StoreInt32(&s.nonblock, nonblock)
+	return nil
+}
+
+// pollFd is struct pollfd of poll(2)
+type pollFd struct {
+	fd      int32
+	events  int16
+	revents int16
+}
+
+// waitReadable waits up to timeout for a message to arrive on the socket without receiving it, the receive
+// loops use it on connections in non-blocking mode. It returns nil on timeout as well.
+func (s *NetlinkConnection) waitReadable(timeout time.Duration) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.isClosed() {
+		return ErrClosed
+	}
+	if err := s.poll(timeout); err != nil && err != syscall.EAGAIN {
+		return err
+	}
+	return nil
+}
+
+// poll waits up to timeout (without limit if negative) for the socket to become readable, it returns
+// syscall.EAGAIN on timeout like a receive timing out and ErrClosed if Close was called meanwhile.
+// It is called with mu read locked.
+func (s *NetlinkConnection) poll(timeout time.Duration) error {
+	fds := [2]pollFd{
+		{fd: int32(s.fd), events: 0x1 /*POLLIN*/},
+		{fd: int32(s.wake), events: 0x1 /*POLLIN*/},
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		var ts *syscall.Timespec
+		if timeout >= 0 {
+			left := deadline.Sub(time.Now())
+			if left < 0 {
+				left = 0
+			}
+			t := syscall.NsecToTimespec(int64(left))
+			ts = &t
+		}
+		n, _, errno := syscall.Syscall6(syscall.SYS_PPOLL, uintptr(unsafe.Pointer(&fds[0])), uintptr(len(fds)), uintptr(unsafe.Pointer(ts)), 0, 0, 0)
+		if errno == syscall.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return errors.Wrap(errno, "ppoll failed")
+		}
+		if fds[1].revents != 0 {
+			return ErrClosed
+		}
+		if n == 0 {
+			return syscall.EAGAIN
+		}
+		return nil
+	}
+}
+
+// recvfrom receives from the socket, a missing message in non-blocking mode is reported as ErrWouldBlock
+// and receiving from a closed connection as ErrClosed. Blocking receives wait in poll so that Close
+// can wake them.
+func (s *NetlinkConnection) recvfrom(rb []byte, block int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.isClosed() {
+		return 0, ErrClosed
+	}
+	if !atomic.CompareAndSwapInt32(&s.receiving, 0, 1) {
+		return 0, ErrReceiveBusy
+	}
+	defer atomic.StoreInt32(&s.receiving, 0)
+	var (
+		nr  int
+		err error
+	)
+	nonblock := atomic.LoadInt32(&s.nonblock) != 0
+	if !nonblock && block&syscall.MSG_DONTWAIT == 0 {
+		timeout := time.Duration(atomic.LoadInt64(&s.recvTimeout)) * time.Millisecond
+		if timeout == 0 {
+			timeout = -1
+		}
+		err = s.poll(timeout)
+	}
+	if err == nil {
+		nr, _, err = syscall.Recvfrom(s.fd, rb, 0|block)
+	}
+	if err != nil && s.isClosed() {
+		return 0, ErrClosed
+	}
+	if err == syscall.EAGAIN && (nonblock || block&syscall.MSG_DONTWAIT != 0) {
+		return 0, ErrWouldBlock
+	}
+	if err != nil {
+		if err == syscall.ENOBUFS {
+			debugf("recvfrom: ENOBUFS, the socket receive buffer overflowed and messages were lost")
+			s.statsMu.Lock()
+			s.stats.Overflows++
+			s.statsMu.Unlock()
+			return 0, errors.Wrap(ErrEventsLost, "recvfrom failed")
+		}
+		return 0, errors.Wrap(err, "recvfrom failed")
+	}
+	return nr, nil
+}
+
+// SetSocketReceiveBuffer sets the size of the socket receive buffer, a larger buffer absorbs bursts of
+// events that would otherwise overflow it (ENOBUFS on receive, lost events). SO_RCVBUFFORCE is used for
+// privileged processes (CAP_NET_ADMIN), SO_RCVBUF limited by net.core.rmem_max otherwise.
+// The kernel doubles the requested size to account for its bookkeeping overhead, SocketReceiveBuffer
+// reports the size granted. The default is net.core.rmem_default (usually 208KiB), busy systems use
+// a few MiB (i.e. 8MiB).
+func (s *NetlinkConnection) SetSocketReceiveBuffer(bytes int) error {
+	err := syscall.SetsockoptInt(s.fd, syscall.SOL_SOCKET, syscall.SO_RCVBUFFORCE, bytes)
+	if err == syscall.EPERM {
+		err = syscall.SetsockoptInt(s.fd, syscall.SOL_SOCKET, syscall.SO_RCVBUF, bytes)
+	}
+	if err != nil {
+		return errors.Wrap(err, "SetSocketReceiveBuffer failed")
+	}
+	return nil
+}
+
+// SocketReceiveBuffer returns the size of the socket receive buffer granted by the kernel
+func (s *NetlinkConnection) SocketReceiveBuffer() (int, error) {
+	size, err := syscall.GetsockoptInt(s.fd, syscall.SOL_SOCKET, syscall.SO_RCVBUF)
+	if err != nil {
+		return 0, errors.Wrap(err, "SocketReceiveBuffer failed")
+	}
+	return size, nil
 }
 
 // Send is a wrapper for sending NetlinkMessage across netlink socket
 func (s *NetlinkConnection) Send(request *NetlinkMessage) error {
-	if err := syscall.Sendto(s.fd, request.ToWireFormat(s.rb), 0, &s.address); err != nil {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.isClosed() {
+		return ErrClosed
+	}
+	// not into rb, a receive can be in progress
+	if err := syscall.Sendto(s.fd, request.ToWireFormat(nil), 0, &s.address); err != nil {
 		return errors.Wrap(err, "could not send NetlinkMessage")
 	}
 	return nil
@@ -283,16 +730,16 @@ func (s *NetlinkConnection) Receive(bytesize int, block int, rb []byte) ([]Netli
 		rb = s.rb
 		//rb = make([]byte, bytesize)
 	}
-	nr, _, err := syscall.Recvfrom(s.fd, rb, 0|block)
-
+	nr, err := s.recvfrom(rb, block)
 	if err != nil {
-		return nil, errors.Wrap(err, "recvfrom failed")
+		return nil, err
 	}
 	if nr < syscall.NLMSG_HDRLEN {
 		return nil, errors.Wrap(err, "message length shorter than expected")
 	}
-	rb = rb[:nr]
-	return ParseAuditNetlinkMessage(rb)
+	msgs, err := ParseAuditNetlinkMessage(rb[:nr])
+	s.observeReceive(rb, nr, len(msgs))
+	return msgs, err
 }
 
 // Receive is a wrapper for recieving from netlink socket and return an array of NetlinkMessage
@@ -301,14 +748,14 @@ func (s *NetlinkConnection) ReceiveNoParse(bytesize int, block int, rb []byte) (
 		rb = s.rb
 		//rb = make([]byte, bytesize)
 	}
-	nr, _, err := syscall.Recvfrom(s.fd, rb, 0|block)
-
+	nr, err := s.recvfrom(rb, block)
 	if err != nil {
-		return nil, errors.Wrap(err, "recvfrom failed")
+		return nil, err
 	}
 	if nr < syscall.NLMSG_HDRLEN {
 		return nil, errors.Wrap(err, "message length shorter than expected")
 	}
+	s.observeReceive(rb, nr, countMessages(rb[:nr]))
 	rb = rb[:nr]
 	return rb, nil
 }
@@ -326,6 +773,8 @@ func (s *NetlinkConnection) GetPID() (int, error) {
 func (s *NetlinkConnection) SetsockRecvTO(recvto int64) error {
 	var tv syscall.Timeval
 	tv = syscall.Timeval{Sec: recvto / 1000, Usec: (recvto % 1000) * 1000}
+	// receives wait in poll, which applies the timeout
+	atomic.StoreInt64(&s.recvTimeout, recvto)
 	return syscall.SetsockoptTimeval(s.fd, 1 /*SOL_SOCKET*/, 20 /*SO_RECVTIMEO*/, &tv)
 }
 
@@ -348,7 +797,7 @@ done:
 			}
 			if h.Seq != seq {
 				if h.Type > uint16(AUDIT_FIRST_USER_MSG) {
-					//fmt.Printf("Seq: %v->Header: %v, data: %v\n", seq, h, dbuf)
+					debugf("auditGetReply: message of type %v with seq %d ends waiting for the reply to seq %d", auditConstant(h.Type), h.Seq, seq)
 					break done
 				}
 				if h.Type == syscall.NLMSG_ERROR {
@@ -356,9 +805,10 @@ done:
 					if e == 0 || e == 17 { // EEXIST
 						break done
 					} else {
-						return fmt.Errorf("auditGetReply: error while recieving reply -%d", e)
+						return changeError(s, e, fmt.Errorf("auditGetReply: error while recieving reply -%d", e))
 					}
 				}
+				debugf("auditGetReply: message of type %v with seq %d, expected %d", auditConstant(h.Type), h.Seq, seq)
 				return fmt.Errorf("auditGetReply: Type %v Wrong Seq nr %d, expected %d", h.Type, h.Seq, seq)
 			}
 			if int(h.Pid) != socketPID {
@@ -372,7 +822,7 @@ done:
 				if e == 0 || e == 17 { // EEXIST
 					break done
 				} else {
-					return fmt.Errorf("auditGetReply: error while recieving reply -%d", e)
+					return changeError(s, e, fmt.Errorf("auditGetReply: error while recieving reply -%d", e))
 				}
 			}
 			// acknowledge AUDIT_GET replies from kernel
@@ -414,14 +864,139 @@ done:
 	return nil
 }
 
+// auditGetReplyData waits for the reply of type msgType to the request with sequence
+// number seq and returns the data part of it. Request acks are skipped, a negative ack
+// is returned as an error.
+func auditGetReplyData(s Netlink, msgType auditConstant, seq uint32) ([]byte, error) {
+	for {
+		b, err := s.ReceiveNoParse(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "auditGetReplyData failed")
+		}
+		for len(b) >= syscall.NLMSG_HDRLEN {
+			h, dbuf, dlen, err := netlinkMessageHeaderAndData(b)
+			if err != nil {
+				return nil, errors.Wrap(err, "auditGetReplyData msg parsing failed")
+			}
+			dbuf = dbuf[:int(h.Len)-syscall.NLMSG_HDRLEN]
+			if dlen > len(b) {
+				dlen = len(b)
+			}
+			b = b[dlen:]
+			if h.Seq != seq {
+				// audit events can be interleaved with the replies, skip them
+				debugf("auditGetReplyData: skipping message of type %v with seq %d, expected %d", auditConstant(h.Type), h.Seq, seq)
+				continue
+			}
+			switch h.Type {
+			case syscall.NLMSG_DONE:
+				return nil, errors.Wrap(errNoReply, fmt.Sprintf("auditGetReplyData: no reply of type %v", msgType))
+			case syscall.NLMSG_ERROR:
+				if len(dbuf) < 4 {
+					return nil, fmt.Errorf("auditGetReplyData: short error message")
+				}
+				e := int32(nativeEndian().Uint32(dbuf[0:4]))
+				if e != 0 {
+					return nil, replyError(e, fmt.Errorf("auditGetReplyData: error while recieving reply %d", e))
+				}
+			case uint16(msgType):
+				data := make([]byte, len(dbuf))
+				copy(data, dbuf)
+				return data, nil
+			}
+		}
+	}
+}
+
+// BuildNetlinkMessage builds a netlink message of type msgType carrying data for commands the library
+// doesn't wrap. The header length is set from data and data is padded to the netlink alignment.
+// The sequence number is taken from the library's counter so replies can be matched to the request,
+// callers must not change Seq nor set Pid (0 addresses the kernel).
+func BuildNetlinkMessage(msgType uint16, flags int, data []byte) NetlinkMessage {
+	m := NetlinkMessage{}
+	m.Header.Len = uint32(syscall.NLMSG_HDRLEN + len(data))
+	m.Header.Type = msgType
+	m.Header.Flags = uint16(flags)
+	m.Header.Seq = atomic.AddUint32(&sequenceNumber, 1)
+	m.Data = make([]byte, nlmAlignOf(len(data)))
+	copy(m.Data, data)
+	return m
+}
+
+// AuditSend sends the command msgType with the payload data to the kernel, the kernel is asked to acknowledge it.
+// The payload must be in the layout (and host byte order) the kernel expects for msgType.
+// It returns the sequence number of the request which is to be passed to AuditRecvReply.
+func AuditSend(s Netlink, msgType uint16, data []byte) (uint32, error) {
+	m := BuildNetlinkMessage(msgType, syscall.NLM_F_REQUEST|syscall.NLM_F_ACK, data)
+	if err := s.Send(&m); err != nil {
+		return 0, errors.Wrap(err, "AuditSend failed")
+	}
+	return m.Header.Seq, nil
+}
+
+// AuditRecvReply waits for the kernel to process the request with the sequence number seq (sent with AuditSend).
+// If replyType is 0 only the acknowledgement is awaited and nil is returned, otherwise the data of the reply of
+// type replyType is returned. A negative acknowledgement is returned as an error.
+func AuditRecvReply(s Netlink, seq uint32, replyType uint16) ([]byte, error) {
+	var (
+		data  []byte
+		acked bool
+	)
+	for !acked || (replyType != 0 && data == nil) {
+		b, err := s.ReceiveNoParse(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "AuditRecvReply failed")
+		}
+		for len(b) >= syscall.NLMSG_HDRLEN {
+			h, dbuf, dlen, err := netlinkMessageHeaderAndData(b)
+			if err != nil {
+				return nil, errors.Wrap(err, "AuditRecvReply msg parsing failed")
+			}
+			dbuf = dbuf[:int(h.Len)-syscall.NLMSG_HDRLEN]
+			if dlen > len(b) {
+				dlen = len(b)
+			}
+			b = b[dlen:]
+			if h.Seq != seq {
+				// audit events can be interleaved with the replies, skip them
+				debugf("AuditRecvReply: skipping message of type %v with seq %d, expected %d", auditConstant(h.Type), h.Seq, seq)
+				continue
+			}
+			switch h.Type {
+			case syscall.NLMSG_ERROR:
+				if len(dbuf) < 4 {
+					return nil, fmt.Errorf("AuditRecvReply: short error message")
+				}
+				e := int32(nativeEndian().Uint32(dbuf[0:4]))
+				if e != 0 {
+					return nil, replyError(e, fmt.Errorf("AuditRecvReply: error while recieving reply %d", e))
+				}
+				acked = true
+			case syscall.NLMSG_DONE:
+				if replyType != 0 && data == nil {
+					return nil, fmt.Errorf("AuditRecvReply: no reply of type %v", replyType)
+				}
+			case replyType:
+				data = make([]byte, len(dbuf))
+				copy(data, dbuf)
+			}
+		}
+	}
+	return data, nil
+}
+
 // AuditSetEnabled enables or disables audit in kernel.
 // Provide `enabled` as 1 for enabling and 0 for disabling.
+// Use 2 (or AuditSetImmutable) to lock the audit configuration until reboot.
 func AuditSetEnabled(s Netlink, enabled int) error {
 	var (
 		status auditStatus
 		err    error
 	)
 
+	if enabled < 0 || enabled > AUDIT_LOCKED {
+		return fmt.Errorf("AuditSetEnabled: invalid enabled state %d", enabled)
+	}
 	status.Enabled = (uint32)(enabled)
 	status.Mask = AUDIT_STATUS_ENABLED
 	buff := new(bytes.Buffer)
@@ -493,7 +1068,7 @@ done:
 					b = b[dlen:]
 					continue
 				}
-				break done
+				return -1, -1, replyError(e, fmt.Errorf("AuditIsEnabled: error while receiving reply %d", e))
 			}
 			if h.Type == uint16(AUDIT_GET) {
 				//Convert the data part written to auditStatus struct
@@ -502,6 +1077,8 @@ done:
 				if err != nil {
 					return -1, -1, errors.Wrap(err, "AuditIsEnabled: binary read into auditStatus failed")
 				}
+				currentMetrics().ObserveLost(int(status.Lost))
+				currentMetrics().ObserveBacklog(int(status.Backlog))
 				state = int(status.Enabled)
 				pid = int(status.Pid)
 				return state, pid, nil
@@ -557,6 +1134,128 @@ done:
 	return -1, -1, nil
 }
 
+// AuditStatus is the audit status of the kernel returned by AuditGetStatus.
+type AuditStatus struct {
+	Enabled       int // AUDIT_DISABLED, AUDIT_ENABLED or AUDIT_LOCKED
+	Failure       int // AUDIT_FAIL_SILENT, AUDIT_FAIL_PRINTK or AUDIT_FAIL_PANIC
+	PID           int // pid of the audit daemon, 0 if none is registered
+	RateLimit     int // messages per second, 0 is unlimited
+	BacklogLimit  int
+	Lost          int // messages lost since boot (or the last reset of the counter)
+	Backlog       int // messages waiting in the queue
+	FeatureBitmap uint32
+	// BacklogWaitTime (in jiffies) and BacklogWaitTimeActual are -1 on kernels which don't report them
+	BacklogWaitTime       int
+	BacklogWaitTimeActual int
+}
+
+// AuditGetStatus returns the complete audit status of the kernel, i.e. the lost and backlog
+// counters for health monitoring.
+func AuditGetStatus(s Netlink) (*AuditStatus, error) {
+	status, n, err := auditGetStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "AuditGetStatus failed")
+	}
+	st := &AuditStatus{
+		Enabled:               int(status.Enabled),
+		Failure:               int(status.Failure),
+		PID:                   int(status.Pid),
+		RateLimit:             int(status.RateLimit),
+		BacklogLimit:          int(status.BacklogLimit),
+		Lost:                  int(status.Lost),
+		Backlog:               int(status.Backlog),
+		FeatureBitmap:         status.Version,
+		BacklogWaitTime:       int(status.BacklogWaitTime),
+		BacklogWaitTimeActual: int(status.BacklogWaitTimeActual),
+	}
+	if n < int(unsafe.Offsetof(status.BacklogWaitTime))+4 {
+		st.BacklogWaitTime = -1
+	}
+	if n < int(unsafe.Offsetof(status.BacklogWaitTimeActual))+4 {
+		st.BacklogWaitTimeActual = -1
+	}
+	return st, nil
+}
+
+// AuditSignalInfo is the sender of the last signal to the audit daemon, see AuditGetSignalInfo.
+type AuditSignalInfo struct {
+	UID     uint32 // login uid (auid) of the sender, its uid if the login uid is unset
+	PID     int    // 0 if the audit daemon wasn't signaled
+	Context string // security context (i.e. SELinux label) of the sender, empty without LSM
+}
+
+// AuditGetSignalInfo returns the sender of the last SIGTERM, SIGHUP or SIGUSR1 to the registered
+// audit daemon (AuditSetPID) like auditd logs it, i.e. to tell a shutdown or a reload apart.
+// The kernel records the sender when the signal is sent, so the daemon queries it in its signal handler.
+func AuditGetSignalInfo(s Netlink) (*AuditSignalInfo, error) {
+	wb := newNetlinkAuditRequest(uint16(AUDIT_SIGNAL_INFO), syscall.AF_NETLINK, 0)
+	// no ack on success like with AUDIT_GET
+	wb.Header.Flags = syscall.NLM_F_REQUEST
+	if err := s.Send(wb); err != nil {
+		return nil, errors.Wrap(err, "AuditGetSignalInfo failed")
+	}
+	data, err := auditGetReplyData(s, AUDIT_SIGNAL_INFO, wb.Header.Seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "AuditGetSignalInfo failed")
+	}
+	// struct audit_sig_info: uid, pid and the context, which isn't NUL terminated
+	if len(data) < 8 {
+		return nil, fmt.Errorf("AuditGetSignalInfo: reply of %d bytes too short", len(data))
+	}
+	return &AuditSignalInfo{
+		UID:     nativeEndian().Uint32(data[0:4]),
+		PID:     int(int32(nativeEndian().Uint32(data[4:8]))),
+		Context: strings.TrimRight(string(data[8:]), "\x00"),
+	}, nil
+}
+
+// auditGetStatus asks the kernel for the audit status. Older kernels reply with a shorter
+// audit_status, the fields they don't know are left 0.
+func auditGetStatus(s Netlink) (*auditStatus, int, error) {
+	var status auditStatus
+
+	wb := newNetlinkAuditRequest(uint16(AUDIT_GET), syscall.AF_NETLINK, 0)
+	// no ack on success, the reply is enough and a stray ack would confuse the next auditGetReply
+	wb.Header.Flags = syscall.NLM_F_REQUEST
+	if err := s.Send(wb); err != nil {
+		return nil, 0, errors.Wrap(err, "auditGetStatus failed")
+	}
+	data, err := auditGetReplyData(s, AUDIT_GET, wb.Header.Seq)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "auditGetStatus failed")
+	}
+	n := len(data)
+	if size := int(unsafe.Sizeof(status)); n < size {
+		data = append(data, make([]byte, size-n)...)
+	}
+	if err := binary.Read(bytes.NewReader(data), nativeEndian(), &status); err != nil {
+		return nil, 0, errors.Wrap(err, "auditGetStatus: binary read into auditStatus failed")
+	}
+	currentMetrics().ObserveLost(int(status.Lost))
+	currentMetrics().ObserveBacklog(int(status.Backlog))
+	return &status, n, nil
+}
+
+// AuditSetImmutable enables audit and locks the audit configuration (enabled=2).
+// Once locked, every further attempt to change rules or audit settings (including
+// disabling audit) is refused by the kernel until the next reboot.
+// Use AuditIsImmutable to check if the lock is in place.
+func AuditSetImmutable(s Netlink) error {
+	if err := AuditSetEnabled(s, AUDIT_LOCKED); err != nil {
+		return errors.Wrap(err, "AuditSetImmutable failed")
+	}
+	return nil
+}
+
+// AuditIsImmutable returns true if the audit configuration is locked (enabled=2).
+func AuditIsImmutable(s Netlink) (bool, error) {
+	state, _, err := AuditIsEnabled(s)
+	if err != nil {
+		return false, errors.Wrap(err, "AuditIsImmutable failed")
+	}
+	return state == AUDIT_LOCKED, nil
+}
+
 // AuditSetPID sends a message to kernel for setting of program PID
 func AuditSetPID(s Netlink, pid int) error {
 	var status auditStatus
@@ -581,6 +1280,62 @@ func AuditSetPID(s Netlink, pid int) error {
 	return nil
 }
 
+// ErrNotRegistered is returned by EnsureRegistered and TryRegisterPID when another process is registered as the audit daemon
+var ErrNotRegistered = errors.New("the process is not registered as the audit daemon")
+
+// AuditIsRegistered reports whether the calling process is the audit daemon registered with the kernel
+// (AuditSetPID), i.e. the process the kernel sends the audit events to.
+func AuditIsRegistered(s Netlink) (bool, error) {
+	_, pid, err := AuditIsEnabled(s)
+	if err != nil {
+		return false, errors.Wrap(err, "AuditIsRegistered failed")
+	}
+	return pid == os.Getpid(), nil
+}
+
+// EnsureRegistered registers the calling process as the audit daemon and verifies the kernel accepted it.
+// The kernel refuses the registration (EEXIST) while another audit daemon (i.e. auditd) is running, the
+// returned error is then ErrNotRegistered (see errors.Cause) naming the pid of the registered process.
+func EnsureRegistered(s Netlink) error {
+	setErr := AuditSetPID(s, os.Getpid())
+	_, pid, err := AuditIsEnabled(s)
+	if err != nil {
+		if setErr != nil {
+			return errors.Wrap(setErr, "EnsureRegistered failed")
+		}
+		return errors.Wrap(err, "EnsureRegistered failed")
+	}
+	if pid != os.Getpid() {
+		return errors.Wrap(ErrNotRegistered, fmt.Sprintf("EnsureRegistered failed: audit pid is %d", pid))
+	}
+	return nil
+}
+
+// TryRegisterPID registers the calling process as the audit daemon unless another process is registered,
+// so that the events of a running auditd are not taken over. It returns the pid registered before, 0 if
+// there was none and the pid of the calling process if it was registered already. While another process
+// is registered it returns its pid with ErrNotRegistered (see errors.Cause) and leaves the registration
+// alone, callers can then back off, take over with AuditSetPID or receive the events from the read only
+// multicast group (AUDIT_NLGRP_READLOG) instead.
+func TryRegisterPID(s Netlink) (previousPID int, err error) {
+	_, pid, err := AuditIsEnabled(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "TryRegisterPID failed")
+	}
+	if pid == os.Getpid() {
+		return pid, nil
+	}
+	if pid > 0 {
+		return pid, errors.Wrap(ErrNotRegistered, fmt.Sprintf("TryRegisterPID failed: audit pid is %d", pid))
+	}
+	if err := EnsureRegistered(s); err != nil {
+		// another process registered meanwhile
+		_, current, _ := AuditIsEnabled(s)
+		return current, errors.Wrap(err, "TryRegisterPID failed")
+	}
+	return 0, nil
+}
+
 // AuditSetRateLimit sets rate limit for audit messages from kernel
 func AuditSetRateLimit(s Netlink, limit int) error {
 	var status auditStatus
@@ -631,27 +1386,139 @@ func AuditSetBacklogLimit(s Netlink, limit int) error {
 
 }
 
-// AuditSetBacklogLimit sets backlog limit for audit messages from kernel
+// AuditSetBacklogWaitTime sets the time (in jiffies) a process waits for room in the backlog of the
+// kernel before its audit message is lost, 0 doesn't wait. It requires kernel 3.14 or later.
+func AuditSetBacklogWaitTime(s Netlink, wait int) error {
+	if err := AuditConfigure(s, AuditConfig{BacklogWaitTime: &wait}); err != nil {
+		return errors.Wrap(err, "AuditSetBacklogWaitTime failed")
+	}
+	return nil
+}
+
+// AuditGetRateLimit returns the rate limit of audit messages (per second) of the kernel, 0 is unlimited.
+func AuditGetRateLimit(s Netlink) (int, error) {
+	status, _, err := auditGetStatus(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "AuditGetRateLimit failed")
+	}
+	return int(status.RateLimit), nil
+}
+
+// AuditGetBacklogLimit returns the number of audit messages the kernel queues for the audit daemon.
+func AuditGetBacklogLimit(s Netlink) (int, error) {
+	status, _, err := auditGetStatus(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "AuditGetBacklogLimit failed")
+	}
+	return int(status.BacklogLimit), nil
+}
+
+// AuditGetBacklogWaitTime returns the backlog wait time (in jiffies) of the kernel, see AuditSetBacklogWaitTime.
+// Kernels older than 3.14 result in ErrFeatureUnsupported.
+func AuditGetBacklogWaitTime(s Netlink) (int, error) {
+	status, n, err := auditGetStatus(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "AuditGetBacklogWaitTime failed")
+	}
+	if n < int(unsafe.Offsetof(status.BacklogWaitTime))+4 {
+		return 0, errors.Wrap(ErrFeatureUnsupported, "AuditGetBacklogWaitTime: no backlog wait time in the audit status")
+	}
+	return int(status.BacklogWaitTime), nil
+}
+
+// failureModeLookup holds the names of the failure modes (AUDIT_FAIL_*)
+var failureModeLookup = map[int]string{
+	AUDIT_FAIL_SILENT: "silent",
+	AUDIT_FAIL_PRINTK: "printk",
+	AUDIT_FAIL_PANIC:  "panic",
+}
+
+// ParseFailureMode converts the name of a failure mode (silent, printk, panic) or its number as
+// used by auditctl -f to its value.
+func ParseFailureMode(name string) (int, error) {
+	for m, n := range failureModeLookup {
+		if n == name || strconv.Itoa(m) == name {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown failure mode %q", name)
+}
+
+// AuditSetFailureMode sets what the kernel does when audit messages can't be delivered (i.e. the
+// backlog limit is exceeded): AUDIT_FAIL_SILENT drops them, AUDIT_FAIL_PRINTK logs them with printk
+// and AUDIT_FAIL_PANIC panics the kernel.
+func AuditSetFailureMode(s Netlink, mode int) error {
+	if err := AuditConfigure(s, AuditConfig{Failure: &mode}); err != nil {
+		return errors.Wrap(err, "AuditSetFailureMode failed")
+	}
+	return nil
+}
+
+// AuditSetFlags sets the failure mode of the kernel, see AuditSetFailureMode
 func AuditSetFlags(s Netlink, flag int) error {
+	if err := AuditSetFailureMode(s, flag); err != nil {
+		return errors.Wrap(err, "AuditSetFlags failed")
+	}
+	return nil
+}
+
+// AuditConfig holds the settable fields of the audit status for AuditConfigure, the nil fields are left as they are.
+type AuditConfig struct {
+	Enabled         *int // AUDIT_DISABLED, AUDIT_ENABLED or AUDIT_LOCKED
+	Failure         *int // AUDIT_FAIL_SILENT, AUDIT_FAIL_PRINTK or AUDIT_FAIL_PANIC
+	PID             *int
+	RateLimit       *int // messages per second, 0 is unlimited
+	BacklogLimit    *int
+	BacklogWaitTime *int // in jiffies, since kernel 3.14
+}
+
+// AuditConfigure sets the fields of cfg with one AUDIT_SET request, instead of one request per field
+// with AuditSetEnabled, AuditSetRateLimit etc. The kernel applies Enabled first, so setting AUDIT_LOCKED along
+// with other fields fails, lock the configuration with AuditSetImmutable once it is set.
+func AuditConfigure(s Netlink, cfg AuditConfig) error {
 	var status auditStatus
-	status.Mask = AUDIT_STATUS_FAILURE
-	status.Failure = (uint32)(flag)
+	fields := []struct {
+		value *int
+		max   int64
+		mask  uint32
+		field *uint32
+		name  string
+	}{
+		{cfg.Enabled, AUDIT_LOCKED, AUDIT_STATUS_ENABLED, &status.Enabled, "enabled state"},
+		{cfg.Failure, AUDIT_FAIL_PANIC, AUDIT_STATUS_FAILURE, &status.Failure, "failure mode"},
+		{cfg.PID, math.MaxInt32, AUDIT_STATUS_PID, &status.Pid, "pid"},
+		{cfg.RateLimit, math.MaxUint32, AUDIT_STATUS_RATE_LIMIT, &status.RateLimit, "rate limit"},
+		{cfg.BacklogLimit, math.MaxUint32, AUDIT_STATUS_BACKLOG_LIMIT, &status.BacklogLimit, "backlog limit"},
+		{cfg.BacklogWaitTime, math.MaxUint32, AUDIT_STATUS_BACKLOG_WAIT_TIME, &status.BacklogWaitTime, "backlog wait time"},
+	}
+	for _, f := range fields {
+		if f.value == nil {
+			continue
+		}
+		if *f.value < 0 || int64(*f.value) > f.max {
+			return fmt.Errorf("AuditConfigure: invalid %v %d", f.name, *f.value)
+		}
+		status.Mask |= f.mask
+		*f.field = uint32(*f.value)
+	}
+	if status.Mask == 0 {
+		return nil
+	}
 	buff := new(bytes.Buffer)
 	err := binary.Write(buff, nativeEndian(), status)
 	if err != nil {
-		return errors.Wrap(err, "AuditSetFlags: binary write from auditStatus failed")
+		return errors.Wrap(err, "AuditConfigure: binary write from auditStatus failed")
 	}
 
 	wb := newNetlinkAuditRequest(uint16(AUDIT_SET), syscall.AF_NETLINK, int(unsafe.Sizeof(status)))
 	wb.Data = append(wb.Data, buff.Bytes()[:]...)
 	if err := s.Send(wb); err != nil {
-		return errors.Wrap(err, "AuditSetFlags failed")
+		return errors.Wrap(err, "AuditConfigure failed")
 	}
 
 	err = auditGetReply(s, syscall.Getpagesize(), 0, wb.Header.Seq)
 	if err != nil {
-		return errors.Wrap(err, "AuditSetFlags failed")
+		return errors.Wrap(err, "AuditConfigure failed")
 	}
 	return nil
-
 }
@@ -10,6 +10,9 @@ import (
 	"strings"
 	"syscall"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 func TestWireFormat(t *testing.T) {
@@ -56,6 +59,9 @@ func TestNetlinkConnection(t *testing.T) {
 	if err != nil {
 		t.Errorf("TestNetlinkConnection: test failed %v", err)
 	}
+	// the status follows the ack, read it so it isn't left to the next socket bound to the same port
+	s.SetsockRecvTO(1000)
+	s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
 }
 
 type testNetlinkConn struct {
@@ -77,10 +83,20 @@ func (t *testNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]Netlink
 	return v, nil
 }
 
+func (t *testNetlinkConn) ReceiveNoParse(bytesize int, block int, rb []byte) ([]byte, error) {
+	m := newNetlinkAuditRequest(uint16(AUDIT_GET), syscall.AF_NETLINK, 0)
+	m.Header.Seq = t.actualNetlinkMessage.Header.Seq
+	return m.ToWireFormat(nil), nil
+}
+
 func (t *testNetlinkConn) GetPID() (int, error) {
 	return 0, nil
 }
 
+func (t *testNetlinkConn) SetsockRecvTO(recvto int64) error {
+	return nil
+}
+
 func testSettersEmulated(t *testing.T) {
 	// try testing with emulated socket
 	var (
@@ -201,6 +217,8 @@ func TestSetters(t *testing.T) {
 	if err != nil {
 		t.Errorf("AuditSetPID failed %v", err)
 	}
+	// the events would go to the sockets bound to the same port by the following tests
+	defer AuditSetPID(s, 0)
 	// now we run `auditctl -s` and match the returned status, rate limit,
 	// backlog limit and pid from the kernel with the passed args. we rely on the format `auditctl`
 	// emits its output for parsing the values. If `auditctl` changes the format, the collection
@@ -245,3 +263,726 @@ func TestSetters(t *testing.T) {
 	}
 
 }
+
+// testReplyNetlinkConn hands every request to reply and queues the returned messages
+// for the following receive calls
+type testReplyNetlinkConn struct {
+	sent    []NetlinkMessage
+	reply   func(request NetlinkMessage) []NetlinkMessage
+	pending []NetlinkMessage
+}
+
+func (t *testReplyNetlinkConn) Send(request *NetlinkMessage) error {
+	t.sent = append(t.sent, *request)
+	if t.reply != nil {
+		t.pending = append(t.pending, t.reply(*request)...)
+	}
+	return nil
+}
+
+func (t *testReplyNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]NetlinkMessage, error) {
+	b, err := t.ReceiveNoParse(bytesize, block, rb)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuditNetlinkMessage(b)
+}
+
+func (t *testReplyNetlinkConn) ReceiveNoParse(bytesize int, block int, rb []byte) ([]byte, error) {
+	if len(t.pending) == 0 {
+		return nil, syscall.EAGAIN
+	}
+	m := t.pending[0]
+	t.pending = t.pending[1:]
+	// like the kernel pad the message and like the socket receive buffer
+	// leave room behind it, ParseAuditNetlinkMessage may slice past its end
+	b := m.ToWireFormat(nil)
+	rb = make([]byte, nlmAlignOf(len(b)), nlmAlignOf(len(b))+syscall.NLMSG_HDRLEN)
+	copy(rb, b)
+	return rb, nil
+}
+
+func (t *testReplyNetlinkConn) GetPID() (int, error) {
+	return 0, nil
+}
+
+func (t *testReplyNetlinkConn) SetsockRecvTO(recvto int64) error {
+	return nil
+}
+
+// testReplyMessage builds a kernel reply of type msgType to request carrying data
+func testReplyMessage(request NetlinkMessage, msgType uint16, data []byte) NetlinkMessage {
+	m := NetlinkMessage{Data: data}
+	m.Header.Len = uint32(syscall.NLMSG_HDRLEN + len(data))
+	m.Header.Type = msgType
+	m.Header.Seq = request.Header.Seq
+	return m
+}
+
+// testAckMessage builds the NLMSG_ERROR reply to request with the given errno (0 for an ack)
+func testAckMessage(request NetlinkMessage, errno int32) NetlinkMessage {
+	data := make([]byte, 4+syscall.NLMSG_HDRLEN)
+	nativeEndian().PutUint32(data, uint32(-errno))
+	return testReplyMessage(request, syscall.NLMSG_ERROR, data)
+}
+
+// testStatusData returns status in the layout of AUDIT_GET replies
+func testStatusData(status auditStatus) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, nativeEndian(), status)
+	return buf.Bytes()
+}
+
+func TestAuditSetImmutable(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	if err := AuditSetImmutable(s); err != nil {
+		t.Fatalf("AuditSetImmutable failed %v", err)
+	}
+	var status auditStatus
+	if err := binary.Read(bytes.NewReader(s.sent[0].Data), nativeEndian(), &status); err != nil {
+		t.Fatalf("AuditSetImmutable: unreadable request %v", err)
+	}
+	if status.Mask != AUDIT_STATUS_ENABLED || status.Enabled != AUDIT_LOCKED {
+		t.Errorf("AuditSetImmutable: expected enabled=2, found %+v", status)
+	}
+	if err := AuditSetEnabled(s, 3); err == nil {
+		t.Errorf("AuditSetEnabled: expected error for invalid state 3")
+	}
+}
+
+func TestAuditConfigure(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	enabled, rateLimit, backlogLimit, failure := AUDIT_ENABLED, 0, 8192, AUDIT_FAIL_PRINTK
+	err := AuditConfigure(s, AuditConfig{Enabled: &enabled, RateLimit: &rateLimit, BacklogLimit: &backlogLimit, Failure: &failure})
+	if err != nil {
+		t.Fatalf("AuditConfigure failed %v", err)
+	}
+	if len(s.sent) != 1 {
+		t.Fatalf("AuditConfigure: expected one request, found %d", len(s.sent))
+	}
+	var status auditStatus
+	if err := binary.Read(bytes.NewReader(s.sent[0].Data), nativeEndian(), &status); err != nil {
+		t.Fatalf("AuditConfigure: unreadable request %v", err)
+	}
+	expected := auditStatus{
+		Mask:         AUDIT_STATUS_ENABLED | AUDIT_STATUS_FAILURE | AUDIT_STATUS_RATE_LIMIT | AUDIT_STATUS_BACKLOG_LIMIT,
+		Enabled:      AUDIT_ENABLED,
+		Failure:      AUDIT_FAIL_PRINTK,
+		BacklogLimit: 8192,
+	}
+	if status != expected {
+		t.Errorf("AuditConfigure: expected %+v, found %+v", expected, status)
+	}
+
+	if err := AuditConfigure(s, AuditConfig{}); err != nil || len(s.sent) != 1 {
+		t.Errorf("AuditConfigure: expected no request without fields, found %d %v", len(s.sent), err)
+	}
+	invalid := 3
+	if err := AuditConfigure(s, AuditConfig{Failure: &invalid}); err == nil {
+		t.Errorf("AuditConfigure: expected error for invalid failure mode 3")
+	}
+}
+
+func TestAuditLimits(t *testing.T) {
+	status := auditStatus{Enabled: 1, RateLimit: 500, BacklogLimit: 8192, BacklogWaitTime: 60000}
+	short := false
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			if request.Header.Type != uint16(AUDIT_GET) {
+				return []NetlinkMessage{testAckMessage(request, 0)}
+			}
+			data := testStatusData(status)
+			if short {
+				// kernels before 3.14 lack backlog_wait_time, the tenth field
+				data = data[:9*4]
+			}
+			return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET), data)}
+		},
+	}
+	for _, tt := range []struct {
+		name     string
+		get      func(Netlink) (int, error)
+		expected int
+	}{
+		{"AuditGetRateLimit", AuditGetRateLimit, 500},
+		{"AuditGetBacklogLimit", AuditGetBacklogLimit, 8192},
+		{"AuditGetBacklogWaitTime", AuditGetBacklogWaitTime, 60000},
+	} {
+		if value, err := tt.get(s); err != nil || value != tt.expected {
+			t.Errorf("%v: expected %d, found %d (%v)", tt.name, tt.expected, value, err)
+		}
+	}
+	short = true
+	if limit, err := AuditGetBacklogLimit(s); err != nil || limit != 8192 {
+		t.Errorf("AuditGetBacklogLimit: expected 8192 from a short status, found %d (%v)", limit, err)
+	}
+	if _, err := AuditGetBacklogWaitTime(s); errors.Cause(err) != ErrFeatureUnsupported {
+		t.Errorf("AuditGetBacklogWaitTime: expected ErrFeatureUnsupported, found %v", err)
+	}
+
+	if err := AuditSetBacklogWaitTime(s, 15000); err != nil {
+		t.Fatalf("AuditSetBacklogWaitTime failed %v", err)
+	}
+	var set auditStatus
+	if err := binary.Read(bytes.NewReader(s.sent[len(s.sent)-1].Data), nativeEndian(), &set); err != nil {
+		t.Fatalf("AuditSetBacklogWaitTime: unreadable request %v", err)
+	}
+	if set != (auditStatus{Mask: AUDIT_STATUS_BACKLOG_WAIT_TIME, BacklogWaitTime: 15000}) {
+		t.Errorf("AuditSetBacklogWaitTime: unexpected request %+v", set)
+	}
+	if err := AuditSetBacklogWaitTime(s, -1); err == nil {
+		t.Errorf("AuditSetBacklogWaitTime: expected error for -1")
+	}
+}
+
+func TestAuditGetStatus(t *testing.T) {
+	status := auditStatus{Enabled: 1, Failure: 2, Pid: 1234, RateLimit: 500, BacklogLimit: 8192, Lost: 7, Backlog: 3,
+		Version: 0x7f, BacklogWaitTime: 60000, BacklogWaitTimeActual: 250}
+	size := 0
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			data := testStatusData(status)
+			if size > 0 {
+				data = data[:size]
+			}
+			return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET), data)}
+		},
+	}
+	for _, tt := range []struct {
+		size     int
+		expected AuditStatus
+	}{
+		{0, AuditStatus{Enabled: 1, Failure: 2, PID: 1234, RateLimit: 500, BacklogLimit: 8192, Lost: 7, Backlog: 3,
+			FeatureBitmap: 0x7f, BacklogWaitTime: 60000, BacklogWaitTimeActual: 250}},
+		// kernels before 5.9 lack backlog_wait_time_actual
+		{10 * 4, AuditStatus{Enabled: 1, Failure: 2, PID: 1234, RateLimit: 500, BacklogLimit: 8192, Lost: 7, Backlog: 3,
+			FeatureBitmap: 0x7f, BacklogWaitTime: 60000, BacklogWaitTimeActual: -1}},
+		// and kernels before 3.14 backlog_wait_time
+		{9 * 4, AuditStatus{Enabled: 1, Failure: 2, PID: 1234, RateLimit: 500, BacklogLimit: 8192, Lost: 7, Backlog: 3,
+			FeatureBitmap: 0x7f, BacklogWaitTime: -1, BacklogWaitTimeActual: -1}},
+	} {
+		size = tt.size
+		found, err := AuditGetStatus(s)
+		if err != nil {
+			t.Fatalf("AuditGetStatus failed %v", err)
+		}
+		if *found != tt.expected {
+			t.Errorf("AuditGetStatus: status of %d bytes: expected %+v, found %+v", tt.size, tt.expected, *found)
+		}
+	}
+}
+
+func TestAuditSetFailureMode(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	for name, expected := range map[string]int{"silent": AUDIT_FAIL_SILENT, "printk": AUDIT_FAIL_PRINTK, "2": AUDIT_FAIL_PANIC} {
+		mode, err := ParseFailureMode(name)
+		if err != nil || mode != expected {
+			t.Errorf("ParseFailureMode(%q): expected %d, found %d (%v)", name, expected, mode, err)
+		}
+	}
+	if _, err := ParseFailureMode("reboot"); err == nil {
+		t.Errorf("ParseFailureMode: expected error for reboot")
+	}
+
+	if err := AuditSetFailureMode(s, AUDIT_FAIL_PANIC); err != nil {
+		t.Fatalf("AuditSetFailureMode failed %v", err)
+	}
+	var set auditStatus
+	if err := binary.Read(bytes.NewReader(s.sent[len(s.sent)-1].Data), nativeEndian(), &set); err != nil {
+		t.Fatalf("AuditSetFailureMode: unreadable request %v", err)
+	}
+	if set != (auditStatus{Mask: AUDIT_STATUS_FAILURE, Failure: AUDIT_FAIL_PANIC}) {
+		t.Errorf("AuditSetFailureMode: unexpected request %+v", set)
+	}
+	if err := AuditSetFailureMode(s, 3); err == nil {
+		t.Errorf("AuditSetFailureMode: expected error for 3")
+	}
+}
+
+func TestAuditGetSignalInfo(t *testing.T) {
+	var data []byte
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_SIGNAL_INFO), data)}
+		},
+	}
+	data = make([]byte, 8)
+	nativeEndian().PutUint32(data[0:4], 1000)
+	nativeEndian().PutUint32(data[4:8], 4321)
+	data = append(data, "system_u:system_r:init_t:s0\x00"...)
+	info, err := AuditGetSignalInfo(s)
+	if err != nil {
+		t.Fatalf("AuditGetSignalInfo failed %v", err)
+	}
+	expected := AuditSignalInfo{UID: 1000, PID: 4321, Context: "system_u:system_r:init_t:s0"}
+	if *info != expected {
+		t.Errorf("AuditGetSignalInfo: expected %+v, found %+v", expected, *info)
+	}
+	if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_SIGNAL_INFO) || s.sent[0].Header.Flags&syscall.NLM_F_ACK != 0 {
+		t.Errorf("AuditGetSignalInfo: unexpected request %+v", s.sent)
+	}
+
+	data = data[:4]
+	if _, err := AuditGetSignalInfo(s); err == nil {
+		t.Errorf("AuditGetSignalInfo: expected error for a short reply")
+	}
+}
+
+func TestErrPermission(t *testing.T) {
+	errno := int32(syscall.EPERM)
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, errno)}
+		},
+	}
+	commands := map[string]func() error{
+		"AuditSetEnabled": func() error { return AuditSetEnabled(s, 1) },
+		"AuditSetPID":     func() error { return AuditSetPID(s, 1234) },
+		"AuditIsEnabled": func() error {
+			_, _, err := AuditIsEnabled(s)
+			return err
+		},
+		"SetRules": func() error {
+			_, err := SetRules(s, []byte(`{"syscall_rules": [{"key": "rename", "fields": [{"name": "arch", "value": 64, "op": "eq"}], "syscalls": ["rename"], "actions": ["exit", "always"]}]}`))
+			return err
+		},
+		"AuditRecvReply": func() error {
+			seq, err := AuditSend(s, uint16(AUDIT_GET), nil)
+			if err != nil {
+				return err
+			}
+			_, err = AuditRecvReply(s, seq, 0)
+			return err
+		},
+	}
+	for _, errno = range []int32{int32(syscall.EPERM), int32(syscall.EACCES)} {
+		for name, command := range commands {
+			if err := command(); errors.Cause(err) != ErrPermission {
+				t.Errorf("%v: expected ErrPermission for errno %d, found %v", name, errno, err)
+			}
+		}
+	}
+	errno = int32(syscall.EINVAL)
+	if err := AuditSetEnabled(s, 1); err == nil || errors.Cause(err) == ErrPermission {
+		t.Errorf("AuditSetEnabled: expected an error other than ErrPermission for EINVAL, found %v", err)
+	}
+}
+
+func TestErrAuditLocked(t *testing.T) {
+	status := auditStatus{Enabled: AUDIT_LOCKED}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			if request.Header.Type == uint16(AUDIT_GET) {
+				return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET), testStatusData(status))}
+			}
+			return []NetlinkMessage{testAckMessage(request, int32(syscall.EPERM))}
+		},
+	}
+	commands := map[string]func() error{
+		"AuditSetEnabled":   func() error { return AuditSetEnabled(s, 0) },
+		"AuditSetImmutable": func() error { return AuditSetImmutable(s) },
+		"AuditSetRateLimit": func() error { return AuditSetRateLimit(s, 100) },
+		"SetRules": func() error {
+			_, err := SetRules(s, []byte("-a always,exit -F arch=b64 -S rename -k rename\n"))
+			return err
+		},
+	}
+	for name, command := range commands {
+		if err := command(); errors.Cause(err) != ErrAuditLocked {
+			t.Errorf("%v: expected ErrAuditLocked, found %v", name, err)
+		}
+	}
+	if locked, err := AuditIsImmutable(s); err != nil || !locked {
+		t.Errorf("AuditIsImmutable: expected the locked state, found %v (%v)", locked, err)
+	}
+
+	// EPERM of an unlocked configuration is a missing permission
+	status.Enabled = 1
+	for name, command := range commands {
+		if err := command(); errors.Cause(err) != ErrPermission {
+			t.Errorf("%v: expected ErrPermission, found %v", name, err)
+		}
+	}
+}
+
+func TestAuditSendRecvReply(t *testing.T) {
+	m := BuildNetlinkMessage(uint16(AUDIT_GET), syscall.NLM_F_REQUEST, []byte{1, 2, 3})
+	if m.Header.Len != syscall.NLMSG_HDRLEN+3 || len(m.Data) != 4 || m.Header.Flags != syscall.NLM_F_REQUEST {
+		t.Errorf("BuildNetlinkMessage: unexpected message %+v", m)
+	}
+	next := BuildNetlinkMessage(uint16(AUDIT_GET), syscall.NLM_F_REQUEST, nil)
+	if next.Header.Seq == m.Header.Seq {
+		t.Errorf("BuildNetlinkMessage: expected distinct sequence numbers")
+	}
+
+	reply := []byte("reply data")
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			switch request.Header.Type {
+			case uint16(AUDIT_GET):
+				// events and the ack can come before the reply
+				return []NetlinkMessage{
+					testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): op=add_rule res=1`),
+					testAckMessage(request, 0),
+					testReplyMessage(request, uint16(AUDIT_GET), reply),
+				}
+			case uint16(AUDIT_SET):
+				return []NetlinkMessage{testAckMessage(request, 0)}
+			}
+			return []NetlinkMessage{testAckMessage(request, int32(syscall.EINVAL))}
+		},
+	}
+	seq, err := AuditSend(s, uint16(AUDIT_GET), nil)
+	if err != nil {
+		t.Fatalf("AuditSend failed %v", err)
+	}
+	if s.sent[0].Header.Flags != syscall.NLM_F_REQUEST|syscall.NLM_F_ACK || s.sent[0].Header.Seq != seq {
+		t.Errorf("AuditSend: unexpected request %+v", s.sent[0].Header)
+	}
+	data, err := AuditRecvReply(s, seq, uint16(AUDIT_GET))
+	if err != nil {
+		t.Fatalf("AuditRecvReply failed %v", err)
+	}
+	if !bytes.Equal(data, reply) || len(s.pending) != 0 {
+		t.Errorf("AuditRecvReply: expected %q, found %q", reply, data)
+	}
+
+	seq, _ = AuditSend(s, uint16(AUDIT_SET), []byte{0})
+	if data, err := AuditRecvReply(s, seq, 0); err != nil || data != nil {
+		t.Errorf("AuditRecvReply: expected ack, found %v %v", data, err)
+	}
+	seq, _ = AuditSend(s, uint16(AUDIT_TRIM), nil)
+	if _, err := AuditRecvReply(s, seq, 0); err == nil {
+		t.Errorf("AuditRecvReply: expected error for negative ack")
+	}
+}
+
+func TestNonblockingReceive(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skipf("skipping netlink socket based tests: not root user")
+	}
+	s, err := NewNetlinkConnection()
+	if err != nil {
+		t.Fatalf("NewNetlinkConnection failed %v", err)
+	}
+	defer s.Close()
+	if s.Fd() <= 0 {
+		t.Errorf("Fd: unexpected file descriptor %d", s.Fd())
+	}
+	// events sent to the audit pid may be queued, they are drained before the socket would block
+	drain := func(block int) error {
+		for i := 0; i < 100; i++ {
+			if _, err := s.ReceiveNoParse(MAX_AUDIT_MESSAGE_LENGTH, block, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := drain(syscall.MSG_DONTWAIT); err != ErrWouldBlock {
+		t.Errorf("ReceiveNoParse: expected ErrWouldBlock for MSG_DONTWAIT, found %v", err)
+	}
+	if err := s.SetNonblock(true); err != nil {
+		t.Fatalf("SetNonblock failed %v", err)
+	}
+	if err := drain(0); err != ErrWouldBlock {
+		t.Errorf("ReceiveNoParse: expected ErrWouldBlock, found %v", err)
+	}
+	// the receive loops wait for the socket to become readable
+	start := time.Now()
+	if err := s.waitReadable(50 * time.Millisecond); err != nil || time.Since(start) < 40*time.Millisecond {
+		t.Errorf("waitReadable: expected to time out, returned after %v with %v", time.Since(start), err)
+	}
+	// the reply is read once it arrived
+	wb := newNetlinkAuditRequest(uint16(AUDIT_GET), syscall.AF_NETLINK, 0)
+	if err := s.Send(wb); err != nil {
+		t.Fatalf("Send failed %v", err)
+	}
+	start = time.Now()
+	if err := s.waitReadable(5 * time.Second); err != nil || time.Since(start) > time.Second {
+		t.Errorf("waitReadable: expected the reply, returned after %v with %v", time.Since(start), err)
+	}
+	found := false
+	for i := 0; i < 100 && !found; i++ {
+		msgs, err := s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+		if err == ErrWouldBlock {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Receive failed %v", err)
+		}
+		for _, m := range msgs {
+			found = found || m.Header.Seq == wb.Header.Seq
+		}
+	}
+	if !found {
+		t.Errorf("Receive: expected the AUDIT_GET reply")
+	}
+
+	// the mode can be switched while another go-routine receives (see go test -race)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			s.ReceiveNoParse(MAX_AUDIT_MESSAGE_LENGTH, syscall.MSG_DONTWAIT, nil)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if err := s.SetNonblock(i%2 == 0); err != nil {
+			t.Errorf("SetNonblock failed %v", err)
+		}
+	}
+	<-done
+}
+
+func TestClose(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skipf("skipping netlink socket based tests: not root user")
+	}
+	s, err := NewNetlinkConnection()
+	if err != nil {
+		t.Fatalf("NewNetlinkConnection failed %v", err)
+	}
+	if err := s.SetsockRecvTO(50); err != nil {
+		t.Fatalf("SetsockRecvTO failed %v", err)
+	}
+	// a blocked receive loop returns once the connection is closed
+	done := make(chan bool)
+	returned := make(chan struct{})
+	go func() {
+		GetAuditMessages(s, func(*AuditEvent, error, ...interface{}) {}, &done)
+		close(returned)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := s.Close(); err != nil {
+		t.Errorf("Close failed %v", err)
+	}
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("GetAuditMessages: expected to return after Close")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Close: expected closing twice to be safe, found %v", err)
+	}
+	if _, err := s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, nil); err != ErrClosed {
+		t.Errorf("Receive: expected ErrClosed, found %v", err)
+	}
+	if _, err := s.ReceiveNoParse(MAX_AUDIT_MESSAGE_LENGTH, 0, nil); err != ErrClosed {
+		t.Errorf("ReceiveNoParse: expected ErrClosed, found %v", err)
+	}
+	if err := s.Send(newNetlinkAuditRequest(uint16(AUDIT_GET), syscall.AF_NETLINK, 0)); err != ErrClosed {
+		t.Errorf("Send: expected ErrClosed, found %v", err)
+	}
+
+	// a receive without timeout is woken by Close
+	s, err = NewNetlinkConnection()
+	if err != nil {
+		t.Fatalf("NewNetlinkConnection failed %v", err)
+	}
+	received := make(chan error)
+	go func() {
+		_, err := s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+		received <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := s.Close(); err != nil {
+		t.Errorf("Close failed %v", err)
+	}
+	select {
+	case err := <-received:
+		if err != ErrClosed {
+			t.Errorf("Receive: expected ErrClosed, found %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Receive: expected to return after Close")
+	}
+}
+
+func TestNewNetlinkConnectionInNS(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skipf("skipping netlink socket based tests: not root user")
+	}
+	current, err := os.Readlink("/proc/self/ns/net")
+	if err != nil {
+		t.Skipf("skipping namespace test: %v", err)
+	}
+	s, err := NewNetlinkConnectionInNS("/proc/self/ns/net")
+	if err != nil {
+		t.Fatalf("NewNetlinkConnectionInNS failed %v", err)
+	}
+	defer s.Close()
+	wb := newNetlinkAuditRequest(uint16(AUDIT_GET), syscall.AF_NETLINK, 0)
+	if err := s.Send(wb); err != nil {
+		t.Fatalf("Send failed %v", err)
+	}
+	if err := auditGetReply(s, MAX_AUDIT_MESSAGE_LENGTH, 0, wb.Header.Seq); err != nil {
+		t.Errorf("NewNetlinkConnectionInNS: request failed %v", err)
+	}
+	// the status follows the ack, read it so it isn't left to the next socket bound to the same port
+	s.SetsockRecvTO(1000)
+	s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+	if ns, _ := os.Readlink(fmt.Sprintf("/proc/self/task/%d/ns/net", syscall.Gettid())); ns != current {
+		t.Errorf("NewNetlinkConnectionInNS: expected the thread in %v, found %v", current, ns)
+	}
+	if _, err := NewNetlinkConnectionInNS("/nonexistent/ns/net"); err == nil {
+		t.Errorf("NewNetlinkConnectionInNS: expected error for a missing namespace")
+	}
+}
+
+func TestNewMulticastNetlinkConnection(t *testing.T) {
+	s, err := NewMulticastNetlinkConnection()
+	if err != nil {
+		// needs CAP_AUDIT_READ and kernel 3.16
+		t.Skipf("skipping multicast test: %v", err)
+	}
+	defer s.Close()
+	sa, err := syscall.Getsockname(s.Fd())
+	if err != nil {
+		t.Fatalf("Getsockname failed %v", err)
+	}
+	if nl, ok := sa.(*syscall.SockaddrNetlink); !ok || nl.Groups != 1<<(AUDIT_NLGRP_READLOG-1) {
+		t.Errorf("NewMulticastNetlinkConnection: expected the READLOG group, found %+v", sa)
+	}
+}
+
+func TestEnsureRegistered(t *testing.T) {
+	var registered uint32
+	newConn := func(accept bool) *testReplyNetlinkConn {
+		return &testReplyNetlinkConn{
+			reply: func(request NetlinkMessage) []NetlinkMessage {
+				switch request.Header.Type {
+				case uint16(AUDIT_SET):
+					if !accept {
+						// the kernel refuses while another daemon is registered
+						return []NetlinkMessage{testAckMessage(request, int32(syscall.EEXIST))}
+					}
+					registered = nativeEndian().Uint32(request.Data[12:16])
+					return []NetlinkMessage{testAckMessage(request, 0)}
+				case uint16(AUDIT_GET):
+					return []NetlinkMessage{
+						testAckMessage(request, 0),
+						testReplyMessage(request, uint16(AUDIT_GET), testStatusData(auditStatus{Enabled: 1, Pid: registered})),
+					}
+				}
+				return []NetlinkMessage{testAckMessage(request, int32(syscall.EINVAL))}
+			},
+		}
+	}
+
+	registered = 1
+	s := newConn(false)
+	if ok, err := AuditIsRegistered(s); err != nil || ok {
+		t.Errorf("AuditIsRegistered: expected false, found %v %v", ok, err)
+	}
+	if err := EnsureRegistered(s); errors.Cause(err) != ErrNotRegistered || !strings.Contains(err.Error(), "audit pid is 1") {
+		t.Errorf("EnsureRegistered: expected ErrNotRegistered, found %v", err)
+	}
+	s = newConn(true)
+	if err := EnsureRegistered(s); err != nil {
+		t.Errorf("EnsureRegistered failed %v", err)
+	}
+	if ok, err := AuditIsRegistered(s); err != nil || !ok {
+		t.Errorf("AuditIsRegistered: expected true, found %v %v", ok, err)
+	}
+
+	// TryRegisterPID leaves the registration of another process alone
+	registered = 1
+	s = newConn(true)
+	if pid, err := TryRegisterPID(s); errors.Cause(err) != ErrNotRegistered || pid != 1 || registered != 1 {
+		t.Errorf("TryRegisterPID: expected ErrNotRegistered with pid 1, found %v %v (registered %d)", pid, err, registered)
+	}
+	registered = 0
+	if pid, err := TryRegisterPID(s); err != nil || pid != 0 || registered != uint32(os.Getpid()) {
+		t.Errorf("TryRegisterPID: expected the registration, found %v %v (registered %d)", pid, err, registered)
+	}
+	if pid, err := TryRegisterPID(s); err != nil || pid != os.Getpid() {
+		t.Errorf("TryRegisterPID: expected the pid of the process, found %v %v", pid, err)
+	}
+}
+
+func TestSetSocketReceiveBuffer(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skipf("skipping netlink socket based tests: not root user")
+	}
+	s, err := NewNetlinkConnection()
+	if err != nil {
+		t.Fatalf("NewNetlinkConnection failed %v", err)
+	}
+	defer s.Close()
+	const size = 4 << 20
+	if err := s.SetSocketReceiveBuffer(size); err != nil {
+		t.Fatalf("SetSocketReceiveBuffer failed %v", err)
+	}
+	// root may exceed rmem_max, the kernel doubles the size
+	granted, err := s.SocketReceiveBuffer()
+	if err != nil {
+		t.Fatalf("SocketReceiveBuffer failed %v", err)
+	}
+	if granted != 2*size {
+		t.Errorf("SocketReceiveBuffer: expected %d, found %d", 2*size, granted)
+	}
+}
+
+func TestNewNetlinkConnectionWithOptions(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skipf("skipping netlink socket based tests: not root user")
+	}
+	const size = 4 << 20
+	s, err := NewNetlinkConnectionWithOptions(ConnectionOptions{ReceiveBuffer: size})
+	if err != nil {
+		t.Fatalf("NewNetlinkConnectionWithOptions failed %v", err)
+	}
+	defer s.Close()
+	if granted, err := s.SocketReceiveBuffer(); err != nil || granted != 2*size {
+		t.Errorf("NewNetlinkConnectionWithOptions: expected a buffer of %d, found %d (%v)", 2*size, granted, err)
+	}
+	d, err := NewNetlinkConnectionWithOptions(ConnectionOptions{})
+	if err != nil {
+		t.Fatalf("NewNetlinkConnectionWithOptions failed %v", err)
+	}
+	defer d.Close()
+	if granted, err := d.SocketReceiveBuffer(); err != nil || granted == 2*size {
+		t.Errorf("NewNetlinkConnectionWithOptions: expected the default buffer, found %d (%v)", granted, err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skipf("skipping netlink socket based tests: not root user")
+	}
+	s, err := NewNetlinkConnection()
+	if err != nil {
+		t.Fatalf("NewNetlinkConnection failed %v", err)
+	}
+	defer s.Close()
+	if stats := s.Stats(); stats != (ReceiveStats{}) {
+		t.Errorf("Stats: expected no receives, found %+v", stats)
+	}
+	if _, _, err := AuditIsEnabled(s); err != nil {
+		t.Fatalf("AuditIsEnabled failed %v", err)
+	}
+	stats := s.Stats()
+	if stats.Receives == 0 || stats.Messages < stats.Receives || stats.Bytes < uint64(syscall.NLMSG_HDRLEN)*stats.Messages ||
+		stats.LastMessages == 0 || stats.BufferSize == 0 {
+		t.Errorf("Stats: unexpected counters %+v", stats)
+	}
+
+	ack, event := testAckMessage(NetlinkMessage{}, 0), testEventMessage(AUDIT_EOE, `audit(1464163771.720:20): `)
+	b := append(ack.ToWireFormat(nil), event.ToWireFormat(nil)...)
+	if n := countMessages(b); n != 2 {
+		t.Errorf("countMessages: expected 2, found %d", n)
+	}
+}
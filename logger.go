@@ -0,0 +1,30 @@
+package libaudit
+
+import "sync/atomic"
+
+// Logger receives the diagnostics of the library (messages skipped, replies not matching their request,
+// receive errors ...), *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// logger holds the loggerHolder set by SetLogger, a nil Logger discards the diagnostics
+var logger atomic.Value
+
+// loggerHolder lets logger store Loggers of different types
+type loggerHolder struct {
+	l Logger
+}
+
+// SetLogger sets the Logger receiving the diagnostics of the receive loops and the commands, nil (the default)
+// discards them. The output is meant for debugging and its format may change.
+func SetLogger(l Logger) {
+	logger.Store(loggerHolder{l})
+}
+
+// debugf formats the diagnostic to the Logger if one is set
+func debugf(format string, v ...interface{}) {
+	if h, _ := logger.Load().(loggerHolder); h.l != nil {
+		h.l.Printf("libaudit: "+format, v...)
+	}
+}
@@ -0,0 +1,64 @@
+package libaudit
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// testLogger records the diagnostics
+type testLogger []string
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	*l = append(*l, fmt.Sprintf(format, v...))
+}
+
+func (l *testLogger) contains(s string) bool {
+	for _, line := range *l {
+		if strings.Contains(line, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSetLogger(t *testing.T) {
+	var l testLogger
+	SetLogger(&l)
+	defer SetLogger(nil)
+	SuppressEventTypes(AUDIT_EOE)
+	defer SuppressEventTypes()
+
+	s := &testReplyNetlinkConn{
+		pending: []NetlinkMessage{testEventMessage(AUDIT_EOE, `audit(1464163771.720:20): `)},
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:021): arch=c000003e syscall=2 success=yes exit=3`),
+				testAckMessage(request, 0)}
+		},
+	}
+	if err := ProcessOnce(s, func(e *AuditEvent, err error, args ...interface{}) {}); err != nil {
+		t.Fatalf("ProcessOnce failed %v", err)
+	}
+	if !l.contains("libaudit: skipping suppressed message of type AUDIT_EOE") {
+		t.Errorf("SetLogger: expected the suppressed message to be logged, found %q", l)
+	}
+	seq, err := AuditSend(s, syscall.NLMSG_NOOP, nil)
+	if err != nil {
+		t.Fatalf("AuditSend failed %v", err)
+	}
+	if _, err := AuditRecvReply(s, seq, 0); err != nil {
+		t.Fatalf("AuditRecvReply failed %v", err)
+	}
+	if !l.contains(fmt.Sprintf("AuditRecvReply: skipping message of type AUDIT_SYSCALL with seq 0, expected %d", seq)) {
+		t.Errorf("SetLogger: expected the skipped event to be logged, found %q", l)
+	}
+
+	SetLogger(nil)
+	l = nil
+	s.pending = []NetlinkMessage{testEventMessage(AUDIT_EOE, `audit(1464163771.720:20): `)}
+	ProcessOnce(s, func(e *AuditEvent, err error, args ...interface{}) {})
+	if len(l) != 0 {
+		t.Errorf("SetLogger: expected no output by default, found %q", l)
+	}
+}
@@ -0,0 +1,90 @@
+package libaudit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// ErrLoginUIDImmutable is returned (wrapped, see errors.Cause) by AuditSetLoginUID when the kernel refuses to
+// change the login uid of a process which already has one: the loginuid is immutable (AUDIT_FEATURE_LOGINUID_IMMUTABLE,
+// CONFIG_AUDIT_LOGINUID_IMMUTABLE), the process lacks CAP_AUDIT_CONTROL or only unsetting is allowed
+// (AUDIT_FEATURE_ONLY_UNSET_LOGINUID).
+var ErrLoginUIDImmutable = errors.New("loginuid is already set and can't be changed")
+
+// procTaskDir returns the proc directory of the calling thread, the kernel keeps the login uid and
+// session per thread and only lets a thread change its own
+var procTaskDir = func() string {
+	return fmt.Sprintf("/proc/self/task/%d", syscall.Gettid())
+}
+
+// readProcID reads the login uid or session id file name of the calling thread, -1 if it's unset
+func readProcID(name string) (int, error) {
+	b, err := ioutil.ReadFile(filepath.Join(procTaskDir(), name))
+	if err != nil {
+		return -1, err
+	}
+	value := strings.TrimSpace(string(b))
+	if value == auditUnset {
+		return -1, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return -1, err
+	}
+	return int(id), nil
+}
+
+// AuditGetLoginUID returns the login uid (auid) of the calling thread, -1 if it's unset (i.e. for
+// daemons started at boot). Threads inherit it from the thread that created them.
+func AuditGetLoginUID() (int, error) {
+	auid, err := readProcID("loginuid")
+	if err != nil {
+		return -1, errors.Wrap(err, "AuditGetLoginUID failed")
+	}
+	return auid, nil
+}
+
+// AuditGetSessionID returns the audit session id (ses) of the calling thread, -1 if it's unset. The kernel
+// assigns a new session when the login uid is set.
+func AuditGetSessionID() (int, error) {
+	ses, err := readProcID("sessionid")
+	if err != nil {
+		return -1, errors.Wrap(err, "AuditGetSessionID failed")
+	}
+	return ses, nil
+}
+
+// AuditSetLoginUID sets the login uid of the calling thread like pam_loginuid does, -1 unsets it. The kernel
+// starts a new audit session for it (see AuditGetSessionID). The login uid belongs to the OS thread, lock the
+// go-routine to its thread (runtime.LockOSThread) before setting it and start the processes which inherit it
+// from the same go-routine. Setting an already set login uid fails with ErrLoginUIDImmutable unless the process
+// has CAP_AUDIT_CONTROL and the kernel allows changes.
+func AuditSetLoginUID(auid int) error {
+	value := auditUnset
+	if auid != -1 {
+		if auid < 0 || int64(auid) >= math.MaxUint32 {
+			return fmt.Errorf("AuditSetLoginUID: invalid login uid %d", auid)
+		}
+		value = strconv.Itoa(auid)
+	}
+	f, err := os.OpenFile(filepath.Join(procTaskDir(), "loginuid"), os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return errors.Wrap(err, "AuditSetLoginUID failed")
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte(value)); err != nil {
+		if perr, ok := err.(*os.PathError); ok && perr.Err == syscall.EPERM {
+			return errors.Wrap(ErrLoginUIDImmutable, fmt.Sprintf("AuditSetLoginUID: writing %v failed", value))
+		}
+		return errors.Wrap(err, "AuditSetLoginUID failed")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package libaudit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditLoginUID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loginuid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(orig func() string) { procTaskDir = orig }(procTaskDir)
+	procTaskDir = func() string { return dir }
+
+	for name, value := range map[string]string{"loginuid": "4294967295", "sessionid": "4294967295"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if auid, err := AuditGetLoginUID(); err != nil || auid != -1 {
+		t.Errorf("AuditGetLoginUID: expected -1 for an unset loginuid, found %d (%v)", auid, err)
+	}
+	if ses, err := AuditGetSessionID(); err != nil || ses != -1 {
+		t.Errorf("AuditGetSessionID: expected -1 for an unset session, found %d (%v)", ses, err)
+	}
+
+	if err := AuditSetLoginUID(1000); err != nil {
+		t.Fatalf("AuditSetLoginUID failed %v", err)
+	}
+	if auid, err := AuditGetLoginUID(); err != nil || auid != 1000 {
+		t.Errorf("AuditGetLoginUID: expected 1000, found %d (%v)", auid, err)
+	}
+	if err := AuditSetLoginUID(-1); err != nil {
+		t.Fatalf("AuditSetLoginUID failed %v", err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "loginuid")); string(b) != "4294967295" {
+		t.Errorf("AuditSetLoginUID: expected the unset loginuid, found %q", b)
+	}
+	for _, auid := range []int{-2, 4294967295} {
+		if err := AuditSetLoginUID(auid); err == nil {
+			t.Errorf("AuditSetLoginUID: expected error for %d", auid)
+		}
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "sessionid"), []byte("3\n"), 0600)
+	if ses, err := AuditGetSessionID(); err != nil || ses != 3 {
+		t.Errorf("AuditGetSessionID: expected 3, found %d (%v)", ses, err)
+	}
+	os.Remove(filepath.Join(dir, "sessionid"))
+	if _, err := AuditGetSessionID(); err == nil {
+		t.Errorf("AuditGetSessionID: expected error without sessionid file")
+	}
+}
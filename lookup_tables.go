@@ -66,15 +66,17 @@ var fieldLookupMap = map[string]fieldType{
 	"cap_pp":         typeCapBitmap,
 	"cap_fi":         typeCapBitmap,
 	"cap_fp":         typeCapBitmap,
+	"cap_pa":         typeCapBitmap,
 	"fp":             typeCapBitmap,
 	"fi":             typeCapBitmap,
-	"fe":             typeCapBitmap,
 	"old_pp":         typeCapBitmap,
 	"old_pi":         typeCapBitmap,
 	"old_pe":         typeCapBitmap,
+	"old_pa":         typeCapBitmap,
 	"new_pp":         typeCapBitmap,
 	"new_pi":         typeCapBitmap,
 	"new_pe":         typeCapBitmap,
+	"new_pa":         typeCapBitmap,
 	"family":         typeNFProto,
 	"icmptype":       typeICMP,
 	"proto":          typeProtocol,
@@ -162,6 +164,7 @@ var fieldLookup = map[int]string{
 	AUDIT_OBJ_TYPE:      "obj_type",
 	AUDIT_OBJ_LEV_LOW:   "obj_lev_low",
 	AUDIT_OBJ_LEV_HIGH:  "obj_lev_high",
+	AUDIT_SESSIONID:     "sessionid",
 	AUDIT_DEVMAJOR:      "devmajor",
 	AUDIT_DEVMINOR:      "devminor",
 	AUDIT_INODE:         "inode",
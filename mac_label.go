@@ -0,0 +1,78 @@
+package libaudit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SELinuxContext holds the components of a SELinux security context (user:role:type:level).
+// Labels of other MACs (i.e. AppArmor profiles like "/usr/sbin/cupsd (enforce)") don't have
+// these components, only Label is set for them.
+type SELinuxContext struct {
+	User  string
+	Role  string
+	Type  string
+	Level string // sensitivity and categories, i.e. s0-s0:c0.c1023, empty if the policy has no MLS
+	Label string // the complete label
+}
+
+// IsSELinux reports whether the label was split into SELinux context components
+func (c *SELinuxContext) IsSELinux() bool {
+	return len(c.Type) > 0
+}
+
+// ParseContext parses the MAC label of subj, obj, scontext and tcontext fields.
+// SELinux contexts are split to their components, other labels are returned as they are in Label.
+func ParseContext(s string) (*SELinuxContext, error) {
+	s = strings.Trim(s, "\"")
+	if len(s) == 0 {
+		return nil, fmt.Errorf("ParseContext failed: empty label")
+	}
+	c := &SELinuxContext{Label: s}
+	parts := strings.SplitN(s, ":", 4)
+	if len(parts) < 3 || strings.ContainsAny(s, " /()") {
+		// not a SELinux context, i.e. AppArmor "unconfined" or "/usr/bin/foo (enforce)"
+		return c, nil
+	}
+	for _, p := range parts[:3] {
+		if len(p) == 0 {
+			return c, nil
+		}
+	}
+	c.User, c.Role, c.Type = parts[0], parts[1], parts[2]
+	if len(parts) == 4 {
+		c.Level = parts[3]
+	}
+	return c, nil
+}
+
+// macLabelFields are the fields of audit events that carry MAC labels
+var macLabelFields = []string{"subj", "obj", "scontext", "tcontext"}
+
+// InterpretContexts splits the SELinux contexts of the MAC label fields (subj, obj, scontext, tcontext)
+// and adds the components to Data as <field>_user, <field>_role, <field>_type and <field>_level.
+// The label fields are kept, labels that are not SELinux contexts are left as they are.
+func (e *AuditEvent) InterpretContexts() error {
+	for _, field := range macLabelFields {
+		value, ok := e.Data[field]
+		if !ok {
+			continue
+		}
+		c, err := ParseContext(value)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("InterpretContexts failed for %v", field))
+		}
+		if !c.IsSELinux() {
+			continue
+		}
+		e.Data[field+"_user"] = c.User
+		e.Data[field+"_role"] = c.Role
+		e.Data[field+"_type"] = c.Type
+		if len(c.Level) > 0 {
+			e.Data[field+"_level"] = c.Level
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package libaudit
+
+import (
+	"testing"
+)
+
+func TestParseContext(t *testing.T) {
+	var tests = []struct {
+		label    string
+		expected SELinuxContext
+	}{
+		{"unconfined_u:unconfined_r:unconfined_t:s0-s0:c0.c1023", SELinuxContext{User: "unconfined_u", Role: "unconfined_r", Type: "unconfined_t", Level: "s0-s0:c0.c1023", Label: "unconfined_u:unconfined_r:unconfined_t:s0-s0:c0.c1023"}},
+		{"system_u:system_r:httpd_t", SELinuxContext{User: "system_u", Role: "system_r", Type: "httpd_t", Label: "system_u:system_r:httpd_t"}},
+		{"unconfined", SELinuxContext{Label: "unconfined"}},
+		{`"/usr/sbin/cupsd (enforce)"`, SELinuxContext{Label: "/usr/sbin/cupsd (enforce)"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseContext(tt.label)
+		if err != nil {
+			t.Fatalf("ParseContext failed %v", err)
+		}
+		if *c != tt.expected {
+			t.Errorf("ParseContext: expected %+v, found %+v", tt.expected, *c)
+		}
+	}
+	if _, err := ParseContext(""); err == nil {
+		t.Errorf("ParseContext: expected error for empty label")
+	}
+}
+
+func TestInterpretContexts(t *testing.T) {
+	x, err := ParseAuditEvent(`audit(1226874073.147:96): avc:  denied  { getattr } for  pid=2465 comm="httpd" path="/var/www/html/file1" dev=dm-0 ino=284133 scontext=unconfined_u:system_r:httpd_t:s0 tcontext=unconfined_u:object_r:samba_share_t:s0 tclass=file`, AUDIT_AVC, true)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if err := x.InterpretContexts(); err != nil {
+		t.Fatalf("InterpretContexts failed %v", err)
+	}
+	if x.Data["scontext_type"] != "httpd_t" || x.Data["tcontext_type"] != "samba_share_t" || x.Data["tcontext_role"] != "object_r" || x.Data["scontext_level"] != "s0" {
+		t.Errorf("InterpretContexts: unexpected fields %v", x.Data)
+	}
+	if x.Data["scontext"] != "unconfined_u:system_r:httpd_t:s0" {
+		t.Errorf("InterpretContexts: expected label to be kept, found %v", x.Data["scontext"])
+	}
+}
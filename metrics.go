@@ -0,0 +1,71 @@
+package libaudit
+
+import "sync/atomic"
+
+// Metrics receives the counters of the receive loops and the audit status, it lets integrators
+// export them (i.e. as Prometheus collectors) without the library depending on a metrics package.
+// The methods are called from the receive loops and have to be safe for concurrent use.
+type Metrics interface {
+	// IncEvent is called for every audit event received, with its type (SYSCALL, PATH, ...)
+	IncEvent(msgType string)
+	// IncParseError is called for every message that fails to parse
+	IncParseError()
+	// AddBytesRead is called with the size of the netlink messages received
+	AddBytesRead(n int)
+	// ObserveLost is called with the number of events lost by the kernel whenever the audit status is read
+	ObserveLost(n int)
+	// ObserveBacklog is called with the number of events queued in the kernel whenever the audit status is read
+	ObserveBacklog(n int)
+}
+
+// noopMetrics is the default Metrics, it discards everything
+type noopMetrics struct{}
+
+func (noopMetrics) IncEvent(string)    {}
+func (noopMetrics) IncParseError()     {}
+func (noopMetrics) AddBytesRead(int)   {}
+func (noopMetrics) ObserveLost(int)    {}
+func (noopMetrics) ObserveBacklog(int) {}
+
+// metrics holds the metricsHolder set by SetMetrics
+var metrics atomic.Value
+
+// metricsHolder lets metrics store Metrics of different types
+type metricsHolder struct {
+	m Metrics
+}
+
+// currentMetrics returns the Metrics set by SetMetrics, it is never nil so the hooks are called unconditionally
+func currentMetrics() Metrics {
+	if h, _ := metrics.Load().(metricsHolder); h.m != nil {
+		return h.m
+	}
+	return noopMetrics{}
+}
+
+// SetMetrics installs m to receive the metrics of the library, nil restores the no-op default.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		m = noopMetrics{}
+	}
+	metrics.Store(metricsHolder{m})
+}
+
+// observeMessages reports the size of the received netlink messages
+func observeMessages(msgs []NetlinkMessage) {
+	var n int
+	for _, m := range msgs {
+		n += int(m.Header.Len)
+	}
+	currentMetrics().AddBytesRead(n)
+}
+
+// observeEvent reports the outcome of parsing an audit event
+func observeEvent(e *AuditEvent, err error) {
+	if err != nil {
+		currentMetrics().IncParseError()
+	}
+	if e != nil {
+		currentMetrics().IncEvent(e.Type)
+	}
+}
@@ -0,0 +1,52 @@
+package libaudit
+
+import (
+	"syscall"
+	"testing"
+)
+
+// testMetrics counts the metrics reported by the library
+type testMetrics struct {
+	events      map[string]int
+	parseErrors int
+	bytesRead   int
+	lost        int
+	backlog     int
+}
+
+func (m *testMetrics) IncEvent(msgType string) { m.events[msgType]++ }
+func (m *testMetrics) IncParseError()          { m.parseErrors++ }
+func (m *testMetrics) AddBytesRead(n int)      { m.bytesRead += n }
+func (m *testMetrics) ObserveLost(n int)       { m.lost = n }
+func (m *testMetrics) ObserveBacklog(n int)    { m.backlog = n }
+
+func TestMetrics(t *testing.T) {
+	m := &testMetrics{events: make(map[string]int)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	event := testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): auid=1000 ses=2 op=add_rule key="passwd" list=4 res=1`)
+	s := &testReplyNetlinkConn{
+		pending: []NetlinkMessage{event, testEventMessage(AUDIT_CONFIG_CHANGE, `malformed`)},
+	}
+	cb := func(e *AuditEvent, err error, args ...interface{}) {}
+	ProcessOnce(s, cb)
+	ProcessOnce(s, cb)
+	if m.events["CONFIG_CHANGE"] != 1 || m.parseErrors != 1 {
+		t.Errorf("Metrics: unexpected counters %+v", m)
+	}
+	if m.bytesRead != int(event.Header.Len)+syscall.NLMSG_HDRLEN+len("malformed") {
+		t.Errorf("Metrics: unexpected bytes read %d", m.bytesRead)
+	}
+
+	status := auditStatus{Enabled: 1, Lost: 7, Backlog: 3}
+	s.reply = func(request NetlinkMessage) []NetlinkMessage {
+		return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_GET), testStatusData(status))}
+	}
+	if _, _, err := AuditIsEnabled(s); err != nil {
+		t.Fatalf("AuditIsEnabled failed %v", err)
+	}
+	if m.lost != 7 || m.backlog != 3 {
+		t.Errorf("Metrics: expected lost 7 backlog 3, found %+v", m)
+	}
+}
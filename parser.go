@@ -3,6 +3,7 @@ package libaudit
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -55,15 +56,59 @@ func parseAuditKeyValue(str string) map[string]string {
 
 }
 
+// FieldError reports a field of an audit message that couldn't be interpreted.
+type FieldError struct {
+	Field string
+	Value string // the value as found in the message, which the event keeps
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %v=%v: %v", e.Field, e.Value, e.Err)
+}
+
+// ParseWarnings is returned along with the event by ParseAuditEventLenient and the receivers in lenient mode
+// (SetLenientParsing) when fields of the message couldn't be interpreted, the event is usable nevertheless.
+type ParseWarnings []*FieldError
+
+func (w ParseWarnings) Error() string {
+	msgs := make([]string, 0, len(w))
+	for _, e := range w {
+		msgs = append(msgs, e.Error())
+	}
+	return "parsing: " + strings.Join(msgs, ", ")
+}
+
 // ParseAuditEvent parses an incoming audit message from kernel and returns an AuditEvent.
 // msgType is supposed to come from the calling function which holds the msg header indicating header type of the messages
 // it uses simple string parsing techniques and provider better performance than the regex parser
 // idea taken from parse_up_record(rnode* r) in ellist.c (libauparse)
 // any intersting looking audit message should be added to parser_test and see how parser performs against it
 func ParseAuditEvent(str string, msgType auditConstant, interpret bool) (*AuditEvent, error) {
+	return parseAuditEvent(str, msgType, interpret, true, false)
+}
+
+// ParseAuditEventLenient is similar to ParseAuditEvent but doesn't discard the event when a field fails
+// to be interpreted, the field keeps its value as found in the message and the event is returned along
+// with ParseWarnings listing the fields. Malformed messages (i.e. missing the audit(...) header) are
+// still an error without event.
+func ParseAuditEventLenient(str string, msgType auditConstant, interpret bool) (*AuditEvent, error) {
+	return parseAuditEvent(str, msgType, interpret, true, true)
+}
+
+// ParseAuditEventNoRaw is similar to ParseAuditEvent but leaves the Raw field of the AuditEvent empty,
+// for consumers that only use the parsed fields.
+func ParseAuditEventNoRaw(str string, msgType auditConstant, interpret bool) (*AuditEvent, error) {
+	return parseAuditEvent(str, msgType, interpret, false, false)
+}
+
+// parseAuditEvent is ParseAuditEvent which sets Raw only if keepRaw is true and
+// keeps the event on interpretation errors if lenient is set
+func parseAuditEvent(str string, msgType auditConstant, interpret bool, keepRaw bool, lenient bool) (*AuditEvent, error) {
 	var r record
-	var event = AuditEvent{
-		Raw: str,
+	var event AuditEvent
+	if keepRaw {
+		event.Raw = str
 	}
 	m := make(map[string]string)
 	if strings.HasPrefix(str, "audit(") {
@@ -186,6 +231,7 @@ func ParseAuditEvent(str string, msgType auditConstant, interpret bool) (*AuditE
 			fixPunctuantions(&value)
 			if key == "arch" {
 				// determine machine type
+				r.arch = value
 			}
 			if key == "a0" {
 				val, err := strconv.ParseInt(value, 16, 64)
@@ -218,24 +264,53 @@ func ParseAuditEvent(str string, msgType auditConstant, interpret bool) (*AuditE
 		}
 
 	}
+	var warnings ParseWarnings
 	if interpret {
 		for key, value := range m {
 			ivalue, err := interpretField(key, value, msgType, r)
 			if err != nil {
-				return nil, err
+				if !lenient {
+					return nil, err
+				}
+				warnings = append(warnings, &FieldError{Field: key, Value: value, Err: err})
+				continue
 			}
 			m[key] = ivalue
 		}
 	}
 
+	// the syscall name is added after interpretation so that it doesn't depend on it
+	if r.syscallNum != "" {
+		if name, err := recordSyscallName(r); err == nil {
+			m["syscall_name"] = name
+		}
+	}
+
 	event.Timestamp = timestamp
 	event.Serial = serial
 	event.Data = m
-	event.Type = msgType.String()[6:]
+	event.Type = msgType.typeName()
+	if warnings != nil {
+		sort.Slice(warnings, func(i, j int) bool { return warnings[i].Field < warnings[j].Field })
+		return &event, warnings
+	}
 	return &event, nil
 
 }
 
+// recordSyscallName returns the name of the syscall number of r from the syscall table of its arch,
+// records without arch field use the table of the native arch
+func recordSyscallName(r record) (string, error) {
+	_, arch := NativeArch()
+	if r.arch != "" {
+		var err error
+		if arch, err = parseArch(r.arch); err != nil {
+			return "", err
+		}
+	}
+	return AuditArchSyscallToName(r.syscallNum, arch)
+}
+
 // getSpaceSlice checks the index of the next space and put the string upto that space into
 // the second string, total number of characters processed is updated with each call to the function
 func getSpaceSlice(str *string, b *string, v *int) {
@@ -0,0 +1,112 @@
+package libaudit
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SyscallRecord holds the fields of a SYSCALL record most consumers look at, see ParseSyscallFast.
+type SyscallRecord struct {
+	Timestamp string
+	Serial    string
+	Arch      string // as reported by the kernel, i.e. c000003e
+	Syscall   int
+	Success   bool
+	Exit      int64
+	// PID and UID are -1 if the record lacks them
+	PID int
+	UID int
+	Exe string
+	Key string // the keys of the rules joined with \x01, empty if the rule has none
+}
+
+// Keys returns the keys of the rules of the record, nil if the rule has none.
+func (r *SyscallRecord) Keys() []string {
+	if len(r.Key) == 0 {
+		return nil
+	}
+	return strings.Split(r.Key, "\x01")
+}
+
+// ParseSyscallFast parses the data of a SYSCALL message as sent by the kernel (audit(...): arch=... syscall=...)
+// into a SyscallRecord. Unlike ParseAuditEvent it doesn't build a map of all the fields and skips the fields
+// it doesn't extract, so it is considerably cheaper for the hot path of consumers only interested in these.
+// Exe and key are decoded like PATH names (quoted or hex encoded), other fields are not interpreted.
+func ParseSyscallFast(data []byte) (*SyscallRecord, error) {
+	const prefix = "audit("
+	if !bytes.HasPrefix(data, []byte(prefix)) {
+		return nil, fmt.Errorf("parsing failed: malformed audit message")
+	}
+	data = data[len(prefix):]
+	end := bytes.Index(data, []byte("): "))
+	colon := bytes.IndexByte(data, ':')
+	if end < 0 || colon < 0 || colon > end {
+		return nil, fmt.Errorf("parsing failed: malformed audit message")
+	}
+	r := &SyscallRecord{
+		Timestamp: string(data[:colon]),
+		Serial:    string(data[colon+1 : end]),
+		PID:       -1,
+		UID:       -1,
+	}
+	data = data[end+3:]
+
+	var syscallFound bool
+	for len(data) > 0 {
+		// fields are separated by single spaces, only quoted values (exe, comm ...) may contain spaces
+		eq := bytes.IndexByte(data, '=')
+		if eq < 0 {
+			break
+		}
+		key := data[:eq]
+		data = data[eq+1:]
+		var value []byte
+		if len(data) > 0 && data[0] == '"' {
+			q := bytes.IndexByte(data[1:], '"')
+			if q < 0 {
+				return nil, fmt.Errorf("parsing failed: unterminated value of field %s", key)
+			}
+			value = data[:q+2]
+			data = data[q+2:]
+		} else if sp := bytes.IndexByte(data, ' '); sp >= 0 {
+			value = data[:sp]
+			data = data[sp:]
+		} else {
+			value = data
+			data = nil
+		}
+		if len(data) > 0 && data[0] == ' ' {
+			data = data[1:]
+		}
+
+		var err error
+		switch string(key) {
+		case "arch":
+			r.Arch = string(value)
+		case "syscall":
+			r.Syscall, err = strconv.Atoi(string(value))
+			syscallFound = err == nil
+		case "success":
+			r.Success = string(value) == "yes"
+		case "exit":
+			r.Exit, err = strconv.ParseInt(string(value), 10, 64)
+		case "pid":
+			r.PID, err = strconv.Atoi(string(value))
+		case "uid":
+			r.UID, err = strconv.Atoi(string(value))
+		case "exe":
+			r.Exe = decodeEncodedValue(string(value))
+		case "key":
+			r.Key = decodeEncodedValue(string(value))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing failed: field %s=%s: %v", key, value, err)
+		}
+	}
+	if !syscallFound {
+		return nil, fmt.Errorf("parsing failed: no syscall field")
+	}
+	return r, nil
+}
@@ -0,0 +1,78 @@
+package libaudit
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testFastSyscallMsg = `audit(1464163771.720:020): arch=c000003e syscall=59 success=yes exit=0 a0=1d4d3f0 a1=1d4c8c0 a2=1d3c530 a3=598 items=2 ppid=2734 pid=2735 auid=1000 uid=0 gid=0 euid=0 suid=0 fsuid=0 egid=0 sgid=0 fsgid=0 tty=pts1 ses=3 comm="ls" exe="/usr/bin/ls" key=6578656301657865637332`
+
+func TestParseSyscallFast(t *testing.T) {
+	r, err := ParseSyscallFast([]byte(testFastSyscallMsg))
+	if err != nil {
+		t.Fatalf("ParseSyscallFast failed %v", err)
+	}
+	expected := SyscallRecord{
+		Timestamp: "1464163771.720",
+		Serial:    "020",
+		Arch:      "c000003e",
+		Syscall:   59,
+		Success:   true,
+		Exit:      0,
+		PID:       2735,
+		UID:       0,
+		Exe:       "/usr/bin/ls",
+		Key:       "exec\x01execs2",
+	}
+	if *r != expected {
+		t.Errorf("ParseSyscallFast: expected %+v, found %+v", expected, *r)
+	}
+
+	// the general parser agrees
+	x, err := ParseAuditEvent(testFastSyscallMsg, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("ParseAuditEvent failed %v", err)
+	}
+	if x.Serial != r.Serial || x.Timestamp != r.Timestamp || x.Data["pid"] != "2735" || x.Data["arch"] != r.Arch {
+		t.Errorf("ParseSyscallFast: record %+v doesn't match event %+v", r, x)
+	}
+	if keys := r.Keys(); !reflect.DeepEqual(keys, []string{"exec", "execs2"}) || !reflect.DeepEqual(x.Keys(), keys) {
+		t.Errorf("Keys: expected keys exec and execs2, found %q and %q", keys, x.Keys())
+	}
+
+	r, err = ParseSyscallFast([]byte(`audit(1464163771.720:021): arch=40000003 syscall=5 success=no exit=-2 comm="my prog" exe="/tmp/my prog" key=(null)`))
+	if err != nil {
+		t.Fatalf("ParseSyscallFast failed %v", err)
+	}
+	if r.Success || r.Exit != -2 || r.PID != -1 || r.UID != -1 || r.Exe != "/tmp/my prog" || r.Key != "" {
+		t.Errorf("ParseSyscallFast: unexpected record %+v", r)
+	}
+	if r.Keys() != nil {
+		t.Errorf("Keys: expected no keys, found %q", r.Keys())
+	}
+
+	for _, msg := range []string{
+		``,
+		`arch=c000003e syscall=59`,
+		`audit(1464163771.720:022): arch=c000003e success=yes`,
+		`audit(1464163771.720:023): arch=c000003e syscall=x59`,
+		`audit(1464163771.720:024): syscall=59 exe="/usr/bin/ls`,
+	} {
+		if _, err := ParseSyscallFast([]byte(msg)); err == nil {
+			t.Errorf("ParseSyscallFast: expected error for %q", msg)
+		}
+	}
+}
+
+func BenchmarkParseSyscallFast(b *testing.B) {
+	data := []byte(testFastSyscallMsg)
+	for n := 0; n < b.N; n++ {
+		ParseSyscallFast(data)
+	}
+}
+
+func BenchmarkParseSyscallNative(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		ParseAuditEvent(testFastSyscallMsg, AUDIT_SYSCALL, false)
+	}
+}
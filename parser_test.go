@@ -28,7 +28,7 @@ var auditTests = []struct {
 			Timestamp: "1464176620.068",
 			Type:      "AVC",
 			Data: map[string]string{
-				"comm": `"chrome"`, "exe": `"/opt/google/chrome/chrome"`, "arch": "c000003e", "compat": "0", "code": "0x50000", "ses": "4294967295", "uid": "1000", "gid": "1000", "pid": "23975", "sig": "0", "syscall": "273", "ip": "0x7f1da6d8b694", "auid": "4294967295"},
+				"comm": `"chrome"`, "exe": `"/opt/google/chrome/chrome"`, "arch": "c000003e", "compat": "0", "code": "0x50000", "ses": "4294967295", "uid": "1000", "gid": "1000", "pid": "23975", "sig": "0", "syscall": "273", "syscall_name": "set_robust_list", "ip": "0x7f1da6d8b694", "auid": "4294967295"},
 		},
 	},
 	{`audit(1464163771.720:20): arch=c000003e syscall=1 success=yes exit=658651 a0=6 a1=7f26862ea010 a2=a0cdb a3=0 items=0 ppid=712 pid=716 auid=4294967295 uid=0 gid=0 euid=0 suid=0 fsuid=0 egid=0 sgid=0 fsgid=0 tty=(none) ses=4294967295 comm="apparmor_parser" exe="/sbin/apparmor_parser" key=(null)`, AUDIT_AVC, nil, true,
@@ -37,7 +37,7 @@ var auditTests = []struct {
 			Timestamp: "1464163771.720",
 			Type:      "AVC",
 			Data: map[string]string{
-				"success": "yes", "a2": "a0cdb", "uid": "0", "sgid": "0", "fsgid": "0", "ses": "4294967295", "exit": "658651", "a0": "6", "ppid": "712", "suid": "0", "key": "(null)", "tty": "(none)", "comm": `"apparmor_parser"`, "arch": "c000003e", "syscall": "1", "a1": "7f26862ea010", "items": "0", "pid": "716", "fsuid": "0", "exe": `"/sbin/apparmor_parser"`, "a3": "0", "auid": "4294967295", "gid": "0", "euid": "0", "egid": "0"},
+				"success": "yes", "a2": "a0cdb", "uid": "0", "sgid": "0", "fsgid": "0", "ses": "4294967295", "exit": "658651", "a0": "6", "ppid": "712", "suid": "0", "key": "(null)", "tty": "(none)", "comm": `"apparmor_parser"`, "arch": "c000003e", "syscall": "1", "syscall_name": "write", "a1": "7f26862ea010", "items": "0", "pid": "716", "fsuid": "0", "exe": `"/sbin/apparmor_parser"`, "a3": "0", "auid": "4294967295", "gid": "0", "euid": "0", "egid": "0"},
 		},
 	},
 	{`audit(1464093935.845:993): pid=4148 uid=0 auid=4294967295 ses=4294967295 msg='op=PAM:setcred acct="root" exe="/usr/bin/sudo" hostname=? addr=? terminal=/dev/pts/18 res=success'`, AUDIT_AVC, nil, true,
@@ -118,7 +118,7 @@ var auditTests = []struct {
 			Timestamp: "1464590772.564",
 			Type:      "AVC",
 			Data: map[string]string{
-				"pid": "5803", "comm": `"chrome"`, "syscall": "273", "ip": "0x7f3deee65694", "gid": "1000", "uid": "1000", "ses": "4294967295", "exe": `"/opt/google/chrome/chrome"`, "sig": "0", "arch": "c000003e", "compat": "0", "code": "0x50000", "auid": "4294967295"},
+				"pid": "5803", "comm": `"chrome"`, "syscall": "273", "syscall_name": "set_robust_list", "ip": "0x7f3deee65694", "gid": "1000", "uid": "1000", "ses": "4294967295", "exe": `"/opt/google/chrome/chrome"`, "sig": "0", "arch": "c000003e", "compat": "0", "code": "0x50000", "auid": "4294967295"},
 		},
 	},
 	{`audit(1464505771.166:388): pid=1 uid=0 auid=4294967295 ses=4294967295'unit=NetworkManager-dispatcher comm="systemd" exe="/lib/systemd/systemd" hostname=? addr=? terminal=? res=success'`, AUDIT_AVC, nil, true,
@@ -136,7 +136,7 @@ var auditTests = []struct {
 			Timestamp: "1464505794.710",
 			Type:      "AVC",
 			Data: map[string]string{
-				"auid": "4294967295", "comm": `"Chrome_libJingl"`, "sig": "0", "arch": "c000003e", "ip": "0x7fb359e4d694", "code": "0x50000", "uid": "1000", "gid": "1000", "ses": "4294967295", "pid": "4075", "exe": `"/opt/google/chrome/chrome"`, "syscall": "273", "compat": "0"},
+				"auid": "4294967295", "comm": `"Chrome_libJingl"`, "sig": "0", "arch": "c000003e", "ip": "0x7fb359e4d694", "code": "0x50000", "uid": "1000", "gid": "1000", "ses": "4294967295", "pid": "4075", "exe": `"/opt/google/chrome/chrome"`, "syscall": "273", "syscall_name": "set_robust_list", "compat": "0"},
 		},
 	},
 	{`audit(1464505808.342:401): auid=4294967295 uid=1000 gid=1000 ses=4294967295 pid=4076 comm="Chrome_libJingl" exe="/opt/google/chrome/chrome" sig=0 arch=c000003e syscall=273 compat=0 ip=0x7fb359e4d694 code=0x50000`, AUDIT_AVC, nil, true,
@@ -145,7 +145,7 @@ var auditTests = []struct {
 			Timestamp: "1464505808.342",
 			Type:      "AVC",
 			Data: map[string]string{
-				"pid": "4076", "comm": `"Chrome_libJingl"`, "exe": `"/opt/google/chrome/chrome"`, "sig": "0", "syscall": "273", "compat": "0", "code": "0x50000", "ses": "4294967295", "uid": "1000", "gid": "1000", "arch": "c000003e", "ip": "0x7fb359e4d694", "auid": "4294967295"},
+				"pid": "4076", "comm": `"Chrome_libJingl"`, "exe": `"/opt/google/chrome/chrome"`, "sig": "0", "syscall": "273", "syscall_name": "set_robust_list", "compat": "0", "code": "0x50000", "ses": "4294967295", "uid": "1000", "gid": "1000", "arch": "c000003e", "ip": "0x7fb359e4d694", "auid": "4294967295"},
 		},
 	},
 	{`audit(1464505810.566:403): auid=4294967295 uid=1000 gid=1000 ses=4294967295 pid=4078 comm="chrome" exe="/opt/google/chrome/chrome" sig=0 arch=c000003e syscall=273 compat=0 ip=0x7fb359e4d694 code=0x50000`, AUDIT_AVC, nil, true,
@@ -154,7 +154,7 @@ var auditTests = []struct {
 			Timestamp: "1464505810.566",
 			Type:      "AVC",
 			Data: map[string]string{
-				"auid": "4294967295", "exe": `"/opt/google/chrome/chrome"`, "sig": "0", "arch": "c000003e", "syscall": "273", "compat": "0", "code": "0x50000", "uid": "1000", "gid": "1000", "ses": "4294967295", "pid": "4078", "comm": `"chrome"`, "ip": "0x7fb359e4d694"},
+				"auid": "4294967295", "exe": `"/opt/google/chrome/chrome"`, "sig": "0", "arch": "c000003e", "syscall": "273", "syscall_name": "set_robust_list", "compat": "0", "code": "0x50000", "uid": "1000", "gid": "1000", "ses": "4294967295", "pid": "4078", "comm": `"chrome"`, "ip": "0x7fb359e4d694"},
 		},
 	},
 	{`audit(1464505927.046:474): pid=1 uid=0 auid=4294967295 ses=4294967295 unit=lm-sensors comm="systemd" exe="/lib/systemd/systemd" hostname=? addr=? terminal=? res=success'`, AUDIT_AVC, nil, true,
@@ -181,7 +181,7 @@ var auditTests = []struct {
 			Timestamp: "1464550921.784",
 			Type:      "AVC",
 			Data: map[string]string{
-				"syscall": "273", "compat": "0", "ip": "0x7f26b8828694", "code": "0x50000", "auid": "4294967295", "uid": "1000", "gid": "1000", "sig": "0", "arch": "c000003e", "ses": "4294967295", "pid": "14869", "comm": `"chrome"`, "exe": `"/opt/google/chrome/chrome"`},
+				"syscall": "273", "syscall_name": "set_robust_list", "compat": "0", "ip": "0x7f26b8828694", "code": "0x50000", "auid": "4294967295", "uid": "1000", "gid": "1000", "sig": "0", "arch": "c000003e", "ses": "4294967295", "pid": "14869", "comm": `"chrome"`, "exe": `"/opt/google/chrome/chrome"`},
 		},
 	},
 	{`audit(1170021493.977:293): avc:  denied  { read write } for  pid=13010 comm="pickup" name="maildrop" dev=hda7 ino=14911367 scontext=system_u:system_r:postfix_pickup_t:s0 tcontext=system_u:object_r:postfix_spool_maildrop_t:s0 tclass=dir`, AUDIT_AVC, nil, true,
@@ -265,3 +265,118 @@ func checkEvent(a *AuditEvent, b *AuditEvent, t *testing.T) bool {
 	}
 	return false
 }
+
+func TestDecodeCapabilities(t *testing.T) {
+	names, err := DecodeCapabilities("0000000000201000")
+	if err != nil {
+		t.Fatalf("DecodeCapabilities failed %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"CAP_NET_ADMIN", "CAP_SYS_ADMIN"}) {
+		t.Errorf("DecodeCapabilities: expected CAP_NET_ADMIN,CAP_SYS_ADMIN, found %v", names)
+	}
+	if _, err := DecodeCapabilities("xyz"); err == nil {
+		t.Errorf("DecodeCapabilities: expected error for invalid mask")
+	}
+
+	x, err := ParseAuditEvent(`audit(1464176620.068:1446): fver=2 fp=0000000000003000 fi=0000000000000000 fe=0 old_pp=0000000000000000 old_pi=0000000000000000 old_pe=0000000000000000 new_pp=0000000000003000 new_pi=0000000000000000 new_pe=0000000000003000`, AUDIT_BPRM_FCAPS, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if err := x.InterpretCapabilities(); err != nil {
+		t.Fatalf("InterpretCapabilities failed %v", err)
+	}
+	if x.Data["fp"] != "CAP_NET_ADMIN,CAP_NET_RAW" || x.Data["new_pi"] != "none" || x.Data["fe"] != "0" {
+		t.Errorf("InterpretCapabilities: unexpected capabilities %v", x.Data)
+	}
+
+	x, err = ParseAuditEvent(`audit(1464176620.068:1446): fver=2 fp=0000000000003000 fi=0000000000000000 fe=0`, AUDIT_BPRM_FCAPS, true)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if x.Data["fp"] != "net_admin,net_raw" || x.Data["fi"] != "none" {
+		t.Errorf("interpretation: unexpected capabilities %v", x.Data)
+	}
+}
+
+func TestParseAuditEventNoRaw(t *testing.T) {
+	msg := `audit(1464163771.720:20): arch=c000003e syscall=59 success=yes exit=0`
+	x, err := ParseAuditEventNoRaw(msg, AUDIT_SYSCALL, true)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if x.Raw != "" || x.Serial != "20" || x.Data["syscall"] != "execve" {
+		t.Errorf("ParseAuditEventNoRaw: unexpected event %+v", x)
+	}
+	x, err = ParseAuditEvent(msg, AUDIT_SYSCALL, true)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if x.Raw != msg {
+		t.Errorf("ParseAuditEvent: expected Raw %v, found %v", msg, x.Raw)
+	}
+
+	SetKeepRaw(false)
+	defer SetKeepRaw(true)
+	dec := NewDecoder(&testReplyNetlinkConn{pending: []NetlinkMessage{testEventMessage(AUDIT_SYSCALL, msg)}})
+	x, err = dec.Next()
+	if err != nil {
+		t.Fatalf("Next failed %v", err)
+	}
+	if x.Raw != "" {
+		t.Errorf("SetKeepRaw: expected empty Raw, found %v", x.Raw)
+	}
+}
+
+func TestParseAuditEventLenient(t *testing.T) {
+	msg := `audit(1464163771.720:20): arch=c000003e syscall=99999 success=yes exit=abc uid=0`
+	if _, err := ParseAuditEvent(msg, AUDIT_SYSCALL, true); err == nil {
+		t.Fatalf("ParseAuditEvent: expected error")
+	}
+	x, err := ParseAuditEventLenient(msg, AUDIT_SYSCALL, true)
+	if x == nil {
+		t.Fatalf("ParseAuditEventLenient: expected event, found error %v", err)
+	}
+	warnings, ok := err.(ParseWarnings)
+	if !ok || len(warnings) != 2 || warnings[0].Field != "exit" || warnings[1].Field != "syscall" {
+		t.Fatalf("ParseAuditEventLenient: unexpected warnings %v", err)
+	}
+	if x.Data["syscall"] != "99999" || x.Data["exit"] != "abc" || x.Data["uid"] != "root" || x.Data["success"] != "yes" {
+		t.Errorf("ParseAuditEventLenient: unexpected event %+v", x)
+	}
+	if _, err := ParseAuditEventLenient("malformed", AUDIT_SYSCALL, true); err == nil {
+		t.Errorf("ParseAuditEventLenient: expected error for malformed message")
+	}
+
+	SetLenientParsing(true)
+	defer SetLenientParsing(false)
+	x, err = NewAuditEvent(testEventMessage(AUDIT_SYSCALL, msg))
+	if x == nil || err == nil || x.Data["uid"] != "root" {
+		t.Errorf("SetLenientParsing: unexpected result %+v %v", x, err)
+	}
+}
+
+func TestSyscallName(t *testing.T) {
+	for _, tt := range []struct {
+		msg       string
+		interpret bool
+		syscall   string
+		name      string
+	}{
+		{`audit(1464163771.720:020): arch=c000003e syscall=59 success=yes exit=0`, false, "59", "execve"},
+		{`audit(1464163771.720:021): arch=40000003 syscall=11 success=yes exit=0`, false, "11", "execve"},
+		{`audit(1464163771.720:022): arch=40000003 syscall=11 success=yes exit=0`, true, "execve", "execve"},
+		{`audit(1464163771.720:023): arch=c000003e syscall=99999 success=yes exit=0`, false, "99999", ""},
+		{`audit(1464163771.720:024): arch=12345678 syscall=11 success=yes exit=0`, false, "11", ""},
+	} {
+		x, err := ParseAuditEvent(tt.msg, AUDIT_SYSCALL, tt.interpret)
+		if err != nil {
+			t.Fatalf("ParseAuditEvent failed %v", err)
+		}
+		if x.Data["syscall"] != tt.syscall || x.Data["syscall_name"] != tt.name {
+			t.Errorf("ParseAuditEvent %v: expected syscall %v name %q, found %v %q", tt.msg, tt.syscall, tt.name, x.Data["syscall"], x.Data["syscall_name"])
+		}
+		if _, ok := x.Data["syscall_name"]; !ok && tt.name != "" {
+			t.Errorf("ParseAuditEvent %v: no syscall_name", tt.msg)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package libaudit
+
+import (
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// PoolOptions configures the workers of GetAuditEventsPooled.
+type PoolOptions struct {
+	// Workers is the number of go-routines running the callback, 4 if not set
+	Workers int
+	// QueueSize is the number of events queued for each worker, 64 if not set
+	QueueSize int
+	// Shard selects the worker of an event, events with the same shard are passed to the callback
+	// in the order they were received. By default events are sharded by serial so that the records
+	// of one event stay in order, shard by SessionKey to keep the order of login sessions.
+	// Errors without event always go to the first worker.
+	Shard func(*AuditEvent) uint32
+	// OnQueueFull is called from the receive loop with the index of the worker whenever an event finds
+	// the queue of its worker full, it lets callers observe the pressure. The receive loop then waits for
+	// the worker unless DropWhenFull is set.
+	OnQueueFull func(worker int)
+	// DropWhenFull drops the events finding the queue of their worker full instead of waiting,
+	// the socket is then drained regardless of the speed of the callback.
+	DropWhenFull bool
+}
+
+// ShardBySerial is the default Shard of PoolOptions
+func ShardBySerial(e *AuditEvent) uint32 {
+	serial, _ := strconv.ParseUint(e.Serial, 10, 32)
+	return uint32(serial)
+}
+
+// pooledEvent is the callback invocation queued for a worker
+type pooledEvent struct {
+	event *AuditEvent
+	err   error
+}
+
+// GetAuditEventsPooled is similar to GetAuditEvents but passes the events to a pool of workers running the
+// callback, so that a slow callback doesn't hold up receiving from the socket (and the kernel backlog fills).
+// The callback is called concurrently from the workers, see PoolOptions for the ordering guarantees.
+// Stopped is closed once the loop has returned and the workers are done with the queued events.
+func GetAuditEventsPooled(s Netlink, cb EventCallback, opts PoolOptions, args ...interface{}) *Receiver {
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 64
+	}
+	if opts.Shard == nil {
+		opts.Shard = ShardBySerial
+	}
+
+	var wg sync.WaitGroup
+	queues := make([]chan pooledEvent, opts.Workers)
+	for i := range queues {
+		queues[i] = make(chan pooledEvent, opts.QueueSize)
+		wg.Add(1)
+		go func(queue chan pooledEvent) {
+			defer wg.Done()
+			for pe := range queue {
+				cb(pe.event, pe.err, args...)
+			}
+		}(queues[i])
+	}
+	dispatch := func(e *AuditEvent, err error, _ ...interface{}) {
+		var worker int
+		if e != nil {
+			worker = int(opts.Shard(e) % uint32(len(queues)))
+		}
+		pe := pooledEvent{event: e, err: err}
+		select {
+		case queues[worker] <- pe:
+			return
+		default:
+		}
+		if opts.OnQueueFull != nil {
+			opts.OnQueueFull(worker)
+		}
+		if !opts.DropWhenFull {
+			queues[worker] <- pe
+		}
+	}
+
+	r := newReceiver()
+	go func() {
+		defer close(r.stopped)
+		rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+		idle := newIdleTimer(s)
+		dispatch := coalesceEventErrors(dispatch)
+
+	loop:
+		for {
+			select {
+			case <-r.done:
+				break loop
+			default:
+				if err := processOnce(s, rb, idle, dispatch); errors.Cause(err) == ErrClosed {
+					break loop
+				}
+			}
+		}
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}()
+	return r
+}
@@ -0,0 +1,106 @@
+package libaudit
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetAuditEventsPooled(t *testing.T) {
+	s := &testReplyNetlinkConn{}
+	for serial := 1; serial <= 3; serial++ {
+		s.pending = append(s.pending,
+			testEventMessage(AUDIT_SYSCALL, fmt.Sprintf(`audit(1464163771.720:%d): arch=c000003e syscall=2 success=yes exit=3`, serial)),
+			testEventMessage(AUDIT_PATH, fmt.Sprintf(`audit(1464163771.720:%d): item=0 name="/etc/passwd"`, serial)))
+	}
+	var mu sync.Mutex
+	received := make(map[string][]string)
+	var count int
+	r := GetAuditEventsPooled(s, func(e *AuditEvent, err error, args ...interface{}) {
+		if err != nil {
+			t.Errorf("GetAuditEventsPooled: unexpected error %v", err)
+			return
+		}
+		if len(args) != 1 || args[0] != "arg" {
+			t.Errorf("GetAuditEventsPooled: unexpected args %v", args)
+		}
+		// slow callback, the records of an event must stay in order nevertheless
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		received[e.Serial] = append(received[e.Serial], e.Type)
+		count++
+		mu.Unlock()
+	}, PoolOptions{Workers: 2}, "arg")
+	defer r.Wait()
+	defer r.Stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := count
+		mu.Unlock()
+		if n == 6 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GetAuditEventsPooled: received %d events, expected 6", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for serial, types := range received {
+		if len(types) != 2 || types[0] != "SYSCALL" || types[1] != "PATH" {
+			t.Errorf("GetAuditEventsPooled: serial %v: unexpected order %v", serial, types)
+		}
+	}
+}
+
+func TestGetAuditEventsPooledQueueFull(t *testing.T) {
+	s := &testReplyNetlinkConn{}
+	for serial := 1; serial <= 4; serial++ {
+		s.pending = append(s.pending, testEventMessage(AUDIT_SYSCALL, fmt.Sprintf(`audit(1464163771.720:%d): arch=c000003e syscall=2 success=yes exit=3`, serial)))
+	}
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var delivered, full int
+	r := GetAuditEventsPooled(s, func(e *AuditEvent, err error, args ...interface{}) {
+		<-release
+		mu.Lock()
+		delivered++
+		mu.Unlock()
+	}, PoolOptions{
+		Workers:      1,
+		QueueSize:    1,
+		DropWhenFull: true,
+		OnQueueFull: func(worker int) {
+			if worker != 0 {
+				t.Errorf("OnQueueFull: unexpected worker %d", worker)
+			}
+			mu.Lock()
+			full++
+			mu.Unlock()
+		},
+	})
+	// the worker and its queue hold 2 events, the others are dropped
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := full
+		mu.Unlock()
+		if n >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("OnQueueFull: called %d times, expected at least 2", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	r.Stop()
+	close(release)
+	r.Wait()
+	if delivered+full != 4 {
+		t.Errorf("GetAuditEventsPooled: %d events delivered and %d dropped, expected 4 in total", delivered, full)
+	}
+}
@@ -0,0 +1,124 @@
+package libaudit
+
+// GroupPredicate selects event groups for Filter. The predicates of this file read the records with the
+// accessors of AuditEvent, so they work on interpreted and on raw records alike.
+type GroupPredicate func(EventGroup) bool
+
+// Filter passes the groups of the channel groups matching pred to the returned channel, which is closed
+// once groups is closed. Like ausearch it lets consumers narrow a stream of events, e.g.
+//
+//	for g := range Filter(groups, And(ByKey("exec"), SuccessOnly())) {
+//		...
+//	}
+func Filter(groups <-chan EventGroup, pred func(EventGroup) bool) <-chan EventGroup {
+	out := make(chan EventGroup)
+	go func() {
+		defer close(out)
+		for g := range groups {
+			if pred(g) {
+				out <- g
+			}
+		}
+	}()
+	return out
+}
+
+// And matches the groups matching all of preds.
+func And(preds ...GroupPredicate) GroupPredicate {
+	return func(g EventGroup) bool {
+		for _, pred := range preds {
+			if !pred(g) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches the groups matching any of preds.
+func Or(preds ...GroupPredicate) GroupPredicate {
+	return func(g EventGroup) bool {
+		for _, pred := range preds {
+			if pred(g) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches the groups not matching pred.
+func Not(pred GroupPredicate) GroupPredicate {
+	return func(g EventGroup) bool {
+		return !pred(g)
+	}
+}
+
+// groupRecord returns the SYSCALL record of the group, the first record with field otherwise
+// (user space records carry their fields themselves)
+func groupRecord(g EventGroup, field string) *AuditEvent {
+	if e := g.Record("SYSCALL"); e != nil {
+		return e
+	}
+	for _, e := range g.Records {
+		if _, ok := e.Data[field]; ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// ByKey matches the groups of events recorded by a rule with the key (-k), rules can have several keys.
+func ByKey(key string) GroupPredicate {
+	return func(g EventGroup) bool {
+		e := groupRecord(g, "key")
+		if e == nil {
+			return false
+		}
+		for _, k := range e.Keys() {
+			if k == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByUID matches the groups of events of processes running as uid (the uid field, not the login uid).
+func ByUID(uid int) GroupPredicate {
+	return func(g EventGroup) bool {
+		e := groupRecord(g, "uid")
+		if e == nil {
+			return false
+		}
+		id, ok := idValue(e.Data["uid"], true)
+		return ok && int(id) == uid
+	}
+}
+
+// BySyscall matches the groups of events of the syscall name (e.g. execve), syscall numbers are
+// converted with the syscall table of the arch of the event.
+func BySyscall(name string) GroupPredicate {
+	return func(g EventGroup) bool {
+		e := g.Record("SYSCALL")
+		if e == nil {
+			return false
+		}
+		syscallName, err := e.syscallName()
+		return err == nil && syscallName == name
+	}
+}
+
+// SuccessOnly matches the groups of events which succeeded, see (*AuditEvent).Success. Groups
+// without outcome don't match.
+func SuccessOnly() GroupPredicate {
+	return func(g EventGroup) bool {
+		for _, field := range successFields {
+			if e := groupRecord(g, field); e != nil {
+				success, ok := e.Success()
+				return ok && success
+			}
+		}
+		return false
+	}
+}
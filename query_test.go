@@ -0,0 +1,73 @@
+package libaudit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	events := [][]struct {
+		msgType auditConstant
+		msg     string
+	}{
+		{
+			{AUDIT_SYSCALL, `audit(1464163771.720:20): arch=c000003e syscall=59 success=yes exit=0 items=1 ppid=1 pid=2 auid=1000 uid=0 ses=1 comm="ls" exe="/bin/ls" key="exec"`},
+			{AUDIT_EXECVE, `audit(1464163771.720:20): argc=1 a0="ls"`},
+		},
+		{
+			{AUDIT_SYSCALL, `audit(1464163771.720:21): arch=c000003e syscall=59 success=no exit=-13 items=1 ppid=1 pid=3 auid=1000 uid=1000 ses=1 comm="ls" exe="/bin/ls" key=6578656301726F6F74`},
+		},
+		{
+			{AUDIT_SYSCALL, `audit(1464163771.720:22): arch=c000003e syscall=2 success=yes exit=3 items=1 ppid=1 pid=4 auid=1000 uid=0 ses=1 comm="cat" exe="/bin/cat" key=(null)`},
+		},
+		{
+			{AUDIT_USER_LOGIN, `audit(1464163771.720:23): pid=5 uid=0 auid=1000 ses=2 msg='op=login id=1000 exe="/usr/sbin/sshd" hostname=? addr=10.0.0.1 terminal=ssh res=success'`},
+		},
+	}
+	tests := []struct {
+		name     string
+		pred     GroupPredicate
+		expected []string
+	}{
+		{"ByKey", ByKey("exec"), []string{"20", "21"}},
+		{"ByKey root", ByKey("root"), []string{"21"}},
+		{"ByUID", ByUID(0), []string{"20", "22", "23"}},
+		{"BySyscall", BySyscall("execve"), []string{"20", "21"}},
+		{"SuccessOnly", SuccessOnly(), []string{"20", "22", "23"}},
+		{"And", And(ByKey("exec"), SuccessOnly()), []string{"20"}},
+		{"Or", Or(BySyscall("open"), ByKey("root")), []string{"21", "22"}},
+		{"Not", Not(BySyscall("execve")), []string{"22", "23"}},
+	}
+	for _, interpret := range []bool{false, true} {
+		var groups []EventGroup
+		for _, records := range events {
+			var g EventGroup
+			for _, r := range records {
+				x, err := ParseAuditEvent(r.msg, r.msgType, interpret)
+				if err != nil {
+					t.Fatalf("parse failed %v", err)
+				}
+				if err := g.Add(x); err != nil {
+					t.Fatalf("Add failed %v", err)
+				}
+			}
+			groups = append(groups, g)
+		}
+		for _, tt := range tests {
+			in := make(chan EventGroup)
+			go func() {
+				for _, g := range groups {
+					in <- g
+				}
+				close(in)
+			}()
+			var serials []string
+			for g := range Filter(in, tt.pred) {
+				serials = append(serials, g.Serial)
+			}
+			if !reflect.DeepEqual(serials, tt.expected) {
+				t.Errorf("Filter %v (interpret %v): expected %v, found %v", tt.name, interpret, tt.expected, serials)
+			}
+		}
+	}
+}
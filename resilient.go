@@ -0,0 +1,126 @@
+package libaudit
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ResilientReceiver runs a receive loop that survives netlink errors: the connection is closed and a new one
+// is obtained from Connect (with backoff) when receiving fails with a fatal error. Transient errors
+// (interrupted calls, ENOBUFS when the socket buffer overflowed) are passed to the callback and receiving
+// continues on the same connection, receive timeouts are ignored.
+type ResilientReceiver struct {
+	// Connect returns a new netlink connection, i.e. a wrapper of NewNetlinkConnection
+	Connect func() (Netlink, error)
+	// Callback receives the events and the errors, events are delivered like with GetAuditMessages
+	Callback EventCallback
+	// RegisterPID registers the process as the audit daemon (AuditSetPID) on every new connection
+	RegisterPID bool
+	// Backoff returns the delay before the given reconnect attempt (starting at 1), nil uses DefaultBackoff
+	Backoff func(attempt int) time.Duration
+	// MaxRetries is the number of consecutive failed reconnect attempts after which Run gives up, 0 is unlimited
+	MaxRetries int
+}
+
+// DefaultBackoff doubles the delay with every attempt, starting at 100ms and capped at 30s.
+func DefaultBackoff(attempt int) time.Duration {
+	d := 100 * time.Millisecond
+	for i := 1; i < attempt && d < 30*time.Second; i++ {
+		d *= 2
+	}
+	if d > 30*time.Second {
+		d = 30 * time.Second
+	}
+	return d
+}
+
+// isTransientReceiveError reports whether receiving can continue on the connection after err
+func isTransientReceiveError(err error) bool {
+	switch errors.Cause(err) {
+	case syscall.EAGAIN, syscall.EINTR, syscall.ENOBUFS, ErrEventsLost:
+		return true
+	}
+	return false
+}
+
+// closeNetlink closes the connection if it can be closed
+func closeNetlink(s Netlink) {
+	if c, ok := s.(interface {
+		Close() error
+	}); ok {
+		c.Close()
+	}
+}
+
+// Run receives events until a signal is received on the done channel (nil on return) or MaxRetries
+// consecutive reconnect attempts failed (the last error is returned).
+func (r *ResilientReceiver) Run(done *chan bool, args ...interface{}) error {
+	var (
+		doneChan chan bool
+		attempt  int
+	)
+	if done != nil {
+		doneChan = *done
+	}
+	backoff := r.Backoff
+	if backoff == nil {
+		backoff = DefaultBackoff
+	}
+	rb := make([]byte, syscall.NLMSG_HDRLEN+MAX_AUDIT_MESSAGE_LENGTH)
+	cb := coalesceEventErrors(r.Callback)
+
+	for {
+		if attempt > 0 {
+			if r.MaxRetries > 0 && attempt > r.MaxRetries {
+				return errors.New("ResilientReceiver: maximum number of reconnect attempts reached")
+			}
+			select {
+			case <-doneChan:
+				return nil
+			case <-time.After(backoff(attempt)):
+			}
+		}
+		s, err := r.Connect()
+		if err != nil {
+			attempt++
+			cb(nil, errors.Wrap(err, "ResilientReceiver: connect failed"), args...)
+			continue
+		}
+		if r.RegisterPID {
+			if err := AuditSetPID(s, os.Getpid()); err != nil {
+				closeNetlink(s)
+				attempt++
+				cb(nil, errors.Wrap(err, "ResilientReceiver: registering the pid failed"), args...)
+				continue
+			}
+		}
+		attempt = 0
+		idle := newIdleTimer(s)
+
+	receive:
+		for {
+			select {
+			case <-doneChan:
+				closeNetlink(s)
+				return nil
+			default:
+			}
+			err := processOnce(s, rb, idle, cb, args...)
+			switch {
+			case err == nil, errors.Cause(err) == syscall.EAGAIN:
+			case errors.Cause(err) == ErrEventsLost:
+				// processOnce passed it to the callback already
+			case isTransientReceiveError(err):
+				cb(nil, err, args...)
+			default:
+				cb(nil, errors.Wrap(err, "ResilientReceiver: reconnecting"), args...)
+				closeNetlink(s)
+				attempt++
+				break receive
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package libaudit
+
+import (
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestResilientReceiver(t *testing.T) {
+	var (
+		conns  []*testClosedNetlinkConn
+		errs   []error
+		events []string
+	)
+	done := make(chan bool)
+	r := &ResilientReceiver{
+		Connect: func() (Netlink, error) {
+			if len(conns) == 1 {
+				// the first reconnect attempt fails
+				conns = append(conns, nil)
+				return nil, errors.New("connection refused")
+			}
+			s := &testClosedNetlinkConn{}
+			event := testEventMessage(AUDIT_CONFIG_CHANGE, fmt.Sprintf("audit(1464163771.720:%d): op=add_rule res=1", len(conns)))
+			// the pid registration is acked, the connection fails after delivering an event
+			s.reply = func(request NetlinkMessage) []NetlinkMessage {
+				return []NetlinkMessage{testAckMessage(request, 0), event}
+			}
+			conns = append(conns, s)
+			return s, nil
+		},
+		Callback: func(e *AuditEvent, err error, args ...interface{}) {
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			events = append(events, e.Serial)
+			if len(events) == 2 {
+				close(done)
+			}
+		},
+		RegisterPID: true,
+		Backoff:     func(int) time.Duration { return time.Millisecond },
+	}
+	if err := r.Run(&done); err != nil {
+		t.Fatalf("Run failed %v", err)
+	}
+	if len(events) != 2 || events[0] != "0" || events[1] != "2" {
+		t.Errorf("ResilientReceiver: expected events from both connections, found %v", events)
+	}
+	// the receive error of the first connection and the failed connect are reported
+	if len(errs) != 2 {
+		t.Errorf("ResilientReceiver: expected 2 errors, found %v", errs)
+	}
+	for _, s := range []*testClosedNetlinkConn{conns[0], conns[2]} {
+		if len(s.sent) == 0 || s.sent[0].Header.Type != uint16(AUDIT_SET) {
+			t.Errorf("ResilientReceiver: expected pid registration, found %v", s.sent)
+		}
+	}
+
+	attempts := 0
+	r = &ResilientReceiver{
+		Connect: func() (Netlink, error) {
+			attempts++
+			return nil, syscall.ECONNREFUSED
+		},
+		Callback:   func(e *AuditEvent, err error, args ...interface{}) {},
+		Backoff:    func(int) time.Duration { return time.Millisecond },
+		MaxRetries: 3,
+	}
+	if err := r.Run(nil); err == nil {
+		t.Errorf("Run: expected error after %d retries", r.MaxRetries)
+	}
+	if attempts != 4 {
+		t.Errorf("Run: expected 4 connect attempts, found %d", attempts)
+	}
+	if DefaultBackoff(1) != 100*time.Millisecond || DefaultBackoff(3) != 400*time.Millisecond || DefaultBackoff(100) != 30*time.Second {
+		t.Errorf("DefaultBackoff: unexpected delays")
+	}
+}
+
+func TestResilientReceiverEventsLost(t *testing.T) {
+	var lost, events int
+	done := make(chan bool)
+	r := &ResilientReceiver{
+		Connect: func() (Netlink, error) {
+			s := &testOverflowNetlinkConn{}
+			s.pending = []NetlinkMessage{testEventMessage(AUDIT_CONFIG_CHANGE, `audit(1464163771.720:20): op=add_rule res=1`)}
+			return s, nil
+		},
+		Callback: func(e *AuditEvent, err error, args ...interface{}) {
+			if errors.Cause(err) == ErrEventsLost {
+				lost++
+			} else if err != nil {
+				t.Errorf("ResilientReceiver: unexpected error %v", err)
+			}
+			if e != nil {
+				events++
+				close(done)
+			}
+		},
+		Backoff: func(int) time.Duration { return time.Millisecond },
+	}
+	if err := r.Run(&done); err != nil {
+		t.Fatalf("Run failed %v", err)
+	}
+	// the overflow before the event is reported once
+	if lost != 1 || events != 1 {
+		t.Errorf("ResilientReceiver: expected 1 overflow and 1 event, found %d %d", lost, events)
+	}
+}
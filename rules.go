@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/user"
 	"path"
-	"runtime"
 	"strconv"
 	"strings"
 	"syscall"
@@ -91,7 +90,7 @@ func isAuditRulePresent(a *AuditRuleData, b []*AuditRuleData) bool {
 
 func RemoveStaleLWAuditRules(s Netlink, ruleArray []*AuditRuleData) (string, error) {
 	var toString string
-	if len(ruleArray) != 0 && len(ruleArray) != 0 {
+	if len(ruleArray) != 0 {
 		for _, r := range ruleArray {
 			printed := printRule(r)
 			str := strings.ToLower(printed)
@@ -161,7 +160,7 @@ done:
 			if m.Header.Type == syscall.NLMSG_ERROR {
 				e := int32(nativeEndian().Uint32(m.Data[0:4]))
 				if e != 0 {
-					return fmt.Errorf("DeleteAllRules: error receiving rules -%d", e)
+					return replyError(e, fmt.Errorf("DeleteAllRules: error receiving rules -%d", e))
 				}
 			}
 			if m.Header.Type == uint16(AUDIT_LIST_RULES) {
@@ -181,8 +180,11 @@ done:
 	return nil
 }
 
-var auditPermAdded bool
-var auditSyscallAdded bool
+// ruleFieldState tracks what was added to a rule being built, the key and arch fields depend on it
+type ruleFieldState struct {
+	syscallAdded bool // the syscalls were added, or the rule doesn't take any
+	permAdded    bool // a watch, dir or perm field was added
+}
 
 func auditWord(nr int) uint32 {
 	word := (uint32)((nr) / 32)
@@ -219,6 +221,23 @@ func auditNameToFtype(name string, value *int) error {
 	return fmt.Errorf("auditNameToFtype failed: filetype %v not found", name)
 }
 
+// auditNameToErrno converts an errno name like EACCES to its number based on the lookup table ErrnoLookup,
+// names with a leading - (-EACCES) are negated like the exit codes of failed syscalls
+func auditNameToErrno(name string, value *int) error {
+	sign := 1
+	if strings.HasPrefix(name, "-") {
+		name, sign = name[1:], -1
+	}
+	for k, v := range headers.ErrnoLookup {
+		if v == name {
+			*value = sign * k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("auditNameToErrno failed: errno %v not found", name)
+}
+
 var (
 	errMaxField = errors.New("max fields for rule exceeded")
 	errNoStr    = errors.New("no support for string values")
@@ -227,27 +246,34 @@ var (
 	errMaxLen   = errors.New("max Rule length exceeded")
 )
 
+var (
+	// ErrKeyTooLong is returned for rule keys longer than AUDIT_MAX_KEY_LEN bytes
+	ErrKeyTooLong = errors.New("key too long")
+	// ErrPathTooLong is returned for watched paths (and other string fields) longer than PATH_MAX bytes
+	ErrPathTooLong = errors.New("path too long")
+)
+
 // auditRuleFieldPairData process the passed auditRuleData struct for passing to kernel
 // according to passed fieldnames and flags
-func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uint32, fieldname string, flags int) error {
+func auditRuleFieldPairData(rule *AuditRuleData, st *ruleFieldState, fieldval interface{}, opval uint32, fieldname string, flags int) error {
 
 	if rule.FieldCount >= (AUDIT_MAX_FIELDS - 1) {
 		return errors.Wrap(errMaxField, "auditRuleFieldPairData failed")
 	}
 
-	var fieldid uint32
-	for k, v := range headers.FieldMap {
-		if k == fieldname {
-			fieldid = uint32(v)
-			break
-		}
-	}
-	if fieldid == 0 {
+	// pid is field 0
+	id, ok := headers.FieldMap[fieldname]
+	if !ok {
 		return fmt.Errorf("auditRuleFieldPairData failed: unknown field %v", fieldname)
 	}
+	fieldid := uint32(id)
+
+	if err := auditFieldOperatorValid(fieldid, opval); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("auditRuleFieldPairData failed for %v", fieldname))
+	}
 
-	if flags == AUDIT_FILTER_EXCLUDE && fieldid != AUDIT_MSGTYPE {
-		return fmt.Errorf("auditRuleFieldPairData failed: only msgtype field can be used with exclude filter")
+	if flags == AUDIT_FILTER_EXCLUDE && !excludeFields[fieldid] {
+		return fmt.Errorf("auditRuleFieldPairData failed: %v field can't be used with exclude filter", fieldname)
 	}
 	rule.Fields[rule.FieldCount] = fieldid
 	rule.Fieldflags[rule.FieldCount] = opval
@@ -285,6 +311,16 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 			return errors.Wrap(errUnset, fmt.Sprintf("auditRuleFieldPairData failed to set: %v", fieldval))
 		}
 
+	case AUDIT_SESSIONID:
+		// the session of processes not part of a login session is unset like their loginuid
+		if val, isInt := fieldval.(float64); isInt {
+			rule.Values[rule.FieldCount] = (uint32)(val)
+		} else if val, isString := fieldval.(string); isString && val == "unset" {
+			rule.Values[rule.FieldCount] = 4294967295
+		} else {
+			return errors.Wrap(errUnset, fmt.Sprintf("auditRuleFieldPairData failed to set: %v should be a number", fieldval))
+		}
+
 	case AUDIT_EXIT:
 
 		if flags != AUDIT_FILTER_EXIT {
@@ -292,9 +328,12 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 		}
 		if val, isInt := fieldval.(float64); isInt {
 			rule.Values[rule.FieldCount] = (uint32)(val)
-		} else if _, isString := fieldval.(string); isString {
-			// TODO: audit_name_to_errno
-			return errors.Wrap(errNoStr, "auditRuleFieldPairData failed")
+		} else if val, isString := fieldval.(string); isString {
+			var errno int
+			if err := auditNameToErrno(val, &errno); err != nil {
+				return errors.Wrap(err, "auditRuleFieldPairData failed")
+			}
+			rule.Values[rule.FieldCount] = (uint32)(errno)
 		} else {
 			return errors.Wrap(errUnset, fmt.Sprintf("auditRuleFieldPairData failed to set: %v", fieldval))
 		}
@@ -307,9 +346,11 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 		if val, isInt := fieldval.(float64); isInt {
 			rule.Values[rule.FieldCount] = (uint32)(val)
 		} else if val, isString := fieldval.(string); isString {
-			// TODO: Add reverse mappings from msgType to audit constants (msg_typetab.h)
-			if _, ok := MsgTypeTab[val]; ok {
-				rule.Values[rule.FieldCount] = (uint32)(MsgTypeTab[val])
+			// record types by name (CWD, cwd) or number
+			if msgType, ok := MsgTypeTab[strings.ToUpper(val)]; ok {
+				rule.Values[rule.FieldCount] = (uint32)(msgType)
+			} else if n, err := strconv.ParseUint(val, 10, 32); err == nil {
+				rule.Values[rule.FieldCount] = (uint32)(n)
 			} else {
 				return errors.Wrap(errNoStr, fmt.Sprintf("auditRuleFieldPairData failed %v", val))
 			}
@@ -318,7 +359,7 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 		}
 
 	//Strings
-	case AUDIT_OBJ_USER, AUDIT_OBJ_ROLE, AUDIT_OBJ_TYPE, AUDIT_OBJ_LEV_LOW, AUDIT_OBJ_LEV_HIGH, AUDIT_WATCH, AUDIT_DIR:
+	case AUDIT_OBJ_USER, AUDIT_OBJ_ROLE, AUDIT_OBJ_TYPE, AUDIT_OBJ_LEV_LOW, AUDIT_OBJ_LEV_HIGH, AUDIT_WATCH, AUDIT_DIR, AUDIT_EXE:
 		/* Watch & object filtering is invalid on anything
 		 * but exit */
 
@@ -326,22 +367,34 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 			return fmt.Errorf("auditRuleFieldPairData failed: %v can only be used with exit filter list", fieldname)
 		}
 		if fieldid == AUDIT_WATCH || fieldid == AUDIT_DIR {
-			auditPermAdded = true
+			st.permAdded = true
+		}
+		if val, isString := fieldval.(string); isString && fieldid == AUDIT_EXE {
+			// the kernel watches the executable like a file, it needs an absolute path of a file
+			if len(val) == 0 {
+				return errors.Wrap(errPathStart, "auditRuleFieldPairData failed: exe")
+			}
+			if err := checkPath(val); err != nil {
+				return errors.Wrap(err, "auditRuleFieldPairData failed: exe")
+			}
+			if strings.HasSuffix(val, "/") {
+				return fmt.Errorf("auditRuleFieldPairData failed: exe %v is a directory", val)
+			}
 		}
 
 		fallthrough //IMP
 	case AUDIT_SUBJ_USER, AUDIT_SUBJ_ROLE, AUDIT_SUBJ_TYPE, AUDIT_SUBJ_SEN, AUDIT_SUBJ_CLR, AUDIT_FILTERKEY:
 		//If And only if a syscall is added or a permisission is added then this field should be set
-		if fieldid == AUDIT_FILTERKEY && !(auditSyscallAdded || auditPermAdded) {
+		if fieldid == AUDIT_FILTERKEY && !(st.syscallAdded || st.permAdded) {
 			return errors.Wrap(errNoSys, "auditRuleFieldPairData failed: Key field needs a watch or syscall given prior to it")
 		}
 		if val, isString := fieldval.(string); isString {
 			valbyte := []byte(val)
 			vlen := len(valbyte)
 			if fieldid == AUDIT_FILTERKEY && vlen > AUDIT_MAX_KEY_LEN {
-				return errors.Wrap(errMaxLen, "auditRuleFieldPairData failed")
+				return errors.Wrap(ErrKeyTooLong, fmt.Sprintf("auditRuleFieldPairData failed: key of %d bytes, the limit is %d", vlen, AUDIT_MAX_KEY_LEN))
 			} else if vlen > PATH_MAX {
-				return errors.Wrap(errMaxLen, "auditRuleFieldPairData failed")
+				return errors.Wrap(ErrPathTooLong, fmt.Sprintf("auditRuleFieldPairData failed: %v of %d bytes, the limit is %d", fieldname, vlen, PATH_MAX))
 			}
 			rule.Values[rule.FieldCount] = (uint32)(vlen)
 			rule.Buflen = rule.Buflen + (uint32)(vlen)
@@ -355,59 +408,38 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 		}
 
 	case AUDIT_ARCH:
-		if auditSyscallAdded == false {
+		if !st.syscallAdded {
 			return errors.Wrap(errNoSys, "auditRuleFieldPairData failed: arch should be mention before syscalls")
 		}
 		if !(opval == AUDIT_NOT_EQUAL || opval == AUDIT_EQUAL) {
 			return fmt.Errorf("auditRuleFieldPairData failed: arch only takes = or != operators")
 		}
-		// IMP NOTE: Considering X64 machines only
-		if _, isInt := fieldval.(float64); isInt {
-			rule.Values[rule.FieldCount] = AUDIT_ARCH_X86_64
-		} else if _, isString := fieldval.(string); isString {
-			return errors.Wrap(errNoStr, "auditRuleFieldPairData failed")
-		} else {
-			return errors.Wrap(errUnset, fmt.Sprintf("auditRuleFieldPairData failed to set: %v", fieldval))
+		arch, err := auditNameToArch(fieldval)
+		if err != nil {
+			return errors.Wrap(err, "auditRuleFieldPairData failed")
 		}
+		rule.Values[rule.FieldCount] = arch
 
 	case AUDIT_PERM:
 		//Decide on various error types
 		if flags != AUDIT_FILTER_EXIT {
 			return fmt.Errorf("auditRuleFieldPairData failed: %v can only be used with exit filter list", fieldname)
-		} else if opval != AUDIT_EQUAL {
-			return fmt.Errorf("auditRuleFieldPairData failed: %v only takes = or != operators", fieldname)
 		} else {
 			if val, isString := fieldval.(string); isString {
-
-				var i, vallen int
-				vallen = len(val)
-				var permval uint32
-				if vallen > 4 {
-					return errors.Wrap(errMaxLen, "auditRuleFieldPairData failed")
-				}
-				lowerval := strings.ToLower(val)
-				for i = 0; i < vallen; i++ {
-					switch lowerval[i] {
-					case 'r':
-						permval |= AUDIT_PERM_READ
-					case 'w':
-						permval |= AUDIT_PERM_WRITE
-					case 'x':
-						permval |= AUDIT_PERM_EXEC
-					case 'a':
-						permval |= AUDIT_PERM_ATTR
-					default:
-						return fmt.Errorf("auditRuleFieldPairData failed: permission can only contain  'rwxa'")
-					}
+				permval, err := EncodePerm(val)
+				if err != nil {
+					return errors.Wrap(err, "auditRuleFieldPairData failed")
 				}
-				rule.Values[rule.FieldCount] = permval
-				auditPermAdded = true
+				rule.Values[rule.FieldCount] = uint32(permval)
+				st.permAdded = true
+			} else {
+				return fmt.Errorf("auditRuleFieldPairData failed: perm expects a string like wa, found %v", fieldval)
 			}
 		}
 	case AUDIT_FILETYPE:
 		if val, isString := fieldval.(string); isString {
-			if !(flags == AUDIT_FILTER_EXIT) && flags == AUDIT_FILTER_ENTRY {
-				return fmt.Errorf("auditRuleFieldPairData failed: %v can only be used with exit and entry filter list", fieldname)
+			if flags != AUDIT_FILTER_EXIT {
+				return fmt.Errorf("auditRuleFieldPairData failed: %v can only be used with exit filter list", fieldname)
 			}
 			var fileval int
 			err := auditNameToFtype(val, &fileval)
@@ -422,6 +454,25 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 			return fmt.Errorf("auditRuleFieldPairData failed: expected string but filetype found %v", fieldval)
 		}
 
+	case AUDIT_FIELD_COMPARE:
+		if flags != AUDIT_FILTER_EXIT {
+			return fmt.Errorf("auditRuleFieldPairData failed: %v can only be used with exit filter list", fieldname)
+		}
+		if val, isString := fieldval.(string); isString {
+			c, err := parseFieldCompare(val)
+			if err != nil {
+				return errors.Wrap(err, "auditRuleFieldPairData failed")
+			}
+			rule.Values[rule.FieldCount] = c
+		} else if val, isInt := fieldval.(float64); isInt {
+			if _, ok := fieldCompares[auditConstant(val)]; !ok {
+				return fmt.Errorf("auditRuleFieldPairData failed: unknown field comparison %v", val)
+			}
+			rule.Values[rule.FieldCount] = (uint32)(val)
+		} else {
+			return errors.Wrap(errUnset, fmt.Sprintf("auditRuleFieldPairData failed to set: %v", fieldval))
+		}
+
 	case AUDIT_ARG0, AUDIT_ARG1, AUDIT_ARG2, AUDIT_ARG3:
 		if val, isInt := fieldval.(float64); isInt {
 			rule.Values[rule.FieldCount] = (uint32)(val)
@@ -464,7 +515,45 @@ func auditRuleFieldPairData(rule *AuditRuleData, fieldval interface{}, opval uin
 	return nil
 }
 
+// ParseOperator converts an operator symbol (=, !=, >, >=, <, <=, &, &=) or its name as used in
+// JSON rule sets (eq, nt_eq, gt, gt_or_eq, lt, lt_or_eq, and, and_eq) to the AUDIT_* operator value.
+func ParseOperator(op string) (uint32, error) {
+	if opval, ok := symbolToOperator(op); ok {
+		return opval, nil
+	}
+	if opval, ok := jsonOperators[op]; ok {
+		return opval, nil
+	}
+	return 0, fmt.Errorf("ParseOperator failed: unknown operator %v", op)
+}
+
+// auditFieldOperatorValid checks that the kernel accepts the operator for the field (audit_field_valid),
+// rules with operators the field doesn't support are rejected or never match.
+func auditFieldOperatorValid(fieldid, opval uint32) error {
+	if _, ok := opLookup[int(opval)]; !ok {
+		return fmt.Errorf("unknown operator 0x%x", opval)
+	}
+	switch fieldid {
+	case AUDIT_ARG0, AUDIT_ARG1, AUDIT_ARG2, AUDIT_ARG3, AUDIT_PERS, AUDIT_DEVMINOR:
+		// all operators, bit operators are meant for syscall arguments, personality and device minors
+		return nil
+	case AUDIT_SUBJ_USER, AUDIT_SUBJ_ROLE, AUDIT_SUBJ_TYPE,
+		AUDIT_OBJ_USER, AUDIT_OBJ_ROLE, AUDIT_OBJ_TYPE,
+		AUDIT_WATCH, AUDIT_DIR, AUDIT_FILTERKEY, AUDIT_LOGINUID_SET, AUDIT_ARCH, AUDIT_PERM,
+		AUDIT_FILETYPE, AUDIT_FIELD_COMPARE, AUDIT_EXE:
+		if opval != AUDIT_EQUAL && opval != AUDIT_NOT_EQUAL {
+			return fmt.Errorf("operator %v not supported, only = and != are", opLookup[int(opval)])
+		}
+	default:
+		if opval == AUDIT_BIT_MASK || opval == AUDIT_BIT_TEST {
+			return fmt.Errorf("operator %v not supported, bit operators are only supported for a0-a3, personality and devminor", opLookup[int(opval)])
+		}
+	}
+	return nil
+}
+
 var errEntryDep = errors.New("use of entry filter is deprecated")
+var errPossibleDep = errors.New("use of possible action is deprecated")
 
 func setActionAndFilters(actions []interface{}) (int, int) {
 	action := -1
@@ -492,23 +581,30 @@ func setActionAndFilters(actions []interface{}) (int, int) {
 	return action, filter
 }
 
-//auditAddRuleData sends the prepared auditRuleData struct via the netlink connection to kernel
-func auditAddRuleData(s Netlink, rule *AuditRuleData, flags int, action int) error {
-
+// auditRuleWireFormat sets the filter list flags and action of rule and returns it in the wire format,
+// the payload of AUDIT_ADD_RULE requests
+func auditRuleWireFormat(rule *AuditRuleData, flags int, action int) ([]byte, error) {
 	if flags == AUDIT_FILTER_ENTRY {
-		return errors.Wrap(errEntryDep, "auditAddRuleData failed")
+		return nil, errEntryDep
 	}
 
 	rule.Flags = uint32(flags)
 	rule.Action = uint32(action)
 	// Using unsafe for conversion
-	newbuff := rule.toWireFormat()
 	// standard method avoided as it require the 0 byte array to be fixed size array
 	// buff := new(bytes.Buffer), binary.Write(buff, nativeEndian(), *rule)
+	return rule.toWireFormat(), nil
+}
+
+//auditAddRuleData sends the prepared auditRuleData struct via the netlink connection to kernel
+func auditAddRuleData(s Netlink, rule *AuditRuleData, flags int, action int) error {
+	newbuff, err := auditRuleWireFormat(rule, flags, action)
+	if err != nil {
+		return errors.Wrap(err, "auditAddRuleData failed")
+	}
 
 	newwb := newNetlinkAuditRequest(uint16(AUDIT_ADD_RULE), syscall.AF_NETLINK, len(newbuff))
 	newwb.Data = append(newwb.Data, newbuff[:]...)
-	var err error
 	if err = s.Send(newwb); err != nil {
 		return errors.Wrap(err, "auditAddRuleData failed")
 	}
@@ -520,7 +616,10 @@ func auditAddRuleData(s Netlink, rule *AuditRuleData, flags int, action int) err
 
 /*
 SetRules reads the configuration file for audit rules and sets them in kernel.
-It expects the config in a json formatted string of following format:
+Rule files in auditctl syntax (audit.rules, see ParseAuditctlRules) are recognized by their first line
+starting with - or #, their -D and status settings are applied as well. JSON rule sets in the list
+form of LoadRulesFromJSON are recognized by their leading [ and loaded like SetRulesFromJSON does.
+Otherwise it expects the config in a json formatted string of following format:
 {
     "delete": true,
     "enable": "1",
@@ -583,181 +682,471 @@ It expects the config in a json formatted string of following format:
         }
 	]
 }
+A list of exclude_rules adds rules to the exclude filter list, the kernel drops the records matching them
+before they are sent (see AddExcludeRule). Exclude rules take the record type as msgtype ({"msgtype": "CWD"})
+and/or fields like syscall rules, i.e. {"fields": [{"name": "msgtype", "value": "CRYPTO_KEY_USER", "op": "eq"}]}.
+The key of file and syscall rules is a single key or a list of keys ("key": ["identity", "secrets"]), the
+kernel stores the keys of a rule joined with \x01 and reports them the same way (see AuditEvent.Keys).
 */
 func SetRules(s Netlink, content []byte) ([]*AuditRuleData, error) {
+	return SetRulesWithOptions(s, content, SetRulesOptions{})
+}
+
+// SetRulesOptions configures SetRulesWithOptions.
+type SetRulesOptions struct {
+	// ExpandArch installs the exit and entry rules naming syscalls without arch field twice like auditctl
+	// does, as arch=b64 and as arch=b32 rule (see ExpandArchRules). Otherwise the syscall numbers of
+	// the native arch apply to the callers of all archs, i.e. 32 bit programs are audited for the wrong
+	// syscalls on 64 bit machines.
+	ExpandArch bool
+}
+
+// SetRulesWithOptions sets the rules of the rule set content like SetRules.
+func SetRulesWithOptions(s Netlink, content []byte, opts SetRulesOptions) ([]*AuditRuleData, error) {
+	if isAuditctlRules(content) {
+		return setAuditctlRules(s, content, opts)
+	}
+	if isJSONRuleList(content) {
+		rules, err := setJSONRuleList(s, content, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "SetRules failed")
+		}
+		return rules, nil
+	}
 	var ruleArray []*AuditRuleData
-	var (
-		rules      interface{}
-		err        error
-		strictPath bool
-	)
-	err = json.Unmarshal(content, &rules)
+	// all rules are checked before any of them is sent so invalid rule sets are not half applied
+	rules, err := parseJSONRules(content, opts.ExpandArch)
 	if err != nil {
 		return nil, errors.Wrap(err, "SetRules failed")
 	}
+	for _, r := range rules {
+		if err := auditAddRuleData(s, r.data, r.filter, r.action); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("SetRules failed %+v", *r.data))
+		}
+		ruleArray = append(ruleArray, r.data)
+	}
+	return ruleArray, nil
+}
 
-	m := rules.(map[string]interface{})
-
+// MarshalRules is the dry run of SetRules, it returns the payloads of the AUDIT_ADD_RULE requests SetRules
+// would send for the rule set content and the rules as auditctl commands (without the auditctl prefix,
+// see ListAllRules), without talking to the kernel.
+func MarshalRules(content []byte) ([][]byte, []string, error) {
+	rules, err := parseJSONRules(content, false)
 	if err != nil {
-		return nil, errors.Wrap(err, "SetRules failed")
+		return nil, nil, errors.Wrap(err, "MarshalRules failed")
+	}
+	var (
+		payloads [][]byte
+		printed  []string
+	)
+	for _, r := range rules {
+		payload, err := auditRuleWireFormat(r.data, r.filter, r.action)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("MarshalRules failed %+v", *r.data))
+		}
+		payloads = append(payloads, payload)
+		printed = append(printed, printRule(r.data))
+	}
+	return payloads, printed, nil
+}
+
+// jsonRule is a rule of a JSON rule set in its kernel representation
+type jsonRule struct {
+	data   *AuditRuleData
+	filter int
+	action int
+}
+
+// jsonOperators maps the operators of JSON syscall rules to their values
+var jsonOperators = map[string]uint32{
+	"eq":       AUDIT_EQUAL,
+	"nt_eq":    AUDIT_NOT_EQUAL,
+	"gt":       AUDIT_GREATER_THAN,
+	"gt_or_eq": AUDIT_GREATER_THAN_OR_EQUAL,
+	"lt":       AUDIT_LESS_THAN,
+	"lt_or_eq": AUDIT_LESS_THAN_OR_EQUAL,
+	"and":      AUDIT_BIT_MASK,
+	"and_eq":   AUDIT_BIT_TEST,
+}
+
+// parseJSONRules converts the file_rules, syscall_rules and exclude_rules of a JSON rule set (see SetRules) to their
+// kernel representation, file rules come first. Errors identify the offending rule and field.
+// expandArch splits syscall rules without arch field into a b64 and a b32 rule, see SetRulesOptions.
+func parseJSONRules(content []byte, expandArch bool) ([]jsonRule, error) {
+	var (
+		rules      interface{}
+		result     []jsonRule
+		strictPath bool
+	)
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, errors.Wrap(err, "parseJSONRules failed")
+	}
+	m, ok := rules.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parseJSONRules failed: rule set is not a JSON object")
 	}
-	if strict, ok := m["strict_path_check"]; ok && strict.(bool) {
+	if strict, ok := m["strict_path_check"].(bool); ok && strict {
 		strictPath = true
 	}
-	//TODO: syscallMap should be loaded according to runtime arch
-	//syscallMap := headers.SysMapX64
-	for k, v := range m {
-		auditSyscallAdded = false
-		switch k {
-		case "file_rules":
-			vi := v.([]interface{})
-			for ruleNo := range vi {
-				rule := vi[ruleNo].(map[string]interface{})
-				path, ok := rule["path"]
-				if path == "" || !ok {
-					return nil, errors.Wrap(err, "SetRules failed: watch option needs a path")
+	if v, ok := m["file_rules"]; ok {
+		vi, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("parseJSONRules failed: file_rules is not a list")
+		}
+		for ruleNo := range vi {
+			rule, ok := vi[ruleNo].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("parseJSONRules failed: file_rules[%d] is not an object", ruleNo)
+			}
+			ruleData, err := fileRuleData(rule, strictPath)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("parseJSONRules failed: file_rules[%d]", ruleNo))
+			}
+			result = append(result, jsonRule{data: ruleData, filter: AUDIT_FILTER_EXIT, action: AUDIT_ALWAYS})
+		}
+	}
+	if v, ok := m["syscall_rules"]; ok {
+		vi, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("parseJSONRules failed: syscall_rules is not a list")
+		}
+		for sruleNo := range vi {
+			srule, ok := vi[sruleNo].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("parseJSONRules failed: syscall_rules[%d] is not an object", sruleNo)
+			}
+			variants := []map[string]interface{}{srule}
+			if expandArch {
+				var err error
+				if variants, err = expandJSONArch(srule); err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("parseJSONRules failed: syscall_rules[%d]", sruleNo))
 				}
-				var ruleData AuditRuleData
-				ruleData.Buf = make([]byte, 0)
-				add := AUDIT_FILTER_EXIT
-				action := AUDIT_ALWAYS
-				auditSyscallAdded = true
-
-				err = auditSetupAndAddWatchDir(&ruleData, path.(string), strictPath)
+			}
+			for _, srule := range variants {
+				r, err := syscallRuleData(srule)
 				if err != nil {
-					return nil, errors.Wrap(err, "SetRules failed")
-				}
-				perms, ok := rule["permission"]
-				if ok {
-					err = auditSetupAndUpdatePerms(&ruleData, perms.(string))
-					if err != nil {
-						return nil, errors.Wrap(err, "SetRules failed")
-					}
+					return nil, errors.Wrap(err, fmt.Sprintf("parseJSONRules failed: syscall_rules[%d]", sruleNo))
 				}
+				result = append(result, *r)
+			}
+		}
+	}
+	if v, ok := m["exclude_rules"]; ok {
+		vi, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("parseJSONRules failed: exclude_rules is not a list")
+		}
+		for ruleNo := range vi {
+			rule, ok := vi[ruleNo].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("parseJSONRules failed: exclude_rules[%d] is not an object", ruleNo)
+			}
+			r, err := excludeRuleData(rule)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("parseJSONRules failed: exclude_rules[%d]", ruleNo))
+			}
+			result = append(result, *r)
+		}
+	}
+	return result, nil
+}
 
-				key, ok := rule["key"]
-				if ok {
-					err = auditRuleFieldPairData(&ruleData, key, AUDIT_EQUAL, "key", AUDIT_FILTER_UNSET) // &AUDIT_BIT_MASK
-					if err != nil {
-						return nil, errors.Wrap(err, "SetRules failed")
-					}
-				}
+// fileRuleData converts a watch of the file_rules of a JSON rule set
+func fileRuleData(rule map[string]interface{}, strictPath bool) (*AuditRuleData, error) {
+	var ruleData AuditRuleData
+	ruleData.Buf = make([]byte, 0)
+	path, ok := rule["path"].(string)
+	if path == "" || !ok {
+		return nil, fmt.Errorf("watch option needs a path")
+	}
+	st := ruleFieldState{syscallAdded: true}
 
-				err = auditAddRuleData(s, &ruleData, add, action)
-				if err != nil {
-					return nil, errors.Wrap(err, fmt.Sprintf("SetRules failed %+v", ruleData))
-				}
-				ruleArray = append(ruleArray, &ruleData)
+	if err := auditSetupAndAddWatchDir(&ruleData, path, strictPath); err != nil {
+		return nil, errors.Wrap(err, "path")
+	}
+	if perms, ok := rule["permission"]; ok {
+		perms, ok := perms.(string)
+		if !ok {
+			return nil, fmt.Errorf("permission: string expected, found %v", rule["permission"])
+		}
+		if err := auditSetupAndUpdatePerms(&ruleData, perms); err != nil {
+			return nil, errors.Wrap(err, "permission")
+		}
+	}
+	if key, ok := rule["key"]; ok {
+		key, err := jsonRuleKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err = auditRuleFieldPairData(&ruleData, &st, key, AUDIT_EQUAL, "key", AUDIT_FILTER_UNSET); err != nil {
+			return nil, errors.Wrap(err, "key")
+		}
+	}
+	return &ruleData, nil
+}
+
+// syscallRuleData converts a rule of the syscall_rules of a JSON rule set
+func syscallRuleData(srule map[string]interface{}) (*jsonRule, error) {
+	var ruleData AuditRuleData
+	ruleData.Buf = make([]byte, 0)
+
+	// syscall numbers depend on the arch the rule is restricted to
+	arch, err := ruleFieldsArch(srule["fields"])
+	if err != nil {
+		return nil, errors.Wrap(err, "field arch")
+	}
+	// Process syscalls
+	if v, ok := srule["syscalls"]; ok {
+		syscalls, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("syscalls is not a list")
+		}
+		for _, syscall := range syscalls {
+			name, ok := syscall.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected syscall name %v", syscall)
 			}
+			ival, err := AuditSyscallToNumber(name, arch)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("syscall %v", name))
+			}
+			if err := auditRuleSyscallData(&ruleData, ival); err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("syscall %v", name))
+			}
+		}
+	}
+	// rules without syscalls (i.e. path and perm fields) apply to all syscalls
+	st := ruleFieldState{syscallAdded: true}
 
-		case "syscall_rules":
-			vi := v.([]interface{})
-			for sruleNo := range vi {
-				srule := vi[sruleNo].(map[string]interface{})
-				var (
-					ruleData         AuditRuleData
-					syscallsNotFound string
-				)
-				ruleData.Buf = make([]byte, 0)
-				// Process syscalls
-				syscalls, ok := srule["syscalls"].([]interface{})
-				if ok {
-					for _, syscall := range syscalls {
-						syscall, ok := syscall.(string)
-						if !ok {
-							return nil, fmt.Errorf("SetRules failed: unexpected syscall name %v", syscall)
-						}
-						if ival := headers.SysMapX64(syscall); ival != -1 {
-							err = auditRuleSyscallData(&ruleData, ival)
-							if err == nil {
-								auditSyscallAdded = true
-							} else {
-								return nil, errors.Wrap(err, "SetRules failed")
-							}
-						} else {
-							syscallsNotFound += " " + syscall
-						}
-					}
-				}
-				if auditSyscallAdded != true {
-					return nil, fmt.Errorf("SetRules failed: one or more syscalls not found: %v", syscallsNotFound)
-				}
+	// Process action
+	actions, ok := srule["actions"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("actions missing")
+	}
 
-				// Process action
-				actions := srule["actions"].([]interface{})
-
-				//Apply action on syscall by separating the filters (exit) from actions (always)
-				action, filter := setActionAndFilters(actions)
-
-				// Process fields
-				fields, ok := srule["fields"].([]interface{})
-				if ok {
-					for _, field := range fields {
-						fieldval := field.(map[string]interface{})["value"]
-						op := field.(map[string]interface{})["op"]
-						fieldname := field.(map[string]interface{})["name"]
-						var opval uint32
-						if op == "nt_eq" {
-							opval = AUDIT_NOT_EQUAL
-						} else if op == "gt_or_eq" {
-							opval = AUDIT_GREATER_THAN_OR_EQUAL
-						} else if op == "lt_or_eq" {
-							opval = AUDIT_LESS_THAN_OR_EQUAL
-						} else if op == "and_eq" {
-							opval = AUDIT_BIT_TEST
-						} else if op == "eq" {
-							opval = AUDIT_EQUAL
-						} else if op == "gt" {
-							opval = AUDIT_GREATER_THAN
-						} else if op == "lt" {
-							opval = AUDIT_LESS_THAN
-						} else if op == "and" {
-							opval = AUDIT_BIT_MASK
-						}
-
-						//Take appropriate action according to filters provided
-						err = auditRuleFieldPairData(&ruleData, fieldval, opval, fieldname.(string), filter) // &AUDIT_BIT_MASK
-						if err != nil {
-							return nil, errors.Wrap(err, "SetRules failed")
-						}
-					}
-				}
+	//Apply action on syscall by separating the filters (exit) from actions (always)
+	action, filter := setActionAndFilters(actions)
+	if filter == AUDIT_FILTER_UNSET || action == -1 {
+		return nil, fmt.Errorf("filters not set or invalid: %v", actions)
+	}
+	if action == AUDIT_POSSIBLE {
+		return nil, errPossibleDep
+	}
+	if ruleData.Mask != ([AUDIT_BITMASK_SIZE]uint32{}) && filter != AUDIT_FILTER_EXIT {
+		return nil, fmt.Errorf("syscalls can't be used with %v filter", flagToName(uint32(filter)))
+	}
+	if ruleData.Mask == ([AUDIT_BITMASK_SIZE]uint32{}) && filter == AUDIT_FILTER_EXIT {
+		for i := 0; i < AUDIT_BITMASK_SIZE-1; i++ {
+			ruleData.Mask[i] = 0xFFFFFFFF
+		}
+	}
 
-				key, ok := srule["key"]
-				if ok {
-					err = auditRuleFieldPairData(&ruleData, key, AUDIT_EQUAL, "key", AUDIT_FILTER_UNSET) // &AUDIT_BIT_MASK
-					if err != nil {
-						return nil, errors.Wrap(err, "SetRules failed")
-					}
-				}
+	// Process fields
+	if v, ok := srule["fields"]; ok {
+		if err := jsonRuleFields(&ruleData, &st, v, filter); err != nil {
+			return nil, err
+		}
+	}
 
-				_, ok = srule["prepend"]
-				if ok {
-					ruleData.Flags |= AUDIT_FILTER_PREPEND
-				}
+	if key, ok := srule["key"]; ok {
+		key, err := jsonRuleKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err = auditRuleFieldPairData(&ruleData, &st, key, AUDIT_EQUAL, "key", AUDIT_FILTER_UNSET); err != nil {
+			return nil, errors.Wrap(err, "key")
+		}
+	}
 
-				if filter != AUDIT_FILTER_UNSET {
-					err = auditAddRuleData(s, &ruleData, filter, action)
-					if err != nil {
-						return nil, errors.Wrap(err, fmt.Sprintf("SetRules failed %+v", ruleData))
-					}
-					ruleArray = append(ruleArray, &ruleData)
-				} else {
-					return nil, fmt.Errorf("SetRules failed: filters not set or invalid: %v , %v ", actions[0].(string), actions[1].(string))
-				}
-			}
+	// the flags of the rule are set from the filter when it is sent
+	if _, ok := srule["prepend"]; ok {
+		filter |= AUDIT_FILTER_PREPEND
+	}
+	return &jsonRule{data: &ruleData, filter: filter, action: action}, nil
+}
+
+// jsonRuleKey returns the key of a rule of a JSON rule set, a list of keys is joined with \x01
+// like the kernel stores several keys
+func jsonRuleKey(key interface{}) (interface{}, error) {
+	list, ok := key.([]interface{})
+	if !ok {
+		return key, nil
+	}
+	keys := make([]string, 0, len(list))
+	for _, k := range list {
+		name, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("key: string expected, found %v", k)
 		}
+		keys = append(keys, name)
 	}
-	return ruleArray, nil
+	return strings.Join(keys, "\x01"), nil
+}
+
+// jsonRuleFields adds the fields of a rule of a JSON rule set to ruleData
+func jsonRuleFields(ruleData *AuditRuleData, st *ruleFieldState, v interface{}, filter int) error {
+	fields, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("fields is not a list")
+	}
+	for fieldNo, field := range fields {
+		f, ok := field.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("fields[%d] is not an object", fieldNo)
+		}
+		fieldname, ok := f["name"].(string)
+		if !ok {
+			return fmt.Errorf("fields[%d]: name missing", fieldNo)
+		}
+		op, ok := f["op"].(string)
+		if !ok {
+			return fmt.Errorf("field %v: op missing", fieldname)
+		}
+		opval, err := ParseOperator(op)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("field %v", fieldname))
+		}
+		//Take appropriate action according to filters provided
+		if err := auditRuleFieldPairData(ruleData, st, f["value"], opval, fieldname, filter); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("field %v", fieldname))
+		}
+	}
+	return nil
+}
+
+// excludeRuleData converts a rule of the exclude_rules of a JSON rule set, the record type of the
+// msgtype shorthand is matched before the fields
+func excludeRuleData(rule map[string]interface{}) (*jsonRule, error) {
+	var ruleData AuditRuleData
+	ruleData.Buf = make([]byte, 0)
+	st := ruleFieldState{syscallAdded: true}
+	if _, ok := rule["syscalls"]; ok {
+		return nil, fmt.Errorf("syscalls can't be used with exclude filter")
+	}
+	msgType, hasType := rule["msgtype"]
+	if hasType {
+		if err := auditRuleFieldPairData(&ruleData, &st, msgType, AUDIT_EQUAL, "msgtype", AUDIT_FILTER_EXCLUDE); err != nil {
+			return nil, errors.Wrap(err, "msgtype")
+		}
+	}
+	v, hasFields := rule["fields"]
+	if hasFields {
+		if err := jsonRuleFields(&ruleData, &st, v, AUDIT_FILTER_EXCLUDE); err != nil {
+			return nil, err
+		}
+	}
+	if !hasType && !hasFields {
+		return nil, fmt.Errorf("exclude rule needs a msgtype or fields")
+	}
+	return &jsonRule{data: &ruleData, filter: AUDIT_FILTER_EXCLUDE, action: AUDIT_ALWAYS}, nil
+}
+
+// ruleFieldsArch returns the arch set in the fields of a JSON syscall rule, the native arch if none is set
+func ruleFieldsArch(fields interface{}) (uint32, error) {
+	fieldList, _ := fields.([]interface{})
+	for _, field := range fieldList {
+		f, ok := field.(map[string]interface{})
+		if ok && f["name"] == "arch" {
+			return auditNameToArch(f["value"])
+		}
+	}
+	_, arch := NativeArch()
+	return arch, nil
+}
+
+// splitArchSyscalls splits the syscalls of a rule without arch field between the native arch and its
+// compat arch, a syscall goes to every arch which has it. Syscalls (and numbers) found on neither arch stay
+// with the native arch where they are reported, syscalls only the compat arch has are an error like with
+// auditctl, the rule has to name the arch for them. ok is false if the native arch has no compat arch with
+// a syscall table, the rule is not split then.
+func splitArchSyscalls(syscalls []string) (native, compat []string, ok bool, err error) {
+	_, arch := NativeArch()
+	carch, ok := compatArch[arch]
+	if !ok || arch&__AUDIT_ARCH_64BIT == 0 {
+		return nil, nil, false, nil
+	}
+	if _, ok := syscallTables[carch]; !ok {
+		return nil, nil, false, nil
+	}
+	for _, name := range syscalls {
+		_, errNative := AuditSyscallToNumber(name, arch)
+		_, errCompat := AuditSyscallToNumber(name, carch)
+		if errNative != nil && errCompat == nil {
+			return nil, nil, false, fmt.Errorf("syscall %v is unknown on arch %v, it needs an arch=%v field", name, archToName(arch), archToName(carch))
+		}
+		if errCompat == nil {
+			compat = append(compat, name)
+		}
+		native = append(native, name)
+	}
+	return native, compat, true, nil
+}
+
+// expandJSONArch returns the b64 and b32 variants of a rule of the syscall_rules of a JSON rule set
+// naming syscalls without arch field, other rules are returned as they are
+func expandJSONArch(srule map[string]interface{}) ([]map[string]interface{}, error) {
+	unchanged := []map[string]interface{}{srule}
+	actions, _ := srule["actions"].([]interface{})
+	if _, filter := setActionAndFilters(actions); filter != AUDIT_FILTER_EXIT && filter != AUDIT_FILTER_ENTRY {
+		return unchanged, nil
+	}
+	fields, _ := srule["fields"].([]interface{})
+	for _, field := range fields {
+		if f, ok := field.(map[string]interface{}); ok && f["name"] == "arch" {
+			return unchanged, nil
+		}
+	}
+	list, _ := srule["syscalls"].([]interface{})
+	var syscalls []string
+	for _, v := range list {
+		name, ok := v.(string)
+		if !ok {
+			// reported by syscallRuleData
+			return unchanged, nil
+		}
+		syscalls = append(syscalls, name)
+	}
+	if len(syscalls) == 0 {
+		return unchanged, nil
+	}
+	native, compat, ok, err := splitArchSyscalls(syscalls)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return unchanged, nil
+	}
+	var variants []map[string]interface{}
+	for _, v := range []struct {
+		arch     string
+		syscalls []string
+	}{{"b64", native}, {"b32", compat}} {
+		if len(v.syscalls) == 0 {
+			continue
+		}
+		variant := make(map[string]interface{}, len(srule))
+		for k, val := range srule {
+			variant[k] = val
+		}
+		var names []interface{}
+		for _, name := range v.syscalls {
+			names = append(names, name)
+		}
+		variant["syscalls"] = names
+		archField := map[string]interface{}{"name": "arch", "value": v.arch, "op": "eq"}
+		variant["fields"] = append([]interface{}{archField}, fields...)
+		variants = append(variants, variant)
+	}
+	return variants, nil
 }
 
-var errPathTooBig = errors.New("the path passed for the watch is too big")
 var errPathStart = errors.New("the path must start with '/'")
 var errBaseTooBig = errors.New("the base name of the path is too big")
 
 func checkPath(pathName string) error {
 	if len(pathName) >= PATH_MAX {
-		return errors.Wrap(errPathTooBig, "checkPath failed")
+		return errors.Wrap(ErrPathTooLong, fmt.Sprintf("checkPath failed: path of %d bytes, the limit is %d", len(pathName), PATH_MAX-1))
 	}
 	if pathName[0] != '/' {
 		return errors.Wrap(errPathStart, "checkPath failed")
@@ -851,30 +1240,192 @@ func auditAddWatchDir(typeName uint16, rule *AuditRuleData, pathName string) err
 	return nil
 }
 
+// AddDirWatch adds a rule watching the whole directory tree under dir (-F dir=), like auditctl
+// -a always,exit -F dir=<dir> -F perm=<perms> -k <key>. dir must be an absolute path, perms is
+// a combination of "rwxa" (all if empty) and key is optional.
+func AddDirWatch(s Netlink, dir string, perms string, key string) error {
+	var rule AuditRuleData
+	rule.Buf = make([]byte, 0)
+	if len(dir) == 0 {
+		return errors.Wrap(errPathStart, "AddDirWatch failed")
+	}
+	if err := checkPath(dir); err != nil {
+		return errors.Wrap(err, "AddDirWatch failed")
+	}
+	// Trim trailing '/' should they exist, but keep the root
+	if trimmed := strings.TrimRight(dir, "/"); len(trimmed) > 0 {
+		dir = trimmed
+	}
+	if err := auditAddWatchDir(uint16(AUDIT_DIR), &rule, dir); err != nil {
+		return errors.Wrap(err, "AddDirWatch failed")
+	}
+	if len(perms) > 0 {
+		if err := auditSetupAndUpdatePerms(&rule, perms); err != nil {
+			return errors.Wrap(err, "AddDirWatch failed")
+		}
+	}
+	if len(key) > 0 {
+		st := ruleFieldState{syscallAdded: true}
+		if err := auditRuleFieldPairData(&rule, &st, key, AUDIT_EQUAL, "key", AUDIT_FILTER_UNSET); err != nil {
+			return errors.Wrap(err, "AddDirWatch failed")
+		}
+	}
+	if err := auditAddRuleData(s, &rule, AUDIT_FILTER_EXIT, AUDIT_ALWAYS); err != nil {
+		return errors.Wrap(err, "AddDirWatch failed")
+	}
+	return nil
+}
+
+// AddWatch adds the watch rule of auditctl -w <path> -p <perms> -k <key>, see NewWatchRule. Directories are
+// watched with their directory tree like with AddDirWatch, perms is a combination of "rwxa" (all if empty)
+// and key is optional.
+func AddWatch(s Netlink, path string, perms string, key string) error {
+	r, err := NewWatchRule(path, perms, key)
+	if err != nil {
+		return errors.Wrap(err, "AddWatch failed")
+	}
+	rule, err := r.toRuleData()
+	if err != nil {
+		return errors.Wrap(err, "AddWatch failed")
+	}
+	if err := auditAddRuleData(s, rule, int(r.Flags), int(r.Action)); err != nil {
+		return errors.Wrap(err, "AddWatch failed")
+	}
+	return nil
+}
+
+// AuditTrimTrees makes the kernel drop the mounts of directory tree watches (AddDirWatch) which are no
+// longer reachable from the watched directory, like auditctl -t after unmounting or moving file systems.
+func AuditTrimTrees(s Netlink) error {
+	wb := newNetlinkAuditRequest(uint16(AUDIT_TRIM), syscall.AF_NETLINK, 0)
+	if err := s.Send(wb); err != nil {
+		return errors.Wrap(err, "AuditTrimTrees failed")
+	}
+	if err := auditGetReply(s, syscall.Getpagesize(), 0, wb.Header.Seq); err != nil {
+		return errors.Wrap(err, "AuditTrimTrees failed")
+	}
+	return nil
+}
+
+// AuditMakeEquiv makes the directory tree under path an equivalent of mount for the directory tree watches,
+// like auditctl -q <mount>,<path>: the trees watched under mount are watched under path as well, i.e. path is
+// a bind mount of mount. Both must be absolute paths.
+func AuditMakeEquiv(s Netlink, mount string, path string) error {
+	for _, p := range []string{mount, path} {
+		if len(p) == 0 {
+			return errors.Wrap(errPathStart, "AuditMakeEquiv failed")
+		}
+		if err := checkPath(p); err != nil {
+			return errors.Wrap(err, "AuditMakeEquiv failed")
+		}
+	}
+	// the sizes of both paths followed by the paths without NUL
+	data := make([]byte, 8, 8+len(mount)+len(path))
+	nativeEndian().PutUint32(data[0:4], uint32(len(mount)))
+	nativeEndian().PutUint32(data[4:8], uint32(len(path)))
+	data = append(append(data, mount...), path...)
+
+	wb := newNetlinkAuditRequest(uint16(AUDIT_MAKE_EQUIV), syscall.AF_NETLINK, len(data))
+	wb.Data = append(wb.Data, data...)
+	if err := s.Send(wb); err != nil {
+		return errors.Wrap(err, "AuditMakeEquiv failed")
+	}
+	if err := auditGetReply(s, syscall.Getpagesize(), 0, wb.Header.Seq); err != nil {
+		return errors.Wrap(err, "AuditMakeEquiv failed")
+	}
+	return nil
+}
+
+// excludeFields are the fields the kernel accepts for the rules of the exclude filter list
+var excludeFields = map[uint32]bool{
+	AUDIT_MSGTYPE:   true,
+	AUDIT_PID:       true,
+	AUDIT_UID:       true,
+	AUDIT_GID:       true,
+	AUDIT_LOGINUID:  true,
+	AUDIT_SUBJ_USER: true,
+	AUDIT_SUBJ_ROLE: true,
+	AUDIT_SUBJ_TYPE: true,
+	AUDIT_SUBJ_SEN:  true,
+	AUDIT_SUBJ_CLR:  true,
+}
+
+// AddExcludeRule adds a rule to the exclude filter list, like auditctl -a always,exclude -F <field><op><value>,
+// the kernel drops the records matching it before they are sent. field is msgtype (the record type, i.e. CWD or
+// 1307), pid, uid, gid, auid or one of the subj_* fields for the security label of the process, op is one of the
+// operators accepted by ParseOperator. The kernel accepts the fields other than msgtype since 4.17.
+func AddExcludeRule(s Netlink, field string, op string, value string) error {
+	if id, ok := headers.FieldMap[field]; !ok || !excludeFields[uint32(id)] {
+		return fmt.Errorf("AddExcludeRule failed: %v field can't be used with exclude filter", field)
+	}
+	r := AuditRule{
+		Flags:  AUDIT_FILTER_EXCLUDE,
+		Action: AUDIT_ALWAYS,
+		Fields: []AuditRuleField{{Name: field, Op: op, Value: value}},
+	}
+	rule, err := r.toRuleData()
+	if err != nil {
+		return errors.Wrap(err, "AddExcludeRule failed")
+	}
+	if err := auditAddRuleData(s, rule, AUDIT_FILTER_EXCLUDE, AUDIT_ALWAYS); err != nil {
+		return errors.Wrap(err, "AddExcludeRule failed")
+	}
+	return nil
+}
+
+// permChars are the characters of the permissions of watch rules (auditctl -p) in the order DecodePerm returns them
+var permChars = []struct {
+	c   byte
+	bit int
+}{
+	{'r', AUDIT_PERM_READ},
+	{'w', AUDIT_PERM_WRITE},
+	{'x', AUDIT_PERM_EXEC},
+	{'a', AUDIT_PERM_ATTR},
+}
+
+// EncodePerm returns the AUDIT_PERM_* mask of the permission string of watch rules, i.e. "wa"
+// is AUDIT_PERM_WRITE|AUDIT_PERM_ATTR, characters other than rwxa are an error.
+func EncodePerm(perms string) (int, error) {
+	if len(perms) == 0 || len(perms) > len(permChars) {
+		return 0, fmt.Errorf("EncodePerm: invalid permission string %q", perms)
+	}
+	var mask int
+	for _, c := range []byte(strings.ToLower(perms)) {
+		var found bool
+		for _, p := range permChars {
+			if p.c == c {
+				mask |= p.bit
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("EncodePerm: permission can only contain 'rwxa', found %q", c)
+		}
+	}
+	return mask, nil
+}
+
+// DecodePerm returns the permission string of the AUDIT_PERM_* mask, the reverse of EncodePerm.
+func DecodePerm(mask int) string {
+	var perms []byte
+	for _, p := range permChars {
+		if mask&p.bit != 0 {
+			perms = append(perms, p.c)
+		}
+	}
+	return string(perms)
+}
+
 // auditSetupAndUpdatePerms validates permission string and passes their
 // integer equivalents to set auditRuleData
 func auditSetupAndUpdatePerms(rule *AuditRuleData, perms string) error {
-	if len(perms) > 4 {
-		return fmt.Errorf("auditSetupAndUpdatePerms failed: invalid permission string %v", perms)
-	}
-	perms = strings.ToLower(perms)
-	var permValue int
-	for _, val := range perms {
-		switch val {
-		case 'r':
-			permValue |= AUDIT_PERM_READ
-		case 'w':
-			permValue |= AUDIT_PERM_WRITE
-		case 'x':
-			permValue |= AUDIT_PERM_EXEC
-		case 'a':
-			permValue |= AUDIT_PERM_ATTR
-		default:
-			return fmt.Errorf("auditSetupAndUpdatePerms failed: unsupported permission %v", val)
-		}
-	}
-
-	err := auditUpdateWatchPerms(rule, permValue)
+	permValue, err := EncodePerm(perms)
+	if err != nil {
+		return errors.Wrap(err, "auditSetupAndUpdatePerms failed")
+	}
+
+	err = auditUpdateWatchPerms(rule, permValue)
 	if err != nil {
 		return errors.Wrap(err, "auditSetupAndUpdatePerms failed")
 	}
@@ -953,13 +1504,14 @@ done:
 			if m.Header.Type == syscall.NLMSG_ERROR {
 				e := int32(nativeEndian().Uint32(m.Data[0:4]))
 				if e != 0 {
-					return nil, nil, fmt.Errorf("ListAllRules: error while receiving rules")
+					return nil, nil, replyError(e, fmt.Errorf("ListAllRules: error while receiving rules %d", e))
 				}
 			}
 			if m.Header.Type == uint16(AUDIT_LIST_RULES) {
 				var r AuditRuleData
 				nbuf := bytes.NewBuffer(m.Data)
-				err = struc.Unpack(nbuf, &r)
+				// audit_rule_data is in host byte order, override the struc tags
+				err = struc.UnpackWithOptions(nbuf, &r, &struc.Options{Order: nativeEndian()})
 				if err != nil {
 					return nil, nil, errors.Wrap(err, "ListAllRules failed")
 				}
@@ -970,14 +1522,117 @@ done:
 	return result, ruleArray, nil
 }
 
+// CountRules returns the number of audit rules currently loaded in the kernel.
+// The kernel status doesn't carry a rule count, the rules are listed but the
+// replies are only counted, not decoded.
+func CountRules(s Netlink) (int, error) {
+	var count int
+	wb := newNetlinkAuditRequest(uint16(AUDIT_LIST_RULES), syscall.AF_NETLINK, 0)
+	if err := s.Send(wb); err != nil {
+		return 0, errors.Wrap(err, "CountRules failed")
+	}
+	for {
+		msgs, err := s.Receive(MAX_AUDIT_MESSAGE_LENGTH, 0, nil)
+		if err != nil {
+			return 0, errors.Wrap(err, "CountRules failed")
+		}
+		for _, m := range msgs {
+			if m.Header.Seq != wb.Header.Seq {
+				return 0, fmt.Errorf("CountRules: Wrong Seq nr %d, expected %d", m.Header.Seq, wb.Header.Seq)
+			}
+			switch m.Header.Type {
+			case syscall.NLMSG_DONE:
+				return count, nil
+			case syscall.NLMSG_ERROR:
+				e := int32(nativeEndian().Uint32(m.Data[0:4]))
+				if e != 0 {
+					return 0, replyError(e, fmt.Errorf("CountRules: error while receiving rules %d", e))
+				}
+			case uint16(AUDIT_LIST_RULES):
+				count++
+			}
+		}
+	}
+}
+
 //AuditSyscallToName takes syscall number and returns the syscall name. Currently only applicable for x64 arch.
 func AuditSyscallToName(syscall string) (name string, err error) {
-	//syscallMap := headers.ReverseSysMapX64
-	if val := headers.ReverseSysMapX64(syscall); val != "Unsupported" {
+	return AuditArchSyscallToName(syscall, AUDIT_ARCH_X86_64)
+}
+
+// syscallTable holds the lookup functions for the syscalls of an arch
+type syscallTable struct {
+	toNumber func(string) int
+	toName   func(string) string
+}
+
+// syscallTables maps the AUDIT_ARCH_* values to their syscall tables, see RegisterSyscallTable
+var syscallTables = map[uint32]syscallTable{
+	AUDIT_ARCH_X86_64:  {headers.SysMapX64, headers.ReverseSysMapX64},
+	AUDIT_ARCH_I386:    {headers.SysMapI386, headers.ReverseSysMapI386},
+	AUDIT_ARCH_AARCH64: {headers.SysMapAarch64, headers.ReverseSysMapAarch64},
+	AUDIT_ARCH_S390X:   {headers.SysMapS390x, headers.ReverseSysMapS390x},
+	// big and little endian ppc64 share the syscall numbers
+	AUDIT_ARCH_PPC64:   {headers.SysMapPpc64le, headers.ReverseSysMapPpc64le},
+	AUDIT_ARCH_PPC64LE: {headers.SysMapPpc64le, headers.ReverseSysMapPpc64le},
+	AUDIT_ARCH_RISCV64: {headers.SysMapRiscv64, headers.ReverseSysMapRiscv64},
+}
+
+// RegisterSyscallTable adds the syscall table of arch (AUDIT_ARCH_*) or replaces the shipped one.
+// toNumber maps syscall names to their numbers and returns -1 for unknown names, toName maps the
+// numbers (in decimal) to the names and returns "Unsupported" for unknown numbers like the tables
+// of the headers package. name is the machine name used in the arch field of rules, it may be empty
+// for archs which are already known.
+// Tables are registered during initialization (i.e. in an init function), RegisterSyscallTable is not
+// safe for concurrent use with the rest of the package.
+func RegisterSyscallTable(arch uint32, name string, toNumber func(string) int, toName func(string) string) error {
+	if toNumber == nil || toName == nil {
+		return fmt.Errorf("RegisterSyscallTable failed: missing lookup function")
+	}
+	if name != "" {
+		archNames[arch] = name
+	} else if _, ok := archNames[arch]; !ok {
+		return fmt.Errorf("RegisterSyscallTable failed: arch 0x%x requires a name", arch)
+	}
+	syscallTables[arch] = syscallTable{toNumber, toName}
+	return nil
+}
+
+// AuditArchSyscallToName takes syscall number and returns the syscall name from the table of arch (AUDIT_ARCH_*).
+func AuditArchSyscallToName(syscall string, arch uint32) (name string, err error) {
+	table, ok := syscallTables[arch]
+	if !ok {
+		return "", fmt.Errorf("AuditArchSyscallToName failed: no syscall table for arch 0x%x", arch)
+	}
+	if val := table.toName(syscall); val != "Unsupported" {
 		return val, nil
 	}
-	return "", fmt.Errorf("AuditSyscallToName failed: syscall %v not found", syscall)
+	return "", fmt.Errorf("AuditArchSyscallToName failed: syscall %v not found", syscall)
+}
 
+// AuditSyscallToNumber takes syscall name and returns the syscall number from the table of arch (AUDIT_ARCH_*).
+func AuditSyscallToNumber(name string, arch uint32) (int, error) {
+	table, ok := syscallTables[arch]
+	if !ok {
+		return -1, fmt.Errorf("AuditSyscallToNumber failed: no syscall table for arch 0x%x", arch)
+	}
+	if nr := table.toNumber(name); nr != -1 {
+		return nr, nil
+	}
+	return -1, fmt.Errorf("AuditSyscallToNumber failed: syscall %v not found", name)
+}
+
+// ruleDataArch returns the arch the rule is restricted to by its arch field,
+// rules without arch field use the syscall table of the native arch
+func ruleDataArch(rule *AuditRuleData) uint32 {
+	for i := 0; i < int(rule.FieldCount); i++ {
+		field := rule.Fields[i] & (^uint32(AUDIT_OPERATORS))
+		if field == AUDIT_ARCH {
+			return rule.Values[i]
+		}
+	}
+	_, arch := NativeArch()
+	return arch
 }
 
 // printRule returns a string describing rule defined by the passed rule struct
@@ -987,30 +1642,20 @@ func printRule(rule *AuditRuleData) string {
 		watch        = isWatch(rule)
 		result, n    string
 		bufferOffset int
-		count        int
-		sys          int
 		printed      bool
 	)
 	if !watch {
-		result = fmt.Sprintf("-a %s,%s", actionToName(rule.Action), flagToName(rule.Flags))
+		// the kernel keeps AUDIT_FILTER_PREPEND in the flags, the listing is in evaluation order anyway
+		result = fmt.Sprintf("-a %s,%s", actionToName(rule.Action), flagToName(rule.Flags&^AUDIT_FILTER_PREPEND))
 		for i := 0; i < int(rule.FieldCount); i++ {
 			field := rule.Fields[i] & (^uint32(AUDIT_OPERATORS))
 			if field == AUDIT_ARCH {
 				op := rule.Fieldflags[i] & uint32(AUDIT_OPERATORS)
-				result += fmt.Sprintf("-F arch%s", operatorToSymbol(op))
-				//determining arch from the runtime package rather than looking from
-				//arch lookup table as auditd does
-				if runtime.GOARCH == "amd64" {
-					result += "b64"
-				} else if runtime.GOARCH == "386" {
-					result += "b32"
-				} else {
-					result += fmt.Sprintf("0x%X", field)
-				}
+				result += fmt.Sprintf(" -F arch%s%s", operatorToSymbol(op), archToName(rule.Values[i]))
 				break
 			}
 		}
-		n, count, sys, printed = printSyscallRule(rule)
+		n, _, _, printed = printSyscallRule(rule)
 		if printed {
 			result += n
 		}
@@ -1030,14 +1675,15 @@ func printRule(rule *AuditRuleData) string {
 		}
 		// Special cases to print the different field types
 		if field == AUDIT_MSGTYPE {
-			if strings.HasPrefix(auditConstant(rule.Values[i]).String(), "auditConstant") {
+			if !auditConstant(rule.Values[i]).IsKnown() {
 				result += fmt.Sprintf(" f%d%s%d", rule.Fields[i], operatorToSymbol(op), rule.Values[i])
 			} else {
-				result += fmt.Sprintf(" -F %s%s%s", fieldName, operatorToSymbol(op), auditConstant(rule.Values[i]).String()[6:])
+				result += fmt.Sprintf(" -F %s%s%s", fieldName, operatorToSymbol(op), auditConstant(rule.Values[i]).typeName())
 			}
 		} else if (field >= AUDIT_SUBJ_USER && field <= AUDIT_OBJ_LEV_HIGH) && field != AUDIT_PPID {
 			// rule.Values[i] denotes the length of the buffer for the field
 			result += fmt.Sprintf(" -F %s%s%s", fieldName, operatorToSymbol(op), string(rule.Buf[bufferOffset:bufferOffset+int(rule.Values[i])]))
+			bufferOffset += int(rule.Values[i])
 		} else if field == AUDIT_WATCH {
 			if watch {
 				result += fmt.Sprintf("-w %s", string(rule.Buf[bufferOffset:bufferOffset+int(rule.Values[i])]))
@@ -1058,8 +1704,8 @@ func printRule(rule *AuditRuleData) string {
 		} else if field == AUDIT_FILTERKEY {
 			key := fmt.Sprintf("%s", string(rule.Buf[bufferOffset:bufferOffset+int(rule.Values[i])]))
 			bufferOffset += int(rule.Values[i])
-			// checking for multiple keys
-			keyList := strings.Split(key, `\0`)
+			// multiple keys are joined with \x01 like in the records
+			keyList := strings.Split(key, "\x01")
 			for _, k := range keyList {
 				if watch {
 					result += fmt.Sprintf(" -k %s", k)
@@ -1068,19 +1714,7 @@ func printRule(rule *AuditRuleData) string {
 				}
 			}
 		} else if field == AUDIT_PERM {
-			var perms string
-			if (rule.Values[i] & uint32(AUDIT_PERM_READ)) > 0 {
-				perms += "r"
-			}
-			if (rule.Values[i] & uint32(AUDIT_PERM_WRITE)) > 0 {
-				perms += "w"
-			}
-			if (rule.Values[i] & uint32(AUDIT_PERM_EXEC)) > 0 {
-				perms += "x"
-			}
-			if (rule.Values[i] & uint32(AUDIT_PERM_ATTR)) > 0 {
-				perms += "a"
-			}
+			perms := DecodePerm(int(rule.Values[i]))
 			if watch {
 				result += fmt.Sprintf(" -p %s", perms)
 			} else {
@@ -1091,30 +1725,17 @@ func printRule(rule *AuditRuleData) string {
 		} else if field == AUDIT_FIELD_COMPARE {
 			result += printFieldCmp(rule.Values[i], op)
 		} else if field >= AUDIT_ARG0 && field <= AUDIT_ARG3 {
-			var a0, a1 int
-			if field == AUDIT_ARG0 {
-				a0 = int(rule.Values[i])
-			} else if field == AUDIT_ARG1 {
-				a1 = int(rule.Values[i])
-			}
-			if count > 1 {
-				result += fmt.Sprintf(" -F %s%s0x%X", fieldName, operatorToSymbol(op), rule.Values[i])
-			} else {
-				// we try to parse the argument passed so we need the syscall found earlier
-				var r = record{syscallNum: fmt.Sprintf("%d", sys), a0: a0, a1: a1}
-				n, err := interpretField("syscall", fmt.Sprintf("%x", rule.Values[i]), AUDIT_SYSCALL, r)
-				if err != nil {
-					continue
-				}
-				result += fmt.Sprintf(" -F %s%s0x%X", fieldName, operatorToSymbol(op), n)
-			}
+			result += fmt.Sprintf(" -F %s%s0x%X", fieldName, operatorToSymbol(op), rule.Values[i])
+		} else if isIDField(field) && rule.Values[i] == ^uint32(0) {
+			// the unset id, auditctl prints it as -1
+			result += fmt.Sprintf(" -F %s%s-1", fieldName, operatorToSymbol(op))
 		} else if field == AUDIT_EXIT {
 			// in this case rule.Values[i] holds the error code for EXIT
 			// therefore it will need a audit_errno_to_name() function that peeks on error codes
 			// but error codes are widely varied and printExit() function only matches 0 => success
 			// so we are directly printing the integer error code in the rule
 			// and not their string equivalents
-			result += fmt.Sprintf(" -F %s%s%d", fieldName, operatorToSymbol(op), int(rule.Values[i]))
+			result += fmt.Sprintf(" -F %s%s%d", fieldName, operatorToSymbol(op), int32(rule.Values[i]))
 		} else {
 			result += fmt.Sprintf(" -F %s%s%d", fieldName, operatorToSymbol(op), rule.Values[i])
 		}
@@ -1124,6 +1745,15 @@ func printRule(rule *AuditRuleData) string {
 	return result
 }
 
+// isIDField reports whether the field holds a uid or session id which may be unset (4294967295)
+func isIDField(field uint32) bool {
+	switch field {
+	case AUDIT_UID, AUDIT_EUID, AUDIT_SUID, AUDIT_FSUID, AUDIT_LOGINUID, AUDIT_OBJ_UID, AUDIT_OBJ_GID, AUDIT_SESSIONID:
+		return true
+	}
+	return false
+}
+
 //isWatch checks if the auditRuleData is a watch rule.
 //returns true when syscall == all and a perm field is detected in auditRuleData
 func isWatch(rule *AuditRuleData) bool {
@@ -1131,6 +1761,10 @@ func isWatch(rule *AuditRuleData) bool {
 		perm bool
 		all  = true
 	)
+	// listing never rules as -w would turn them into always rules
+	if rule.Action != AUDIT_ALWAYS {
+		return false
+	}
 	for i := 0; i < int(rule.FieldCount); i++ {
 		field := rule.Fields[i] & (^uint32(AUDIT_OPERATORS))
 		if field == AUDIT_PERM {
@@ -1210,7 +1844,7 @@ func printSyscallRule(rule *AuditRuleData) (string, int, int, bool) {
 		word := auditWord(i)
 		bit := auditBit(i)
 		if (rule.Mask[word] & bit) > 0 {
-			n, err := AuditSyscallToName(fmt.Sprintf("%d", i))
+			n, err := AuditArchSyscallToName(fmt.Sprintf("%d", i), ruleDataArch(rule))
 			if len(name) == 0 {
 				name += fmt.Sprintf(" -S ")
 			}
@@ -1237,64 +1871,65 @@ func fieldToName(field uint32) string {
 	return name
 }
 
+// fieldCompares maps the AUDIT_COMPARE_* values of field_compare fields to the fields they compare,
+// in the order auditctl -C prints them
+var fieldCompares = map[auditConstant][2]string{
+	AUDIT_COMPARE_UID_TO_OBJ_UID:   {"uid", "obj_uid"},
+	AUDIT_COMPARE_GID_TO_OBJ_GID:   {"gid", "obj_gid"},
+	AUDIT_COMPARE_EUID_TO_OBJ_UID:  {"euid", "obj_uid"},
+	AUDIT_COMPARE_EGID_TO_OBJ_GID:  {"egid", "obj_gid"},
+	AUDIT_COMPARE_AUID_TO_OBJ_UID:  {"auid", "obj_uid"},
+	AUDIT_COMPARE_SUID_TO_OBJ_UID:  {"suid", "obj_uid"},
+	AUDIT_COMPARE_SGID_TO_OBJ_GID:  {"sgid", "obj_gid"},
+	AUDIT_COMPARE_FSUID_TO_OBJ_UID: {"fsuid", "obj_uid"},
+	AUDIT_COMPARE_FSGID_TO_OBJ_GID: {"fsgid", "obj_gid"},
+	AUDIT_COMPARE_UID_TO_AUID:      {"uid", "auid"},
+	AUDIT_COMPARE_UID_TO_EUID:      {"uid", "euid"},
+	AUDIT_COMPARE_UID_TO_FSUID:     {"uid", "fsuid"},
+	AUDIT_COMPARE_UID_TO_SUID:      {"uid", "suid"},
+	AUDIT_COMPARE_AUID_TO_FSUID:    {"auid", "fsuid"},
+	AUDIT_COMPARE_AUID_TO_SUID:     {"auid", "suid"},
+	AUDIT_COMPARE_AUID_TO_EUID:     {"auid", "euid"},
+	AUDIT_COMPARE_EUID_TO_SUID:     {"euid", "suid"},
+	AUDIT_COMPARE_EUID_TO_FSUID:    {"euid", "fsuid"},
+	AUDIT_COMPARE_SUID_TO_FSUID:    {"suid", "fsuid"},
+	AUDIT_COMPARE_GID_TO_EGID:      {"gid", "egid"},
+	AUDIT_COMPARE_GID_TO_FSGID:     {"gid", "fsgid"},
+	AUDIT_COMPARE_GID_TO_SGID:      {"gid", "sgid"},
+	AUDIT_COMPARE_EGID_TO_FSGID:    {"egid", "fsgid"},
+	AUDIT_COMPARE_EGID_TO_SGID:     {"egid", "sgid"},
+	AUDIT_COMPARE_SGID_TO_FSGID:    {"sgid", "fsgid"},
+}
+
+// parseFieldCompare returns the AUDIT_COMPARE_* value of the fields compared by a field_compare field
+// written as <field>:<field> (i.e. auid:uid), the fields may be given in either order like with auditctl -C
+func parseFieldCompare(value string) (uint32, error) {
+	i := strings.IndexByte(value, ':')
+	if i < 0 {
+		return 0, fmt.Errorf("expected <field>:<field>, found %q", value)
+	}
+	left, right := value[:i], value[i+1:]
+	if left == "loginuid" {
+		left = "auid"
+	}
+	if right == "loginuid" {
+		right = "auid"
+	}
+	for c, f := range fieldCompares {
+		if (f[0] == left && f[1] == right) || (f[0] == right && f[1] == left) {
+			return uint32(c), nil
+		}
+	}
+	return 0, fmt.Errorf("fields %v and %v can't be compared", left, right)
+}
+
 //printFieldCmp returs a string denoting the comparsion between the field values
 func printFieldCmp(value, op uint32) string {
-	var result string
-
-	switch auditConstant(value) {
-	case AUDIT_COMPARE_UID_TO_OBJ_UID:
-		result = fmt.Sprintf(" -C uid%sobj_uid", operatorToSymbol(op))
-	case AUDIT_COMPARE_GID_TO_OBJ_GID:
-		result = fmt.Sprintf(" -C gid%sobj_gid", operatorToSymbol(op))
-	case AUDIT_COMPARE_EUID_TO_OBJ_UID:
-		result = fmt.Sprintf(" -C euid%sobj_uid", operatorToSymbol(op))
-	case AUDIT_COMPARE_EGID_TO_OBJ_GID:
-		result = fmt.Sprintf(" -C egid%sobj_gid", operatorToSymbol(op))
-	case AUDIT_COMPARE_AUID_TO_OBJ_UID:
-		result = fmt.Sprintf(" -C auid%sobj_uid", operatorToSymbol(op))
-	case AUDIT_COMPARE_SUID_TO_OBJ_UID:
-		result = fmt.Sprintf(" -C suid%sobj_uid", operatorToSymbol(op))
-	case AUDIT_COMPARE_SGID_TO_OBJ_GID:
-		result = fmt.Sprintf(" -C sgid%sobj_gid", operatorToSymbol(op))
-	case AUDIT_COMPARE_FSUID_TO_OBJ_UID:
-		result = fmt.Sprintf(" -C fsuid%sobj_uid", operatorToSymbol(op))
-	case AUDIT_COMPARE_FSGID_TO_OBJ_GID:
-		result = fmt.Sprintf(" -C fsgid%sobj_gid", operatorToSymbol(op))
-	case AUDIT_COMPARE_UID_TO_AUID:
-		result = fmt.Sprintf(" -C uid%sauid", operatorToSymbol(op))
-	case AUDIT_COMPARE_UID_TO_EUID:
-		result = fmt.Sprintf(" -C uid%seuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_UID_TO_FSUID:
-		result = fmt.Sprintf(" -C uid%sfsuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_UID_TO_SUID:
-		result = fmt.Sprintf(" -C uid%ssuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_AUID_TO_FSUID:
-		result = fmt.Sprintf(" -C auid%sfsuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_AUID_TO_SUID:
-		result = fmt.Sprintf(" -C auid%ssuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_AUID_TO_EUID:
-		result = fmt.Sprintf(" -C auid%seuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_EUID_TO_SUID:
-		result = fmt.Sprintf(" -C euid%ssuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_EUID_TO_FSUID:
-		result = fmt.Sprintf(" -C euid%sfsuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_SUID_TO_FSUID:
-		result = fmt.Sprintf(" -C suid%sfsuid", operatorToSymbol(op))
-	case AUDIT_COMPARE_GID_TO_EGID:
-		result = fmt.Sprintf(" -C gid%segid", operatorToSymbol(op))
-	case AUDIT_COMPARE_GID_TO_FSGID:
-		result = fmt.Sprintf(" -C gid%sfsgid", operatorToSymbol(op))
-	case AUDIT_COMPARE_GID_TO_SGID:
-		result = fmt.Sprintf(" -C gid%ssgid", operatorToSymbol(op))
-	case AUDIT_COMPARE_EGID_TO_FSGID:
-		result = fmt.Sprintf(" -C egid%sfsgid", operatorToSymbol(op))
-	case AUDIT_COMPARE_EGID_TO_SGID:
-		result = fmt.Sprintf(" -C egid%ssgid", operatorToSymbol(op))
-	case AUDIT_COMPARE_SGID_TO_FSGID:
-		result = fmt.Sprintf(" -C sgid%sfsgid", operatorToSymbol(op))
+	f, ok := fieldCompares[auditConstant(value)]
+	if !ok {
+		return ""
 	}
-
-	return result
+	return fmt.Sprintf(" -C %s%s%s", f[0], operatorToSymbol(op), f[1])
 }
 
 //keyMatch indicates whether or not rule should be printed or not
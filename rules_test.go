@@ -1,10 +1,16 @@
 package libaudit
 
 import (
+	"bytes"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"syscall"
 	"testing"
+
+	"github.com/lunixbochs/struc"
+	"github.com/pkg/errors"
 )
 
 var jsonRules = `
@@ -114,11 +120,11 @@ var jsonRules = `
 var expectedRules = []string{
 	"-w /etc/libaudit.conf -p wa -k audit",
 	"-w /etc/rsyslog.conf -p wa -k syslog",
-	"-a always,exit-F arch=b64 -S personality -F key=bypass",
-	"-a never,exit -F path=/bin/ls -F perm=x",
-	"-a always,exit-F arch=b64 -S execve -F key=exec",
+	"-a always,exit -F arch=b64 -S personality -F key=bypass",
+	"-a never,exit -S all -F path=/bin/ls -F perm=x",
+	"-a always,exit -F arch=b64 -S execve -F key=exec",
 	"-a always,exit -S clone,fork,vfork",
-	"-a always,exit-F arch=b64 -S rename,renameat -F auid>=1000 -F key=rename",
+	"-a always,exit -F arch=b64 -S rename,renameat -F auid>=1000 -F key=rename",
 }
 
 type testRulesNetlinkConn struct {
@@ -139,10 +145,20 @@ func (t *testRulesNetlinkConn) Receive(bytesize int, block int, rb []byte) ([]Ne
 	v = append(v, *m)
 	return v, nil
 }
+func (t *testRulesNetlinkConn) ReceiveNoParse(bytesize int, block int, rb []byte) ([]byte, error) {
+	m := newNetlinkAuditRequest(syscall.NLMSG_DONE, syscall.AF_NETLINK, 0)
+	m.Header.Seq = t.actualNetlinkMessage.Header.Seq
+	return m.ToWireFormat(nil), nil
+}
+
 func (t *testRulesNetlinkConn) GetPID() (int, error) {
 	return 0, nil
 }
 
+func (t *testRulesNetlinkConn) SetsockRecvTO(recvto int64) error {
+	return nil
+}
+
 type testListRulesNetlinkConn struct {
 	// we store the incoming NetlinkMessage to be checked later
 	actualNetlinkMessage NetlinkMessage
@@ -179,10 +195,20 @@ func (t *testListRulesNetlinkConn) Receive(bytesize int, block int, rb []byte) (
 	v = append(v, *m)
 	return v, nil
 }
+func (t *testListRulesNetlinkConn) ReceiveNoParse(bytesize int, block int, rb []byte) ([]byte, error) {
+	m := newNetlinkAuditRequest(syscall.NLMSG_DONE, syscall.AF_NETLINK, 0)
+	m.Header.Seq = t.actualNetlinkMessage.Header.Seq
+	return m.ToWireFormat(nil), nil
+}
+
 func (t *testListRulesNetlinkConn) GetPID() (int, error) {
 	return 0, nil
 }
 
+func (t *testListRulesNetlinkConn) SetsockRecvTO(recvto int64) error {
+	return nil
+}
+
 // test the rules functions using emulated socket
 func testRulesEmulated(t *testing.T) {
 	var n testRulesNetlinkConn
@@ -212,7 +238,7 @@ func testRulesEmulated(t *testing.T) {
         }
     ]
 }`
-	err = SetRules(&n, []byte(testRule))
+	_, err = SetRules(&n, []byte(testRule))
 	if err != nil {
 		t.Errorf("SetRules failed %v", err)
 	}
@@ -233,7 +259,7 @@ func testRulesEmulated(t *testing.T) {
 	}
 	// we emulate a list rule via Send() and push an actual rule in ListAllRules for which we test later
 	var v testListRulesNetlinkConn
-	ruleArray, err := ListAllRules(&v)
+	ruleArray, _, err := ListAllRules(&v)
 	if err != nil {
 		t.Errorf("ListAllRules failed %v", err)
 	}
@@ -268,7 +294,7 @@ func TestSetRules(t *testing.T) {
 		t.Errorf("rule deletion failed %v", err)
 	}
 
-	err = SetRules(s, []byte(jsonRules))
+	_, err = SetRules(s, []byte(jsonRules))
 	if err != nil {
 		t.Errorf("rule setting failed %v", err)
 	}
@@ -282,7 +308,7 @@ func TestSetRules(t *testing.T) {
 		t.Errorf("failed to avail netlink connection %v", err)
 	}
 
-	actualRules, err := ListAllRules(x)
+	actualRules, _, err := ListAllRules(x)
 	if err != nil {
 		t.Errorf("rule listing failed %v", err)
 	}
@@ -296,3 +322,689 @@ func TestSetRules(t *testing.T) {
 	}
 	x.Close()
 }
+
+func TestAuditSyscallToNumber(t *testing.T) {
+	for _, tt := range []struct {
+		arch     uint32
+		expected int
+	}{
+		{AUDIT_ARCH_I386, 11},
+		{AUDIT_ARCH_X86_64, 59},
+		{AUDIT_ARCH_AARCH64, 221},
+		{AUDIT_ARCH_S390X, 11},
+		{AUDIT_ARCH_PPC64LE, 11},
+		{AUDIT_ARCH_RISCV64, 221},
+	} {
+		nr, err := AuditSyscallToNumber("execve", tt.arch)
+		if err != nil {
+			t.Errorf("AuditSyscallToNumber failed %v", err)
+		}
+		if nr != tt.expected {
+			t.Errorf("AuditSyscallToNumber: expected execve to be %d on 0x%x, found %d", tt.expected, tt.arch, nr)
+		}
+		name, err := AuditArchSyscallToName(strconv.Itoa(tt.expected), tt.arch)
+		if err != nil || name != "execve" {
+			t.Errorf("AuditArchSyscallToName: expected execve, found %v (%v)", name, err)
+		}
+	}
+
+	// the syscalls of newer kernels
+	for name, expected := range map[string]int{"statx": 332, "io_uring_setup": 425, "clone3": 435, "close_range": 436} {
+		if nr, err := AuditSyscallToNumber(name, AUDIT_ARCH_X86_64); nr != expected {
+			t.Errorf("AuditSyscallToNumber: expected %v to be %d on x86_64, found %d (%v)", name, expected, nr, err)
+		}
+	}
+
+	// b32 rules must use the i386 table
+	r := AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"execve"},
+		Fields:   []AuditRuleField{{Name: "arch", Op: "=", Value: "b32"}},
+	}
+	data, err := r.toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if data.Mask[0] != 1<<11 || data.Values[0] != AUDIT_ARCH_I386 {
+		t.Errorf("toRuleData: expected b32 execve rule, found mask %x arch %x", data.Mask[0], data.Values[0])
+	}
+	if printed := printRule(data); printed != "-a always,exit -F arch=b32 -S execve" {
+		t.Errorf("printRule: unexpected b32 rule %v", printed)
+	}
+
+	// aarch64 rules use the arm64 table whatever the native arch
+	r.Syscalls = []string{"openat"}
+	r.Fields[0].Value = "aarch64"
+	if data, err = r.toRuleData(); err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if data.Mask[1] != 1<<(56-32) || data.Values[0] != AUDIT_ARCH_AARCH64 {
+		t.Errorf("toRuleData: expected aarch64 openat rule, found mask %x arch %x", data.Mask[:2], data.Values[0])
+	}
+	if printed := printRule(data); !strings.HasSuffix(printed, " -S openat") {
+		t.Errorf("printRule: unexpected aarch64 rule %v", printed)
+	}
+}
+
+func TestRegisterSyscallTable(t *testing.T) {
+	defer func() {
+		delete(syscallTables, AUDIT_ARCH_MIPSEL64)
+		delete(archNames, AUDIT_ARCH_MIPSEL64)
+	}()
+	toNumber := func(name string) int {
+		if name == "execve" {
+			return 57
+		}
+		return -1
+	}
+	toName := func(nr string) string {
+		if nr == "57" {
+			return "execve"
+		}
+		return "Unsupported"
+	}
+	if err := RegisterSyscallTable(AUDIT_ARCH_MIPSEL64, "", toNumber, toName); err == nil {
+		t.Errorf("RegisterSyscallTable: expected error for an unknown arch without name")
+	}
+	if err := RegisterSyscallTable(AUDIT_ARCH_MIPSEL64, "mips64el", toNumber, toName); err != nil {
+		t.Fatalf("RegisterSyscallTable failed %v", err)
+	}
+	if nr, err := AuditSyscallToNumber("execve", AUDIT_ARCH_MIPSEL64); err != nil || nr != 57 {
+		t.Errorf("AuditSyscallToNumber: expected 57, found %d (%v)", nr, err)
+	}
+	r := AuditRule{
+		Flags:    AUDIT_FILTER_EXIT,
+		Action:   AUDIT_ALWAYS,
+		Syscalls: []string{"execve"},
+		Fields:   []AuditRuleField{{Name: "arch", Op: "=", Value: "mips64el"}},
+	}
+	data, err := r.toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if data.Mask[1] != 1<<(57-32) || data.Values[0] != AUDIT_ARCH_MIPSEL64 {
+		t.Errorf("toRuleData: expected mips64el execve rule, found arch %x", data.Values[0])
+	}
+	if printed := printRule(data); printed != "-a always,exit -F arch=mips64el -S execve" {
+		t.Errorf("printRule: unexpected mips64el rule %v", printed)
+	}
+}
+
+func TestCountRules(t *testing.T) {
+	var rules [][]byte
+	for _, r := range []AuditRule{testRuleWatch, testRuleExec} {
+		data, err := r.toRuleData()
+		if err != nil {
+			t.Fatalf("toRuleData failed %v", err)
+		}
+		rules = append(rules, data.toWireFormat())
+	}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			var msgs []NetlinkMessage
+			for _, r := range rules {
+				msgs = append(msgs, testReplyMessage(request, uint16(AUDIT_LIST_RULES), r))
+			}
+			return append(msgs, testReplyMessage(request, syscall.NLMSG_DONE, nil))
+		},
+	}
+	count, err := CountRules(s)
+	if err != nil {
+		t.Fatalf("CountRules failed %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountRules: expected 2 rules, found %d", count)
+	}
+	if len(s.pending) != 0 {
+		t.Errorf("CountRules: replies left unread %v", s.pending)
+	}
+}
+
+func TestSetRulesValidation(t *testing.T) {
+	s := &testReplyNetlinkConn{}
+	for _, tt := range []struct {
+		rules    string
+		expected string
+	}{
+		{`{"syscall_rules": [{"syscalls": ["execve"], "actions": ["always", "exit"]}, {"syscalls": ["nosuchsyscall"], "actions": ["always", "exit"]}]}`, "syscall_rules[1]: syscall nosuchsyscall"},
+		{`{"syscall_rules": [{"fields": [{"name": "uid", "value": 0, "op": "equal"}], "syscalls": ["execve"], "actions": ["always", "exit"]}]}`, "syscall_rules[0]: field uid: ParseOperator failed: unknown operator equal"},
+		{`{"file_rules": [{"path": "/etc/passwd", "permission": "wz"}]}`, "file_rules[0]: permission"},
+		{`{"syscall_rules": [{"syscalls": ["execve"], "actions": ["possible", "exit"]}]}`, "syscall_rules[0]: use of possible action is deprecated"},
+		{`{"syscall_rules": [{"syscalls": ["execve"], "actions": ["always", "user"]}]}`, "syscall_rules[0]: syscalls can't be used with user filter"},
+		{`{"syscall_rules": [{"fields": [{"name": "perm", "value": 2, "op": "eq"}], "actions": ["always", "exit"]}]}`, "syscall_rules[0]: field perm"},
+	} {
+		_, err := SetRules(s, []byte(tt.rules))
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("SetRules: expected error containing %q, found %v", tt.expected, err)
+		}
+	}
+	// nothing is sent for invalid rule sets
+	if len(s.sent) != 0 {
+		t.Errorf("SetRules: expected no rules sent, found %d", len(s.sent))
+	}
+}
+
+func TestKeyLength(t *testing.T) {
+	rule := testRuleExec
+	rule.Fields = []AuditRuleField{{Name: "key", Op: "=", Value: strings.Repeat("k", AUDIT_MAX_KEY_LEN)}}
+	if _, err := rule.toRuleData(); err != nil {
+		t.Errorf("toRuleData: unexpected error for key of %d bytes %v", AUDIT_MAX_KEY_LEN, err)
+	}
+	rule.Fields = []AuditRuleField{{Name: "key", Op: "=", Value: strings.Repeat("k", AUDIT_MAX_KEY_LEN+1)}}
+	if _, err := rule.toRuleData(); errors.Cause(err) != ErrKeyTooLong {
+		t.Errorf("toRuleData: expected ErrKeyTooLong, found %v", err)
+	}
+	if errs := ValidateRules([]AuditRule{rule}); len(errs) != 1 || errors.Cause(errs[0]) != ErrKeyTooLong {
+		t.Errorf("ValidateRules: expected ErrKeyTooLong, found %v", errs)
+	}
+
+	_, err := SetRules(&testReplyNetlinkConn{}, []byte(`{"file_rules": [{"path": "/`+strings.Repeat("p", PATH_MAX)+`"}]}`))
+	if errors.Cause(err) != ErrPathTooLong {
+		t.Errorf("SetRules: expected ErrPathTooLong, found %v", err)
+	}
+}
+
+func TestFieldOperators(t *testing.T) {
+	for _, tt := range []struct {
+		field AuditRuleField
+		valid bool
+	}{
+		{AuditRuleField{Name: "a1", Op: "&", Value: "0x40"}, true},
+		{AuditRuleField{Name: "a1", Op: "&=", Value: "0x40"}, true},
+		{AuditRuleField{Name: "auid", Op: "gt_or_eq", Value: "1000"}, true},
+		{AuditRuleField{Name: "auid", Op: "&", Value: "1000"}, false},
+		{AuditRuleField{Name: "exit", Op: "<", Value: "0"}, true},
+		{AuditRuleField{Name: "exit", Op: "&=", Value: "1"}, false},
+		{AuditRuleField{Name: "arch", Op: ">", Value: "b64"}, false},
+		{AuditRuleField{Name: "key", Op: ">=", Value: "exec"}, false},
+		{AuditRuleField{Name: "uid", Op: "=<", Value: "0"}, false},
+		{AuditRuleField{Name: "devminor", Op: "&", Value: "1"}, true},
+		{AuditRuleField{Name: "devminor", Op: "&=", Value: "1"}, true},
+		{AuditRuleField{Name: "devmajor", Op: "&", Value: "1"}, false},
+		{AuditRuleField{Name: "subj_sen", Op: ">=", Value: "s0"}, true},
+		{AuditRuleField{Name: "subj_clr", Op: "<=", Value: "s0:c0.c1023"}, true},
+		{AuditRuleField{Name: "obj_lev_low", Op: ">", Value: "s0"}, true},
+		{AuditRuleField{Name: "obj_lev_high", Op: "<", Value: "s15"}, true},
+		{AuditRuleField{Name: "subj_sen", Op: "&", Value: "s0"}, false},
+		{AuditRuleField{Name: "subj_user", Op: ">", Value: "root"}, false},
+		{AuditRuleField{Name: "sessionid", Op: "!=", Value: "unset"}, true},
+		{AuditRuleField{Name: "sessionid", Op: ">", Value: "3"}, true},
+		{AuditRuleField{Name: "sessionid", Op: "&", Value: "3"}, false},
+	} {
+		rule := AuditRule{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Syscalls: []string{"openat"}, Fields: []AuditRuleField{tt.field}}
+		_, err := rule.toRuleData()
+		if tt.valid && err != nil {
+			t.Errorf("toRuleData: unexpected error for %v %v", tt.field, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("toRuleData: expected error for %v", tt.field)
+		}
+	}
+
+	// the operator bits end up in the field flags
+	rule := AuditRule{Flags: AUDIT_FILTER_EXIT, Action: AUDIT_ALWAYS, Syscalls: []string{"openat"}, Fields: []AuditRuleField{{Name: "a1", Op: "&", Value: "0x40"}}}
+	data, err := rule.toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if data.Fieldflags[0] != AUDIT_BIT_MASK || data.Values[0] != 0x40 {
+		t.Errorf("toRuleData: expected bit mask 0x40, found 0x%x 0x%x", data.Fieldflags[0], data.Values[0])
+	}
+}
+
+func TestAddDirWatch(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	if err := AddDirWatch(s, "/etc/", "wa", "etcwatch"); err != nil {
+		t.Fatalf("AddDirWatch failed %v", err)
+	}
+	if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_ADD_RULE) {
+		t.Fatalf("AddDirWatch: expected one AUDIT_ADD_RULE request, found %v", s.sent)
+	}
+	rule := testUnpackRule(t, s.sent[0].Data)
+	if rule.Fields[0] != AUDIT_DIR || string(rule.Buf[:rule.Values[0]]) != "/etc" {
+		t.Errorf("AddDirWatch: expected dir field /etc, found %+v", rule)
+	}
+	if printed := printRule(rule); printed != "-w /etc -p wa -k etcwatch" {
+		t.Errorf("AddDirWatch: unexpected rule %v", printed)
+	}
+
+	for _, tt := range []struct{ dir, perms string }{
+		{"etc", "wa"},
+		{"", "wa"},
+		{"/etc", "wz"},
+	} {
+		if err := AddDirWatch(s, tt.dir, tt.perms, ""); err == nil {
+			t.Errorf("AddDirWatch: expected error for %v %v", tt.dir, tt.perms)
+		}
+	}
+	if len(s.sent) != 1 {
+		t.Errorf("AddDirWatch: invalid watches were sent")
+	}
+}
+
+// testUnpackRule decodes the rule of an AUDIT_ADD_RULE request
+func testUnpackRule(t *testing.T, data []byte) *AuditRuleData {
+	var rule AuditRuleData
+	if err := struc.UnpackWithOptions(bytes.NewBuffer(data), &rule, &struc.Options{Order: nativeEndian()}); err != nil {
+		t.Fatalf("rule unpacking failed %v", err)
+	}
+	return &rule
+}
+
+func TestAddWatch(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	if err := AddWatch(s, "/etc/passwd", "wa", "identity"); err != nil {
+		t.Fatalf("AddWatch failed %v", err)
+	}
+	if err := AddWatch(s, "/etc/", "", ""); err != nil {
+		t.Fatalf("AddWatch failed %v", err)
+	}
+	if len(s.sent) != 2 {
+		t.Fatalf("AddWatch: expected two AUDIT_ADD_RULE requests, found %v", s.sent)
+	}
+	for i, expected := range []string{"-w /etc/passwd -p wa -k identity", "-w /etc -p rwxa"} {
+		rule := testUnpackRule(t, s.sent[i].Data)
+		if printed := printRule(rule); printed != expected {
+			t.Errorf("AddWatch: expected rule %v, found %v", expected, printed)
+		}
+	}
+	if rule := testUnpackRule(t, s.sent[1].Data); rule.Fields[0] != AUDIT_DIR {
+		t.Errorf("AddWatch: expected the directory tree to be watched, found field %d", rule.Fields[0])
+	}
+
+	for _, tt := range []struct{ path, perms string }{
+		{"etc/passwd", "wa"},
+		{"", "wa"},
+		{"/etc/passwd", "wz"},
+	} {
+		if err := AddWatch(s, tt.path, tt.perms, ""); err == nil {
+			t.Errorf("AddWatch: expected error for %v %v", tt.path, tt.perms)
+		}
+	}
+	if len(s.sent) != 2 {
+		t.Errorf("AddWatch: invalid watches were sent")
+	}
+
+	// the kernel returns ENOENT when the parent directory of the watch doesn't exist
+	s = &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, int32(syscall.ENOENT))}
+		},
+	}
+	err := AddWatch(s, "/nosuchdir/file", "wa", "")
+	if err == nil || errors.Cause(err) != syscall.ENOENT {
+		t.Errorf("AddWatch: expected ENOENT, found %v", err)
+	}
+}
+
+func TestAuditTrimMakeEquiv(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	if err := AuditTrimTrees(s); err != nil {
+		t.Fatalf("AuditTrimTrees failed %v", err)
+	}
+	if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_TRIM) || len(s.sent[0].Data) != 0 {
+		t.Fatalf("AuditTrimTrees: expected one empty AUDIT_TRIM request, found %v", s.sent)
+	}
+
+	if err := AuditMakeEquiv(s, "/srv", "/mnt/srv"); err != nil {
+		t.Fatalf("AuditMakeEquiv failed %v", err)
+	}
+	data := s.sent[1].Data
+	if s.sent[1].Header.Type != uint16(AUDIT_MAKE_EQUIV) || len(data) != 8+4+8 ||
+		nativeEndian().Uint32(data[0:4]) != 4 || nativeEndian().Uint32(data[4:8]) != 8 || string(data[8:]) != "/srv/mnt/srv" {
+		t.Errorf("AuditMakeEquiv: unexpected request %+v", s.sent[1])
+	}
+	for _, paths := range [][2]string{{"srv", "/mnt/srv"}, {"/srv", ""}} {
+		if err := AuditMakeEquiv(s, paths[0], paths[1]); err == nil {
+			t.Errorf("AuditMakeEquiv: expected error for %q", paths)
+		}
+	}
+	if len(s.sent) != 2 {
+		t.Errorf("AuditMakeEquiv: invalid paths were sent")
+	}
+}
+
+func TestExcludeRulesJSON(t *testing.T) {
+	_, printed, err := MarshalRules([]byte(`{
+	"exclude_rules": [
+		{"msgtype": "CWD"},
+		{"msgtype": 2404},
+		{"fields": [{"name": "msgtype", "value": "EOE", "op": "eq"}, {"name": "auid", "value": "unset", "op": "eq"}]}
+	]
+}`))
+	if err != nil {
+		t.Fatalf("MarshalRules failed %v", err)
+	}
+	expected := []string{
+		"-a always,exclude -F msgtype=CWD",
+		"-a always,exclude -F msgtype=CRYPTO_KEY_USER",
+		"-a always,exclude -F msgtype=EOE -F auid=-1",
+	}
+	if !reflect.DeepEqual(printed, expected) {
+		t.Errorf("MarshalRules: expected %v, found %v", expected, printed)
+	}
+
+	for _, rule := range []string{
+		`{}`,
+		`{"msgtype": "NO_SUCH_TYPE"}`,
+		`{"msgtype": "CWD", "syscalls": ["open"]}`,
+		`{"fields": [{"name": "path", "value": "/etc", "op": "eq"}]}`,
+	} {
+		if _, _, err := MarshalRules([]byte(`{"exclude_rules": [` + rule + `]}`)); err == nil {
+			t.Errorf("MarshalRules: expected error for exclude rule %v", rule)
+		}
+	}
+}
+
+func TestEncodeDecodePerm(t *testing.T) {
+	for _, tt := range []struct {
+		perms    string
+		mask     int
+		expected string
+	}{
+		{"r", AUDIT_PERM_READ, "r"},
+		{"wa", AUDIT_PERM_WRITE | AUDIT_PERM_ATTR, "wa"},
+		{"XWR", AUDIT_PERM_READ | AUDIT_PERM_WRITE | AUDIT_PERM_EXEC, "rwx"},
+		{"arwx", 0x0F, "rwxa"},
+	} {
+		mask, err := EncodePerm(tt.perms)
+		if err != nil || mask != tt.mask {
+			t.Errorf("EncodePerm: %q: expected %d, found %d %v", tt.perms, tt.mask, mask, err)
+		}
+		if perms := DecodePerm(mask); perms != tt.expected {
+			t.Errorf("DecodePerm: %d: expected %q, found %q", mask, tt.expected, perms)
+		}
+	}
+	for _, perms := range []string{"", "rwz", "rwxar"} {
+		if _, err := EncodePerm(perms); err == nil {
+			t.Errorf("EncodePerm: expected error for %q", perms)
+		}
+	}
+}
+
+func TestAddExcludeRule(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	for _, tt := range []struct {
+		field, op, value string
+		printed          string
+	}{
+		{"msgtype", "=", "CWD", "-a always,exclude -F msgtype=CWD"},
+		{"pid", "=", "1234", "-a always,exclude -F pid=1234"},
+		{"auid", "!=", "1000", "-a always,exclude -F auid!=1000"},
+		{"msgtype", "=", "1307", "-a always,exclude -F msgtype=CWD"},
+		{"msgtype", "!=", "crypto_key_user", "-a always,exclude -F msgtype!=CRYPTO_KEY_USER"},
+	} {
+		s.sent = nil
+		if err := AddExcludeRule(s, tt.field, tt.op, tt.value); err != nil {
+			t.Fatalf("AddExcludeRule failed %v", err)
+		}
+		if len(s.sent) != 1 || s.sent[0].Header.Type != uint16(AUDIT_ADD_RULE) {
+			t.Fatalf("AddExcludeRule: expected one AUDIT_ADD_RULE request, found %v", s.sent)
+		}
+		rule := testUnpackRule(t, s.sent[0].Data)
+		if printed := printRule(rule); rule.Flags != AUDIT_FILTER_EXCLUDE || printed != tt.printed {
+			t.Errorf("AddExcludeRule: expected %q, found %q", tt.printed, printed)
+		}
+	}
+
+	s.sent = nil
+	for _, tt := range []struct{ field, value string }{
+		{"path", "/etc"},
+		{"key", "noisy"},
+		{"unknown", "1"},
+		{"msgtype", "NO_SUCH_TYPE"},
+	} {
+		if err := AddExcludeRule(s, tt.field, "=", tt.value); err == nil {
+			t.Errorf("AddExcludeRule: expected error for %v=%v", tt.field, tt.value)
+		}
+	}
+	if len(s.sent) != 0 {
+		t.Errorf("AddExcludeRule: invalid rules were sent")
+	}
+}
+
+func TestMarshalRules(t *testing.T) {
+	payloads, printed, err := MarshalRules([]byte(jsonRules))
+	if err != nil {
+		t.Fatalf("MarshalRules failed %v", err)
+	}
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	rules, err := SetRules(s, []byte(jsonRules))
+	if err != nil {
+		t.Fatalf("SetRules failed %v", err)
+	}
+	if len(payloads) != len(s.sent) || len(printed) != len(rules) {
+		t.Fatalf("MarshalRules: expected %d rules, found %d", len(s.sent), len(payloads))
+	}
+	for i := range payloads {
+		if !bytes.Equal(s.sent[i].Data[:len(payloads[i])], payloads[i]) {
+			t.Errorf("MarshalRules: payload %d differs from the request sent by SetRules", i)
+		}
+		if printed[i] != printRule(rules[i]) {
+			t.Errorf("MarshalRules: expected %q, found %q", printRule(rules[i]), printed[i])
+		}
+	}
+	if printed[0] != "-w /etc/libaudit.conf -p wa -k audit" {
+		t.Errorf("MarshalRules: unexpected rule %q", printed[0])
+	}
+	if _, _, err := MarshalRules([]byte(`{"file_rules": [{"path": "/etc/passwd", "permission": "wz"}]}`)); err == nil {
+		t.Errorf("MarshalRules: expected error for an invalid rule set")
+	}
+}
+
+func TestSetRulesExpandArch(t *testing.T) {
+	if _, arch := NativeArch(); arch != AUDIT_ARCH_X86_64 {
+		t.Skipf("skipping arch expansion test: native arch 0x%x", arch)
+	}
+	for _, tt := range []struct {
+		rules    string
+		expected []string
+	}{
+		{
+			`{"syscall_rules": [
+				{"key": "net", "syscalls": ["connect", "statx"], "actions": ["exit", "always"]},
+				{"key": "stat", "syscalls": ["newfstatat"], "actions": ["always", "exit"]},
+				{"key": "pinned", "fields": [{"name": "arch", "value": "b64", "op": "eq"}], "syscalls": ["open"], "actions": ["exit", "always"]},
+				{"fields": [{"name": "uid", "value": 0, "op": "eq"}], "actions": ["user", "always"]}
+			]}`,
+			[]string{
+				"-a always,exit -F arch=b64 -S connect,statx -F key=net",
+				"-a always,exit -F arch=b32 -S connect,statx -F key=net",
+				"-a always,exit -F arch=b64 -S newfstatat -F key=stat",
+				"-a always,exit -F arch=b64 -S open -F key=pinned",
+				"-a always,user -F uid=0",
+			},
+		},
+		{
+			"-a always,exit -S execve -F euid=0 -k exec\n-a always,exit -S all -k all\n",
+			[]string{
+				"-a always,exit -F arch=b64 -S execve -F euid=0 -F key=exec",
+				"-a always,exit -F arch=b32 -S execve -F euid=0 -F key=exec",
+				"-a always,exit -S all -F key=all",
+			},
+		},
+	} {
+		s := &testReplyNetlinkConn{
+			reply: func(request NetlinkMessage) []NetlinkMessage {
+				return []NetlinkMessage{testAckMessage(request, 0)}
+			},
+		}
+		if _, err := SetRulesWithOptions(s, []byte(tt.rules), SetRulesOptions{ExpandArch: true}); err != nil {
+			t.Fatalf("SetRulesWithOptions failed %v", err)
+		}
+		var printed []string
+		for _, m := range s.sent {
+			printed = append(printed, printRule(testUnpackRule(t, m.Data)))
+		}
+		if !reflect.DeepEqual(printed, tt.expected) {
+			t.Errorf("SetRulesWithOptions: expected rules\n%v\nfound\n%v", strings.Join(tt.expected, "\n"), strings.Join(printed, "\n"))
+		}
+	}
+
+	// syscalls unknown on both archs are still reported, those only the compat arch has need its arch field
+	for _, tt := range []struct {
+		rules, syscall string
+	}{
+		{`{"syscall_rules": [{"syscalls": ["nosuchsyscall"], "actions": ["exit", "always"]}]}`, "nosuchsyscall"},
+		{`{"syscall_rules": [{"syscalls": ["connect", "socketcall"], "actions": ["exit", "always"]}]}`, "socketcall"},
+		{"-a always,exit -S socketcall -k net\n", "socketcall"},
+	} {
+		s := &testReplyNetlinkConn{}
+		_, err := SetRulesWithOptions(s, []byte(tt.rules), SetRulesOptions{ExpandArch: true})
+		if err == nil || !strings.Contains(err.Error(), tt.syscall) || len(s.sent) != 0 {
+			t.Errorf("SetRulesWithOptions: expected error for syscall %v, found %v", tt.syscall, err)
+		}
+	}
+}
+
+func TestJSONRuleKeys(t *testing.T) {
+	_, printed, err := MarshalRules([]byte(`{
+		"file_rules": [{"path": "/etc/shadow", "permission": "r", "key": ["identity", "secrets"]}],
+		"syscall_rules": [{"key": ["exec", "root"], "fields": [{"name": "euid", "value": 0, "op": "eq"}], "syscalls": ["execve"], "actions": ["exit", "always"]}]
+	}`))
+	if err != nil {
+		t.Fatalf("MarshalRules failed %v", err)
+	}
+	expected := []string{
+		"-w /etc/shadow -p r -k identity -k secrets",
+		"-a always,exit -S execve -F euid=0 -F key=exec -F key=root",
+	}
+	if !reflect.DeepEqual(printed, expected) {
+		t.Errorf("MarshalRules: expected %q, found %q", expected, printed)
+	}
+	if _, _, err := MarshalRules([]byte(`{"file_rules": [{"path": "/etc/shadow", "key": ["identity", 1]}]}`)); err == nil {
+		t.Errorf("MarshalRules: expected error for a key which is not a string")
+	}
+}
+
+func TestSessionIDField(t *testing.T) {
+	_, printed, err := MarshalRules([]byte(`{"syscall_rules": [
+		{"key": "ses", "fields": [{"name": "sessionid", "value": 3, "op": "eq"}], "syscalls": ["execve"], "actions": ["exit", "always"]},
+		{"fields": [{"name": "sessionid", "value": "unset", "op": "nt_eq"}], "syscalls": ["execve"], "actions": ["exit", "always"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("MarshalRules failed %v", err)
+	}
+	expected := []string{
+		"-a always,exit -S execve -F sessionid=3 -F key=ses",
+		"-a always,exit -S execve -F sessionid!=-1",
+	}
+	if !reflect.DeepEqual(printed, expected) {
+		t.Errorf("MarshalRules: expected %q, found %q", expected, printed)
+	}
+
+	rf, err := ParseAuditctlRules([]byte("-a always,exit -F arch=b64 -S execve -F sessionid!=-1\n"))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed %v", err)
+	}
+	data, err := rf.Rules[0].toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	if data.Fields[1] != AUDIT_SESSIONID || data.Fieldflags[1] != AUDIT_NOT_EQUAL || data.Values[1] != 4294967295 {
+		t.Errorf("toRuleData: unexpected sessionid field %v %v %v", data.Fields[:2], data.Fieldflags[:2], data.Values[:2])
+	}
+	if _, _, err := MarshalRules([]byte(`{"syscall_rules": [{"fields": [{"name": "sessionid", "value": "mine", "op": "eq"}], "syscalls": ["execve"], "actions": ["exit", "always"]}]}`)); err == nil {
+		t.Errorf("MarshalRules: expected error for a sessionid which is not a number")
+	}
+}
+
+func TestExeField(t *testing.T) {
+	_, printed, err := MarshalRules([]byte(`{"syscall_rules": [
+		{"key": "curl", "fields": [{"name": "exe", "value": "/usr/bin/curl", "op": "eq"}], "syscalls": ["connect"], "actions": ["exit", "always"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("MarshalRules failed %v", err)
+	}
+	if expected := []string{"-a always,exit -S connect -F exe=/usr/bin/curl -F key=curl"}; !reflect.DeepEqual(printed, expected) {
+		t.Errorf("MarshalRules: expected %q, found %q", expected, printed)
+	}
+
+	rf, err := ParseAuditctlRules([]byte("-a always,exit -F arch=b64 -S execve -F exe=/usr/bin/sudo -k sudo\n"))
+	if err != nil {
+		t.Fatalf("ParseAuditctlRules failed %v", err)
+	}
+	data, err := rf.Rules[0].toRuleData()
+	if err != nil {
+		t.Fatalf("toRuleData failed %v", err)
+	}
+	// the path is carried in the buffer ahead of the key
+	if data.Fields[1] != AUDIT_EXE || data.Values[1] != uint32(len("/usr/bin/sudo")) ||
+		data.Buflen != uint32(len("/usr/bin/sudosudo")) || string(data.Buf) != "/usr/bin/sudosudo" {
+		t.Errorf("toRuleData: unexpected exe field %v %v buffer %q", data.Fields[:3], data.Values[:3], data.Buf)
+	}
+	if parsed := NewAuditRule(data); !parsed.Equal(rf.Rules[0]) || parsed.Fields[1].Value != "/usr/bin/sudo" {
+		t.Errorf("NewAuditRule: expected %v, found %v", rf.Rules[0], parsed)
+	}
+
+	for _, line := range []string{
+		"-a always,exit -S execve -F exe=sudo",
+		"-a always,exit -S execve -F exe=/usr/bin/",
+		"-a always,exit -S execve -F exe>/usr/bin/sudo",
+		"-a always,user -F exe=/usr/bin/sudo",
+	} {
+		if _, err := ParseAuditctlRules([]byte(line)); err == nil {
+			t.Errorf("ParseAuditctlRules: expected error for %q", line)
+		}
+	}
+}
+
+func TestJSONRuleAllSyscalls(t *testing.T) {
+	rules, err := parseJSONRules([]byte(`{"syscall_rules": [
+		{"fields": [{"name": "auid", "value": 1000, "op": "eq"}], "actions": ["exit", "always"]},
+		{"fields": [{"name": "auid", "value": 1000, "op": "eq"}], "actions": ["user", "always"]}
+	]}`), false)
+	if err != nil {
+		t.Fatalf("parseJSONRules failed %v", err)
+	}
+	// exit rules without syscalls apply to all of them like auditctl without -S
+	for i, word := range rules[0].data.Mask {
+		expected := uint32(0xFFFFFFFF)
+		if i == AUDIT_BITMASK_SIZE-1 {
+			expected = 0
+		}
+		if word != expected {
+			t.Errorf("parseJSONRules: expected mask word %d %#x, found %#x", i, expected, word)
+		}
+	}
+	if rules[1].data.Mask != ([AUDIT_BITMASK_SIZE]uint32{}) {
+		t.Errorf("parseJSONRules: expected no syscalls for the user filter, found %v", rules[1].data.Mask)
+	}
+}
+
+func TestJSONRulePrepend(t *testing.T) {
+	payloads, printed, err := MarshalRules([]byte(`{"syscall_rules": [
+		{"syscalls": ["execve"], "actions": ["exit", "always"], "prepend": true}
+	]}`))
+	if err != nil {
+		t.Fatalf("MarshalRules failed %v", err)
+	}
+	rule := testUnpackRule(t, payloads[0])
+	if rule.Flags != AUDIT_FILTER_EXIT|AUDIT_FILTER_PREPEND {
+		t.Errorf("MarshalRules: expected the prepend flag, found flags %#x", rule.Flags)
+	}
+	if expected := []string{"-a always,exit -S execve"}; !reflect.DeepEqual(printed, expected) {
+		t.Errorf("MarshalRules: expected %q, found %q", expected, printed)
+	}
+}
@@ -0,0 +1,167 @@
+package libaudit
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/lacework/libaudit-go/headers"
+	"github.com/pkg/errors"
+)
+
+// SockAddr is the socket address of a SOCKADDR record, the address a process connected, bound or sent to.
+type SockAddr struct {
+	Family     int    // address family, syscall.AF_INET, AF_INET6, AF_UNIX, AF_NETLINK ...
+	FamilyName string // name of the family as printed by auditd (inet, inet6, local, netlink ...), empty if unknown
+	IP         net.IP // address of inet and inet6 sockets
+	Port       int    // port of inet and inet6 sockets
+	Path       string // path of unix sockets, the names of abstract sockets start with @
+	PID        uint32 // port id of netlink sockets, 0 for the kernel
+	Groups     uint32 // multicast groups of netlink sockets
+}
+
+// String returns the address as the interpretation of the saddr field prints it (i.e. inet host:10.0.0.1 serv:22),
+// families without address are printed by name.
+func (a *SockAddr) String() string {
+	if a.FamilyName == "" {
+		return "unknown family (" + strconv.Itoa(a.Family) + ")"
+	}
+	switch a.Family {
+	case syscall.AF_UNIX:
+		return a.FamilyName + " " + a.Path
+	case syscall.AF_INET, syscall.AF_INET6:
+		return a.FamilyName + " host:" + a.IP.String() + " serv:" + strconv.Itoa(a.Port)
+	case syscall.AF_NETLINK:
+		return a.FamilyName + " pid:" + strconv.FormatUint(uint64(a.PID), 10)
+	}
+	return a.FamilyName
+}
+
+// SockAddr returns the socket address of a SOCKADDR record, the saddr field is decoded whether the record was
+// interpreted or not. The groups of netlink addresses are only known for records which are not interpreted.
+func (e *AuditEvent) SockAddr() (*SockAddr, error) {
+	if e.Type != "SOCKADDR" {
+		return nil, fmt.Errorf("SockAddr failed: event type is %v, expected SOCKADDR", e.Type)
+	}
+	value, ok := e.Data["saddr"]
+	if !ok {
+		return nil, fmt.Errorf("SockAddr failed: no saddr field")
+	}
+	var (
+		a   *SockAddr
+		err error
+	)
+	if _, herr := hex.DecodeString(value); herr == nil {
+		a, err = decodeSockAddr(value)
+	} else {
+		a, err = parseSockAddr(value)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "SockAddr failed")
+	}
+	return a, nil
+}
+
+// decodeSockAddr decodes the hex encoded struct sockaddr of a saddr field, the family is in host byte
+// order while ports and addresses are in network byte order
+func decodeSockAddr(value string) (*SockAddr, error) {
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "sockaddr parsing failed")
+	}
+	if len(b) < 2 {
+		return nil, fmt.Errorf("sockaddr too short")
+	}
+	a := &SockAddr{Family: int(nativeEndian().Uint16(b[0:2]))}
+	a.FamilyName = headers.SocketFamLookup[a.Family]
+	short := fmt.Errorf("%v sockaddr too short", a.FamilyName)
+
+	switch a.Family {
+	case syscall.AF_UNIX:
+		path := b[2:]
+		if len(path) > 0 && path[0] == 0 {
+			// abstract socket, the name is not NUL terminated
+			a.Path = "@" + strings.TrimRight(string(path[1:]), "\x00")
+		} else if i := strings.IndexByte(string(path), 0); i >= 0 {
+			a.Path = string(path[:i])
+		} else {
+			a.Path = string(path)
+		}
+	case syscall.AF_INET:
+		if len(b) < 8 {
+			return nil, short
+		}
+		a.Port = int(binary.BigEndian.Uint16(b[2:4]))
+		a.IP = net.IP(append([]byte(nil), b[4:8]...))
+	case syscall.AF_INET6:
+		if len(b) < 24 {
+			return nil, short
+		}
+		a.Port = int(binary.BigEndian.Uint16(b[2:4]))
+		a.IP = net.IP(append([]byte(nil), b[8:24]...))
+	case syscall.AF_NETLINK:
+		if len(b) < 12 {
+			return nil, short
+		}
+		a.PID = nativeEndian().Uint32(b[4:8])
+		a.Groups = nativeEndian().Uint32(b[8:12])
+	}
+	return a, nil
+}
+
+// parseSockAddr parses an interpreted saddr field, see (*SockAddr).String
+func parseSockAddr(value string) (*SockAddr, error) {
+	var family int
+	if strings.HasPrefix(value, "unknown family (") {
+		if _, err := fmt.Sscanf(value, "unknown family (%d)", &family); err != nil {
+			return nil, errors.Wrap(err, "sockaddr parsing failed")
+		}
+		return &SockAddr{Family: family}, nil
+	}
+	name, rest := value, ""
+	if i := strings.IndexByte(value, ' '); i >= 0 {
+		name, rest = value[:i], value[i+1:]
+	}
+	found := false
+	for f, n := range headers.SocketFamLookup {
+		if n == name {
+			family, found = f, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("sockaddr parsing failed: unknown family %q", name)
+	}
+	a := &SockAddr{Family: family, FamilyName: name}
+	switch family {
+	case syscall.AF_UNIX:
+		a.Path = rest
+	case syscall.AF_INET, syscall.AF_INET6:
+		i := strings.LastIndex(rest, " serv:")
+		if !strings.HasPrefix(rest, "host:") || i < 0 {
+			return nil, fmt.Errorf("sockaddr parsing failed: unexpected %v address %q", name, rest)
+		}
+		if a.IP = net.ParseIP(rest[len("host:"):i]); a.IP == nil {
+			return nil, fmt.Errorf("sockaddr parsing failed: invalid host %q", rest[len("host:"):i])
+		}
+		port, err := strconv.Atoi(rest[i+len(" serv:"):])
+		if err != nil {
+			return nil, errors.Wrap(err, "sockaddr parsing failed")
+		}
+		a.Port = port
+	case syscall.AF_NETLINK:
+		pid, err := strconv.ParseUint(strings.TrimPrefix(rest, "pid:"), 10, 32)
+		if err != nil {
+			return nil, errors.Wrap(err, "sockaddr parsing failed")
+		}
+		a.PID = uint32(pid)
+	}
+	if ip4 := a.IP.To4(); family == syscall.AF_INET && ip4 != nil {
+		a.IP = ip4
+	}
+	return a, nil
+}
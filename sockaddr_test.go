@@ -0,0 +1,63 @@
+package libaudit
+
+import (
+	"net"
+	"syscall"
+	"testing"
+)
+
+func TestSockAddr(t *testing.T) {
+	tests := []struct {
+		saddr    string
+		expected SockAddr
+		printed  string
+	}{
+		{"02000050C0A801010000000000000000", SockAddr{Family: syscall.AF_INET, FamilyName: "inet", IP: net.IPv4(192, 168, 1, 1).To4(), Port: 80},
+			"inet host:192.168.1.1 serv:80"},
+		{"0A0001BB000000000000000000000000000000000000000100000000", SockAddr{Family: syscall.AF_INET6, FamilyName: "inet6", IP: net.IPv6loopback, Port: 443},
+			"inet6 host:::1 serv:443"},
+		{"01002F7661722F72756E2F6E7363642F736F636B657400", SockAddr{Family: syscall.AF_UNIX, FamilyName: "local", Path: "/var/run/nscd/socket"},
+			"local /var/run/nscd/socket"},
+		{"0100006162630000", SockAddr{Family: syscall.AF_UNIX, FamilyName: "local", Path: "@abc"},
+			"local @abc"},
+		{"100000000000000001000000", SockAddr{Family: syscall.AF_NETLINK, FamilyName: "netlink", Groups: 1},
+			"netlink pid:0"},
+		{"FFFF", SockAddr{Family: 0xffff}, "unknown family (65535)"},
+	}
+	for _, tt := range tests {
+		for _, interpret := range []bool{false, true} {
+			x, err := ParseAuditEvent(`audit(1464163771.720:020): saddr=`+tt.saddr, AUDIT_SOCKADDR, interpret)
+			if err != nil {
+				t.Fatalf("parse failed %v", err)
+			}
+			if interpret && x.Data["saddr"] != tt.printed {
+				t.Errorf("interpret: expected saddr %q, found %q", tt.printed, x.Data["saddr"])
+			}
+			a, err := x.SockAddr()
+			if err != nil {
+				t.Fatalf("SockAddr failed %v for %v", err, x.Data["saddr"])
+			}
+			expected := tt.expected
+			if interpret {
+				expected.Groups = 0
+			}
+			if a.Family != expected.Family || a.FamilyName != expected.FamilyName || !a.IP.Equal(expected.IP) || a.Port != expected.Port ||
+				a.Path != expected.Path || a.PID != expected.PID || a.Groups != expected.Groups || a.String() != tt.printed {
+				t.Errorf("SockAddr (interpret %v): expected %+v, found %+v", interpret, expected, *a)
+			}
+		}
+	}
+
+	for _, saddr := range []string{"0200", "0A000050"} {
+		if _, err := ParseAuditEvent(`audit(1464163771.720:021): saddr=`+saddr, AUDIT_SOCKADDR, true); err == nil {
+			t.Errorf("interpret: expected error for a short sockaddr %v", saddr)
+		}
+	}
+	x, err := ParseAuditEvent(`audit(1464163771.720:022): arch=c000003e syscall=42 success=yes exit=0`, AUDIT_SYSCALL, false)
+	if err != nil {
+		t.Fatalf("parse failed %v", err)
+	}
+	if _, err := x.SockAddr(); err == nil {
+		t.Errorf("SockAddr: expected error for SYSCALL event")
+	}
+}
@@ -0,0 +1,44 @@
+package libaudit
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// StreamAuditEvents is the channel counterpart of GetAuditMessagesCtx, it receives audit events in a
+// go-routine and sends them on the returned event channel, the errors the callback would have received
+// are sent on the error channel (errors belonging to an event, i.e. ParseWarnings, are sent after the event).
+// buffer is the capacity of both channels. Consumers have to drain both channels until they are closed,
+// which happens once ctx is done or the connection is closed, an error setting up the receive loop is
+// the last error sent. The receive loop waits for the consumer, it doesn't drop events.
+func StreamAuditEvents(ctx context.Context, s Netlink, buffer int) (<-chan *AuditEvent, <-chan error) {
+	events := make(chan *AuditEvent, buffer)
+	errs := make(chan error, buffer)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		err := GetAuditMessagesCtx(ctx, s, func(e *AuditEvent, err error, _ ...interface{}) {
+			if e != nil {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+				}
+			}
+		})
+		if err != nil && errors.Cause(err) != ctx.Err() {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return events, errs
+}
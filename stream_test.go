@@ -0,0 +1,55 @@
+package libaudit
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestStreamAuditEvents(t *testing.T) {
+	s := &testReplyNetlinkConn{
+		pending: []NetlinkMessage{
+			testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:020): arch=c000003e syscall=2 success=yes exit=3`),
+			testEventMessage(0xffff, `audit(1464163771.720:021): op=unknown`),
+			testEventMessage(AUDIT_SYSCALL, `audit(1464163771.720:022): arch=c000003e syscall=2 success=yes exit=3`),
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errs := StreamAuditEvents(ctx, s, 0)
+	var serials []string
+	var received []error
+	for events != nil || errs != nil {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			serials = append(serials, e.Serial)
+			if len(serials) == 2 {
+				cancel()
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			received = append(received, err)
+		}
+	}
+	if len(serials) != 2 || serials[0] != "020" || serials[1] != "022" || len(received) != 1 {
+		t.Errorf("StreamAuditEvents: expected 2 events and 1 error, found %v %v", serials, received)
+	}
+
+	// setup errors are sent before the channels are closed
+	events, errs = StreamAuditEvents(context.Background(), &testRecvTONetlinkConn{}, 1)
+	if err := <-errs; errors.Cause(err) != syscall.EBADF {
+		t.Errorf("StreamAuditEvents: expected %v, found %v", syscall.EBADF, err)
+	}
+	if _, ok := <-events; ok {
+		t.Errorf("StreamAuditEvents: expected the event channel to be closed")
+	}
+}
@@ -0,0 +1,137 @@
+package libaudit
+
+import (
+	"fmt"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// SyscallArgInterpreter converts the hex formatted value of a syscall argument register to its
+// symbolic form. args holds the values of all argument registers (a0-a3) of the syscall for
+// arguments whose meaning depends on another argument.
+type SyscallArgInterpreter func(value string, args [4]uint64) (string, error)
+
+// argInterpreter adapts the hex value printers to SyscallArgInterpreter
+func argInterpreter(print func(string) (string, error)) SyscallArgInterpreter {
+	return func(value string, args [4]uint64) (string, error) {
+		return print(value)
+	}
+}
+
+// argOpenMode interprets the mode of open like syscalls, it is only used with O_CREAT or O_TMPFILE (flags is
+// the index of the flags argument)
+func argOpenMode(flags int) SyscallArgInterpreter {
+	return func(value string, args [4]uint64) (string, error) {
+		if args[flags]&(syscall.O_CREAT|0x400000 /*__O_TMPFILE*/) == 0 {
+			return "", nil
+		}
+		return printModeShort(value, 16)
+	}
+}
+
+// argFcntl interprets the argument of fcntl depending on the command
+func argFcntl(value string, args [4]uint64) (string, error) {
+	switch args[1] {
+	case syscall.F_SETFL:
+		return printOpenFlags(value)
+	case syscall.F_SETFD:
+		if args[2] == syscall.FD_CLOEXEC {
+			return "FD_CLOEXEC", nil
+		}
+	}
+	return "", nil
+}
+
+// syscallArgInterpreters maps syscall names to the interpreters of their argument registers a0-a3,
+// arguments without interpreter are left as they are
+var syscallArgInterpreters = map[string][4]SyscallArgInterpreter{
+	"open":   {nil, argInterpreter(printOpenFlags), argOpenMode(1)},
+	"openat": {nil, nil, argInterpreter(printOpenFlags), argOpenMode(2)},
+	"socket": {argInterpreter(printSocketDomain), argInterpreter(printSocketType)},
+	"clone":  {argInterpreter(printCloneFlags)},
+	"mmap": {nil, nil, func(value string, args [4]uint64) (string, error) {
+		return printProt(value, 1)
+	}, argInterpreter(printMmap)},
+	"fcntl": {nil, argInterpreter(printFcntlCmd), argFcntl},
+}
+
+// RegisterSyscallArgInterpreter sets the interpreter of the argument register arg (0-3 for a0-a3) of
+// the syscall for InterpretSyscallArgs, replacing the built in one. A nil interpreter leaves the argument
+// as it is.
+// RegisterSyscallArgInterpreter is not synchronized with InterpretSyscallArgs, call it before
+// interpreting events.
+func RegisterSyscallArgInterpreter(syscallName string, arg int, interpreter SyscallArgInterpreter) error {
+	if arg < 0 || arg > 3 {
+		return fmt.Errorf("RegisterSyscallArgInterpreter failed: invalid argument a%d", arg)
+	}
+	interpreters := syscallArgInterpreters[syscallName]
+	interpreters[arg] = interpreter
+	syscallArgInterpreters[syscallName] = interpreters
+	return nil
+}
+
+// syscallName returns the name of the syscall field of ev whether it was interpreted or not, using the
+// syscall table of the arch field. It is empty if ev has no syscall field.
+func (ev *AuditEvent) syscallName() (string, error) {
+	if name, ok := ev.Data["syscall_name"]; ok {
+		return name, nil
+	}
+	name := ev.Data["syscall"]
+	if _, err := strconv.Atoi(name); err == nil {
+		_, arch := NativeArch()
+		if a, ok := ev.Data["arch"]; ok {
+			if arch, err = parseArch(a); err != nil {
+				return "", err
+			}
+		}
+		return AuditArchSyscallToName(name, arch)
+	}
+	return name, nil
+}
+
+// InterpretSyscallArgs replaces the hex formatted argument registers (a0-a3) of a SYSCALL event that
+// was parsed without interpretation by their symbolic form, i.e. the flags of open and openat
+// (O_WRONLY|O_CREAT|O_TRUNC), the mode of created files, the domain and type of sockets, clone flags,
+// the protection and flags of mmap and fcntl commands.
+// Only the syscalls with an interpreter (see RegisterSyscallArgInterpreter) are changed, arguments
+// of other syscalls are left as they are. The syscall is resolved with the table of the event's arch.
+func InterpretSyscallArgs(ev *AuditEvent) error {
+	name, err := ev.syscallName()
+	if err != nil {
+		return errors.Wrap(err, "InterpretSyscallArgs failed")
+	}
+	if len(name) == 0 {
+		return nil
+	}
+	interpreters, ok := syscallArgInterpreters[name]
+	if !ok {
+		return nil
+	}
+	var args [4]uint64
+	for i := range args {
+		if value, ok := ev.Data[fmt.Sprintf("a%d", i)]; ok {
+			v, err := strconv.ParseUint(value, 16, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("InterpretSyscallArgs failed: a%d", i))
+			}
+			args[i] = v
+		}
+	}
+	for i, interpret := range interpreters {
+		field := fmt.Sprintf("a%d", i)
+		value, ok := ev.Data[field]
+		if interpret == nil || !ok {
+			continue
+		}
+		result, err := interpret(value, args)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("InterpretSyscallArgs failed: %v", field))
+		}
+		if len(result) > 0 {
+			ev.Data[field] = result
+		}
+	}
+	return nil
+}
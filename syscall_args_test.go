@@ -0,0 +1,74 @@
+package libaudit
+
+import (
+	"testing"
+)
+
+func TestInterpretSyscallArgs(t *testing.T) {
+	var tests = []struct {
+		msg      string
+		expected map[string]string
+	}{
+		{
+			// openat(AT_FDCWD, path, O_WRONLY|O_CREAT|O_TRUNC|O_CLOEXEC, 0644)
+			`audit(1464163771.720:20): arch=c000003e syscall=257 success=yes exit=3 a0=ffffff9c a1=7ffd7a2b3c10 a2=80241 a3=1a4 items=2 ppid=1 pid=2`,
+			map[string]string{"a0": "ffffff9c", "a1": "7ffd7a2b3c10", "a2": "O_WRONLY|O_CREAT|O_TRUNC|O_CLOEXEC", "a3": "0644"},
+		},
+		{
+			// open(path, O_RDONLY), the mode is ignored without O_CREAT
+			`audit(1464163771.720:21): arch=c000003e syscall=2 success=yes exit=3 a0=7ffd7a2b3c10 a1=0 a2=1b6 a3=0 items=1 ppid=1 pid=2`,
+			map[string]string{"a1": "O_RDONLY", "a2": "1b6"},
+		},
+		{
+			// socket(AF_INET, SOCK_STREAM|SOCK_CLOEXEC, 0)
+			`audit(1464163771.720:22): arch=c000003e syscall=41 success=yes exit=3 a0=2 a1=80001 a2=0 a3=0 items=0 ppid=1 pid=2`,
+			map[string]string{"a0": "inet", "a1": "SOCK_STREAM|SOCK_CLOEXEC"},
+		},
+		{
+			`audit(1464163771.720:23): arch=c000003e syscall=56 success=yes exit=3 a0=1200011 a1=0 a2=0 a3=7f0a items=0 ppid=1 pid=2`,
+			map[string]string{"a0": "CLONE_CHILD_CLEARTID|CLONE_CHILD_SETTID|SIGCHLD"},
+		},
+		{
+			`audit(1464163771.720:24): arch=c000003e syscall=9 success=yes exit=0 a0=0 a1=1000 a2=5 a3=22 items=0 ppid=1 pid=2`,
+			map[string]string{"a2": "PROT_READ|PROT_EXEC", "a3": "MAP_PRIVATE|MAP_ANONYMOUS"},
+		},
+		{
+			`audit(1464163771.720:25): arch=c000003e syscall=72 success=yes exit=0 a0=3 a1=4 a2=800 a3=0 items=0 ppid=1 pid=2`,
+			map[string]string{"a1": "F_SETFL", "a2": "O_RDONLY|O_NONBLOCK"},
+		},
+		{
+			// syscalls without interpreter are left as they are
+			`audit(1464163771.720:26): arch=c000003e syscall=59 success=yes exit=0 a0=5 a1=6 a2=7 a3=8 items=0 ppid=1 pid=2`,
+			map[string]string{"a0": "5", "a1": "6", "a2": "7", "a3": "8"},
+		},
+	}
+	for _, tt := range tests {
+		x, err := ParseAuditEvent(tt.msg, AUDIT_SYSCALL, false)
+		if err != nil {
+			t.Fatalf("parse failed %v", err)
+		}
+		if err := InterpretSyscallArgs(x); err != nil {
+			t.Fatalf("InterpretSyscallArgs failed %v", err)
+		}
+		for field, value := range tt.expected {
+			if x.Data[field] != value {
+				t.Errorf("InterpretSyscallArgs: %v: expected %v=%v, found %v", tt.msg, field, value, x.Data[field])
+			}
+		}
+	}
+
+	// custom interpreters replace the built in ones
+	if err := RegisterSyscallArgInterpreter("execve", 0, func(value string, args [4]uint64) (string, error) {
+		return "fd" + value, nil
+	}); err != nil {
+		t.Fatalf("RegisterSyscallArgInterpreter failed %v", err)
+	}
+	defer delete(syscallArgInterpreters, "execve")
+	x, _ := ParseAuditEvent(tests[6].msg, AUDIT_SYSCALL, false)
+	if err := InterpretSyscallArgs(x); err != nil || x.Data["a0"] != "fd5" {
+		t.Errorf("InterpretSyscallArgs: expected custom interpretation, found %v %v", x.Data["a0"], err)
+	}
+	if err := RegisterSyscallArgInterpreter("execve", 4, nil); err == nil {
+		t.Errorf("RegisterSyscallArgInterpreter: expected error for a4")
+	}
+}
@@ -0,0 +1,74 @@
+package libaudit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// auditTTYStatus is the c compatible struct of audit_tty_status (audit.h)
+type auditTTYStatus struct {
+	Enabled   uint32 /* 1 = enabled, 0 = disabled */
+	LogPasswd uint32 /* 1 = enabled, 0 = disabled, since kernel 2.6.39 */
+}
+
+// AuditTTYStatus is the TTY auditing state of a process, see AuditGetTTYStatus.
+type AuditTTYStatus struct {
+	Enabled bool // the input on the terminals of the process is logged in TTY records
+	// LogPasswd logs the input while echo is off as well, i.e. the passwords typed in
+	LogPasswd bool
+}
+
+// AuditGetTTYStatus returns the TTY auditing state of the calling process.
+func AuditGetTTYStatus(s Netlink) (*AuditTTYStatus, error) {
+	var status auditTTYStatus
+
+	wb := newNetlinkAuditRequest(uint16(AUDIT_TTY_GET), syscall.AF_NETLINK, 0)
+	// no ack on success like with AUDIT_GET
+	wb.Header.Flags = syscall.NLM_F_REQUEST
+	if err := s.Send(wb); err != nil {
+		return nil, errors.Wrap(err, "AuditGetTTYStatus failed")
+	}
+	data, err := auditGetReplyData(s, AUDIT_TTY_GET, wb.Header.Seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "AuditGetTTYStatus failed")
+	}
+	// kernels before 2.6.39 lack log_passwd
+	if size := int(unsafe.Sizeof(status)); len(data) < size {
+		data = append(data, make([]byte, size-len(data))...)
+	}
+	if err := binary.Read(bytes.NewReader(data), nativeEndian(), &status); err != nil {
+		return nil, errors.Wrap(err, "AuditGetTTYStatus: binary read into auditTTYStatus failed")
+	}
+	return &AuditTTYStatus{Enabled: status.Enabled != 0, LogPasswd: status.LogPasswd != 0}, nil
+}
+
+// AuditSetTTYStatus enables or disables TTY auditing of the calling process like pam_tty_audit does for
+// login sessions. The state is inherited by the processes forked afterwards, other processes are not
+// affected. The keystrokes are reported in TTY records, see (*AuditEvent).TTYRecord.
+func AuditSetTTYStatus(s Netlink, status AuditTTYStatus) error {
+	var ts auditTTYStatus
+	if status.Enabled {
+		ts.Enabled = 1
+	}
+	if status.LogPasswd {
+		ts.LogPasswd = 1
+	}
+	buff := new(bytes.Buffer)
+	if err := binary.Write(buff, nativeEndian(), ts); err != nil {
+		return errors.Wrap(err, "AuditSetTTYStatus: binary write from auditTTYStatus failed")
+	}
+
+	wb := newNetlinkAuditRequest(uint16(AUDIT_TTY_SET), syscall.AF_NETLINK, int(unsafe.Sizeof(ts)))
+	wb.Data = append(wb.Data, buff.Bytes()[:]...)
+	if err := s.Send(wb); err != nil {
+		return errors.Wrap(err, "AuditSetTTYStatus failed")
+	}
+	if err := auditGetReply(s, syscall.Getpagesize(), 0, wb.Header.Seq); err != nil {
+		return errors.Wrap(err, "AuditSetTTYStatus failed")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package libaudit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestAuditTTYStatus(t *testing.T) {
+	current := auditTTYStatus{Enabled: 1}
+	short := false
+	s := &testReplyNetlinkConn{
+		reply: func(request NetlinkMessage) []NetlinkMessage {
+			switch auditConstant(request.Header.Type) {
+			case AUDIT_TTY_GET:
+				buff := new(bytes.Buffer)
+				binary.Write(buff, nativeEndian(), current)
+				data := buff.Bytes()
+				if short {
+					data = data[:4]
+				}
+				return []NetlinkMessage{testReplyMessage(request, uint16(AUDIT_TTY_GET), data)}
+			case AUDIT_TTY_SET:
+				binary.Read(bytes.NewReader(request.Data), nativeEndian(), &current)
+			}
+			return []NetlinkMessage{testAckMessage(request, 0)}
+		},
+	}
+	status, err := AuditGetTTYStatus(s)
+	if err != nil {
+		t.Fatalf("AuditGetTTYStatus failed %v", err)
+	}
+	if *status != (AuditTTYStatus{Enabled: true}) {
+		t.Errorf("AuditGetTTYStatus: unexpected status %+v", *status)
+	}
+
+	if err := AuditSetTTYStatus(s, AuditTTYStatus{Enabled: true, LogPasswd: true}); err != nil {
+		t.Fatalf("AuditSetTTYStatus failed %v", err)
+	}
+	if current != (auditTTYStatus{Enabled: 1, LogPasswd: 1}) {
+		t.Errorf("AuditSetTTYStatus: unexpected request %+v", current)
+	}
+	status, err = AuditGetTTYStatus(s)
+	if err != nil || *status != (AuditTTYStatus{Enabled: true, LogPasswd: true}) {
+		t.Errorf("AuditGetTTYStatus: expected the status set, found %+v (%v)", status, err)
+	}
+
+	// kernels before 2.6.39 only report enabled
+	short = true
+	status, err = AuditGetTTYStatus(s)
+	if err != nil || *status != (AuditTTYStatus{Enabled: true}) {
+		t.Errorf("AuditGetTTYStatus: unexpected status of a short reply %+v (%v)", status, err)
+	}
+}